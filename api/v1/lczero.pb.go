@@ -10,7 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	_ "google.golang.org/protobuf/types/known/durationpb"
-	_ "google.golang.org/protobuf/types/known/timestamppb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -127,6 +127,58 @@ func (ResourceType) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_lczero_proto_rawDescGZIP(), []int{1}
 }
 
+type TokenStatus int32
+
+const (
+	TokenStatus_TOKEN_STATUS_UNSPECIFIED TokenStatus = 0
+	TokenStatus_TOKEN_ACTIVE             TokenStatus = 1
+	TokenStatus_TOKEN_REVOKED            TokenStatus = 2 // Permanently disabled by its owner
+	TokenStatus_TOKEN_SUSPENDED          TokenStatus = 3 // Disabled by the server operators
+)
+
+// Enum value maps for TokenStatus.
+var (
+	TokenStatus_name = map[int32]string{
+		0: "TOKEN_STATUS_UNSPECIFIED",
+		1: "TOKEN_ACTIVE",
+		2: "TOKEN_REVOKED",
+		3: "TOKEN_SUSPENDED",
+	}
+	TokenStatus_value = map[string]int32{
+		"TOKEN_STATUS_UNSPECIFIED": 0,
+		"TOKEN_ACTIVE":             1,
+		"TOKEN_REVOKED":            2,
+		"TOKEN_SUSPENDED":          3,
+	}
+)
+
+func (x TokenStatus) Enum() *TokenStatus {
+	p := new(TokenStatus)
+	*p = x
+	return p
+}
+
+func (x TokenStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TokenStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_lczero_proto_enumTypes[2].Descriptor()
+}
+
+func (TokenStatus) Type() protoreflect.EnumType {
+	return &file_api_v1_lczero_proto_enumTypes[2]
+}
+
+func (x TokenStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TokenStatus.Descriptor instead.
+func (TokenStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{2}
+}
+
 type ShortOutcome int32
 
 const (
@@ -163,11 +215,11 @@ func (x ShortOutcome) String() string {
 }
 
 func (ShortOutcome) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_v1_lczero_proto_enumTypes[2].Descriptor()
+	return file_api_v1_lczero_proto_enumTypes[3].Descriptor()
 }
 
 func (ShortOutcome) Type() protoreflect.EnumType {
-	return &file_api_v1_lczero_proto_enumTypes[2]
+	return &file_api_v1_lczero_proto_enumTypes[3]
 }
 
 func (x ShortOutcome) Number() protoreflect.EnumNumber {
@@ -176,7 +228,7 @@ func (x ShortOutcome) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ShortOutcome.Descriptor instead.
 func (ShortOutcome) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{2}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{3}
 }
 
 type DetailedOutcome int32
@@ -221,11 +273,11 @@ func (x DetailedOutcome) String() string {
 }
 
 func (DetailedOutcome) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_v1_lczero_proto_enumTypes[3].Descriptor()
+	return file_api_v1_lczero_proto_enumTypes[4].Descriptor()
 }
 
 func (DetailedOutcome) Type() protoreflect.EnumType {
-	return &file_api_v1_lczero_proto_enumTypes[3]
+	return &file_api_v1_lczero_proto_enumTypes[4]
 }
 
 func (x DetailedOutcome) Number() protoreflect.EnumNumber {
@@ -234,7 +286,111 @@ func (x DetailedOutcome) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DetailedOutcome.Descriptor instead.
 func (DetailedOutcome) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{3}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{4}
+}
+
+type TuningMode int32
+
+const (
+	TuningMode_TUNING_MODE_UNSPECIFIED TuningMode = 0
+	TuningMode_TUNING_GRID             TuningMode = 1 // Every combination of the parameters' values
+	TuningMode_TUNING_RANDOM           TuningMode = 2 // num_samples points drawn uniformly
+	TuningMode_TUNING_SPSA             TuningMode = 3 // Perturbation pairs around values updated after every result
+	TuningMode_TUNING_BAYESIAN         TuningMode = 4 // Batches proposed by a Gaussian process fitted to the results so far
+)
+
+// Enum value maps for TuningMode.
+var (
+	TuningMode_name = map[int32]string{
+		0: "TUNING_MODE_UNSPECIFIED",
+		1: "TUNING_GRID",
+		2: "TUNING_RANDOM",
+		3: "TUNING_SPSA",
+		4: "TUNING_BAYESIAN",
+	}
+	TuningMode_value = map[string]int32{
+		"TUNING_MODE_UNSPECIFIED": 0,
+		"TUNING_GRID":             1,
+		"TUNING_RANDOM":           2,
+		"TUNING_SPSA":             3,
+		"TUNING_BAYESIAN":         4,
+	}
+)
+
+func (x TuningMode) Enum() *TuningMode {
+	p := new(TuningMode)
+	*p = x
+	return p
+}
+
+func (x TuningMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TuningMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_lczero_proto_enumTypes[5].Descriptor()
+}
+
+func (TuningMode) Type() protoreflect.EnumType {
+	return &file_api_v1_lczero_proto_enumTypes[5]
+}
+
+func (x TuningMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TuningMode.Descriptor instead.
+func (TuningMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{5}
+}
+
+type Acquisition int32
+
+const (
+	Acquisition_ACQUISITION_UNSPECIFIED Acquisition = 0 // Means ACQUISITION_EI
+	Acquisition_ACQUISITION_EI          Acquisition = 1 // Expected improvement over the best predicted Elo
+	Acquisition_ACQUISITION_UCB         Acquisition = 2 // Upper confidence bound: mean plus kappa standard deviations
+)
+
+// Enum value maps for Acquisition.
+var (
+	Acquisition_name = map[int32]string{
+		0: "ACQUISITION_UNSPECIFIED",
+		1: "ACQUISITION_EI",
+		2: "ACQUISITION_UCB",
+	}
+	Acquisition_value = map[string]int32{
+		"ACQUISITION_UNSPECIFIED": 0,
+		"ACQUISITION_EI":          1,
+		"ACQUISITION_UCB":         2,
+	}
+)
+
+func (x Acquisition) Enum() *Acquisition {
+	p := new(Acquisition)
+	*p = x
+	return p
+}
+
+func (x Acquisition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Acquisition) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_lczero_proto_enumTypes[6].Descriptor()
+}
+
+func (Acquisition) Type() protoreflect.EnumType {
+	return &file_api_v1_lczero_proto_enumTypes[6]
+}
+
+func (x Acquisition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Acquisition.Descriptor instead.
+func (Acquisition) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{6}
 }
 
 type ProgressResponse_Status int32
@@ -270,11 +426,11 @@ func (x ProgressResponse_Status) String() string {
 }
 
 func (ProgressResponse_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_v1_lczero_proto_enumTypes[4].Descriptor()
+	return file_api_v1_lczero_proto_enumTypes[7].Descriptor()
 }
 
 func (ProgressResponse_Status) Type() protoreflect.EnumType {
-	return &file_api_v1_lczero_proto_enumTypes[4]
+	return &file_api_v1_lczero_proto_enumTypes[7]
 }
 
 func (x ProgressResponse_Status) Number() protoreflect.EnumNumber {
@@ -283,7 +439,7 @@ func (x ProgressResponse_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ProgressResponse_Status.Descriptor instead.
 func (ProgressResponse_Status) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{17, 0}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{24, 0}
 }
 
 type CrashReport_CrashType int32
@@ -322,11 +478,11 @@ func (x CrashReport_CrashType) String() string {
 }
 
 func (CrashReport_CrashType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_v1_lczero_proto_enumTypes[5].Descriptor()
+	return file_api_v1_lczero_proto_enumTypes[8].Descriptor()
 }
 
 func (CrashReport_CrashType) Type() protoreflect.EnumType {
-	return &file_api_v1_lczero_proto_enumTypes[5]
+	return &file_api_v1_lczero_proto_enumTypes[8]
 }
 
 func (x CrashReport_CrashType) Number() protoreflect.EnumNumber {
@@ -335,7 +491,7 @@ func (x CrashReport_CrashType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CrashReport_CrashType.Descriptor instead.
 func (CrashReport_CrashType) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{19, 0}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{26, 0}
 }
 
 type ClientInfo struct {
@@ -345,6 +501,7 @@ type ClientInfo struct {
 	GpuType            string                 `protobuf:"bytes,3,opt,name=gpu_type,json=gpuType,proto3" json:"gpu_type,omitempty"`                                                                        // GPU description (e.g., "NVIDIA GeForce RTX 4090")
 	GpuId              int32                  `protobuf:"varint,4,opt,name=gpu_id,json=gpuId,proto3" json:"gpu_id,omitempty"`                                                                             // GPU device ID
 	SupportedTaskTypes []TaskType             `protobuf:"varint,5,rep,packed,name=supported_task_types,json=supportedTaskTypes,proto3,enum=lczero.api.v1.TaskType" json:"supported_task_types,omitempty"` // List of supported task types
+	EngineVersion      string                 `protobuf:"bytes,6,opt,name=engine_version,json=engineVersion,proto3" json:"engine_version,omitempty"`                                                      // lc0 engine version (e.g., "v0.31.0")
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -414,6 +571,13 @@ func (x *ClientInfo) GetSupportedTaskTypes() []TaskType {
 	return nil
 }
 
+func (x *ClientInfo) GetEngineVersion() string {
+	if x != nil {
+		return x.EngineVersion
+	}
+	return ""
+}
+
 type ResourceSpec struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sha256        string                 `protobuf:"bytes,1,opt,name=sha256,proto3" json:"sha256,omitempty"`                              // SHA256 hash of the uncompressed file
@@ -694,6 +858,7 @@ type TaskResponse struct {
 	//	*TaskResponse_Sprt
 	//	*TaskResponse_Tuning
 	Task          isTaskResponse_Task `protobuf_oneof:"task"`
+	Upgrade       *UpgradeAdvisory    `protobuf:"bytes,6,opt,name=upgrade,proto3" json:"upgrade,omitempty"` // Set while the client is older than the next required versions
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -778,6 +943,13 @@ func (x *TaskResponse) GetTuning() *TuningTask {
 	return nil
 }
 
+func (x *TaskResponse) GetUpgrade() *UpgradeAdvisory {
+	if x != nil {
+		return x.Upgrade
+	}
+	return nil
+}
+
 type isTaskResponse_Task interface {
 	isTaskResponse_Task()
 }
@@ -806,6 +978,92 @@ func (*TaskResponse_Sprt) isTaskResponse_Task() {}
 
 func (*TaskResponse_Tuning) isTaskResponse_Task() {}
 
+// UpgradeAdvisory asks a client that still meets the minimum versions to
+// upgrade before the next versions become the minimum.
+type UpgradeAdvisory struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ClientVersion     string                 `protobuf:"bytes,1,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"` // Version to upgrade the client to; empty if it is recent enough
+	EngineVersion     string                 `protobuf:"bytes,2,opt,name=engine_version,json=engineVersion,proto3" json:"engine_version,omitempty"` // Version to upgrade lc0 to; empty if it is recent enough
+	RequiredBy        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=required_by,json=requiredBy,proto3" json:"required_by,omitempty"`          // When older versions stop getting tasks; unset if not scheduled
+	ClientDownloadUrl string                 `protobuf:"bytes,4,opt,name=client_download_url,json=clientDownloadUrl,proto3" json:"client_download_url,omitempty"`
+	EngineDownloadUrl string                 `protobuf:"bytes,5,opt,name=engine_download_url,json=engineDownloadUrl,proto3" json:"engine_download_url,omitempty"`
+	Message           string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"` // Notice to show the user
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpgradeAdvisory) Reset() {
+	*x = UpgradeAdvisory{}
+	mi := &file_api_v1_lczero_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeAdvisory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeAdvisory) ProtoMessage() {}
+
+func (x *UpgradeAdvisory) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeAdvisory.ProtoReflect.Descriptor instead.
+func (*UpgradeAdvisory) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpgradeAdvisory) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *UpgradeAdvisory) GetEngineVersion() string {
+	if x != nil {
+		return x.EngineVersion
+	}
+	return ""
+}
+
+func (x *UpgradeAdvisory) GetRequiredBy() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequiredBy
+	}
+	return nil
+}
+
+func (x *UpgradeAdvisory) GetClientDownloadUrl() string {
+	if x != nil {
+		return x.ClientDownloadUrl
+	}
+	return ""
+}
+
+func (x *UpgradeAdvisory) GetEngineDownloadUrl() string {
+	if x != nil {
+		return x.EngineDownloadUrl
+	}
+	return ""
+}
+
+func (x *UpgradeAdvisory) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type EngineConfiguration struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Build         *BuildSpec             `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`     // Engine build specification
@@ -817,7 +1075,7 @@ type EngineConfiguration struct {
 
 func (x *EngineConfiguration) Reset() {
 	*x = EngineConfiguration{}
-	mi := &file_api_v1_lczero_proto_msgTypes[6]
+	mi := &file_api_v1_lczero_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -829,7 +1087,7 @@ func (x *EngineConfiguration) String() string {
 func (*EngineConfiguration) ProtoMessage() {}
 
 func (x *EngineConfiguration) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[6]
+	mi := &file_api_v1_lczero_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -842,7 +1100,7 @@ func (x *EngineConfiguration) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EngineConfiguration.ProtoReflect.Descriptor instead.
 func (*EngineConfiguration) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *EngineConfiguration) GetBuild() *BuildSpec {
@@ -877,7 +1135,7 @@ type TrainingTask struct {
 
 func (x *TrainingTask) Reset() {
 	*x = TrainingTask{}
-	mi := &file_api_v1_lczero_proto_msgTypes[7]
+	mi := &file_api_v1_lczero_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -889,7 +1147,7 @@ func (x *TrainingTask) String() string {
 func (*TrainingTask) ProtoMessage() {}
 
 func (x *TrainingTask) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[7]
+	mi := &file_api_v1_lczero_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,7 +1160,7 @@ func (x *TrainingTask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrainingTask.ProtoReflect.Descriptor instead.
 func (*TrainingTask) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{7}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TrainingTask) GetEngine() *EngineConfiguration {
@@ -927,18 +1185,19 @@ func (x *TrainingTask) GetNodesPerMove() int64 {
 }
 
 type MatchTask struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Baseline      *EngineConfiguration   `protobuf:"bytes,1,opt,name=baseline,proto3" json:"baseline,omitempty"`                                // Baseline engine
-	Candidate     *EngineConfiguration   `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`                              // Candidate engine
-	OpeningBook   *ResourceSpec          `protobuf:"bytes,3,opt,name=opening_book,json=openingBook,proto3" json:"opening_book,omitempty"`       // Optional opening book
-	NodesPerMove  int64                  `protobuf:"varint,4,opt,name=nodes_per_move,json=nodesPerMove,proto3" json:"nodes_per_move,omitempty"` // Nodes per move for matches
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Baseline         *EngineConfiguration   `protobuf:"bytes,1,opt,name=baseline,proto3" json:"baseline,omitempty"`                                            // Baseline engine
+	Candidate        *EngineConfiguration   `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`                                          // Candidate engine
+	OpeningBook      *ResourceSpec          `protobuf:"bytes,3,opt,name=opening_book,json=openingBook,proto3" json:"opening_book,omitempty"`                   // Optional opening book
+	NodesPerMove     int64                  `protobuf:"varint,4,opt,name=nodes_per_move,json=nodesPerMove,proto3" json:"nodes_per_move,omitempty"`             // Nodes per move for matches
+	CandidateIsWhite bool                   `protobuf:"varint,5,opt,name=candidate_is_white,json=candidateIsWhite,proto3" json:"candidate_is_white,omitempty"` // Colour the candidate plays; reported games must match it
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *MatchTask) Reset() {
 	*x = MatchTask{}
-	mi := &file_api_v1_lczero_proto_msgTypes[8]
+	mi := &file_api_v1_lczero_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -950,7 +1209,7 @@ func (x *MatchTask) String() string {
 func (*MatchTask) ProtoMessage() {}
 
 func (x *MatchTask) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[8]
+	mi := &file_api_v1_lczero_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,7 +1222,7 @@ func (x *MatchTask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchTask.ProtoReflect.Descriptor instead.
 func (*MatchTask) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{8}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *MatchTask) GetBaseline() *EngineConfiguration {
@@ -994,6 +1253,13 @@ func (x *MatchTask) GetNodesPerMove() int64 {
 	return 0
 }
 
+func (x *MatchTask) GetCandidateIsWhite() bool {
+	if x != nil {
+		return x.CandidateIsWhite
+	}
+	return false
+}
+
 type SprtTask struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Baseline      *EngineConfiguration   `protobuf:"bytes,1,opt,name=baseline,proto3" json:"baseline,omitempty"`                          // Baseline engine configuration
@@ -1006,7 +1272,7 @@ type SprtTask struct {
 
 func (x *SprtTask) Reset() {
 	*x = SprtTask{}
-	mi := &file_api_v1_lczero_proto_msgTypes[9]
+	mi := &file_api_v1_lczero_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1018,7 +1284,7 @@ func (x *SprtTask) String() string {
 func (*SprtTask) ProtoMessage() {}
 
 func (x *SprtTask) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[9]
+	mi := &file_api_v1_lczero_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1031,7 +1297,7 @@ func (x *SprtTask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SprtTask.ProtoReflect.Descriptor instead.
 func (*SprtTask) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{9}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SprtTask) GetBaseline() *EngineConfiguration {
@@ -1063,20 +1329,22 @@ func (x *SprtTask) GetTimeControl() *TimeControl {
 }
 
 type TuningTask struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Build            *BuildSpec             `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`                                                    // Engine build specification
-	Network          *ResourceSpec          `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`                                                // Network to use for all games
-	OpeningBook      *ResourceSpec          `protobuf:"bytes,3,opt,name=opening_book,json=openingBook,proto3" json:"opening_book,omitempty"`                     // Optional opening book
-	ParamSets        []*ParamSet            `protobuf:"bytes,4,rep,name=param_sets,json=paramSets,proto3" json:"param_sets,omitempty"`                           // A list of parameter sets to test
-	TimeControl      *TimeControl           `protobuf:"bytes,5,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"`                     // Game time control
-	GamesPerParamSet int32                  `protobuf:"varint,6,opt,name=games_per_param_set,json=gamesPerParamSet,proto3" json:"games_per_param_set,omitempty"` // Number of games to play for each parameter set
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Build       *BuildSpec             `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`                                // Engine build specification
+	Network     *ResourceSpec          `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`                            // Network to use for all games
+	OpeningBook *ResourceSpec          `protobuf:"bytes,3,opt,name=opening_book,json=openingBook,proto3" json:"opening_book,omitempty"` // Optional opening book
+	ParamSets   []*ParamSet            `protobuf:"bytes,4,rep,name=param_sets,json=paramSets,proto3" json:"param_sets,omitempty"`       // A list of parameter sets to test. In SPSA tuning these are
+	// the plus and minus sets of one iteration, which play each
+	// other; report the pairs under either set, from its side.
+	TimeControl      *TimeControl `protobuf:"bytes,5,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"`                     // Game time control
+	GamesPerParamSet int32        `protobuf:"varint,6,opt,name=games_per_param_set,json=gamesPerParamSet,proto3" json:"games_per_param_set,omitempty"` // Number of games to play for each parameter set
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *TuningTask) Reset() {
 	*x = TuningTask{}
-	mi := &file_api_v1_lczero_proto_msgTypes[10]
+	mi := &file_api_v1_lczero_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1088,7 +1356,7 @@ func (x *TuningTask) String() string {
 func (*TuningTask) ProtoMessage() {}
 
 func (x *TuningTask) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[10]
+	mi := &file_api_v1_lczero_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1101,7 +1369,7 @@ func (x *TuningTask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TuningTask.ProtoReflect.Descriptor instead.
 func (*TuningTask) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{10}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *TuningTask) GetBuild() *BuildSpec {
@@ -1156,7 +1424,7 @@ type ParamSet struct {
 
 func (x *ParamSet) Reset() {
 	*x = ParamSet{}
-	mi := &file_api_v1_lczero_proto_msgTypes[11]
+	mi := &file_api_v1_lczero_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1168,7 +1436,7 @@ func (x *ParamSet) String() string {
 func (*ParamSet) ProtoMessage() {}
 
 func (x *ParamSet) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[11]
+	mi := &file_api_v1_lczero_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1181,7 +1449,7 @@ func (x *ParamSet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ParamSet.ProtoReflect.Descriptor instead.
 func (*ParamSet) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{11}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ParamSet) GetParamSetId() string {
@@ -1208,7 +1476,7 @@ type MigrateCredentialsRequest struct {
 
 func (x *MigrateCredentialsRequest) Reset() {
 	*x = MigrateCredentialsRequest{}
-	mi := &file_api_v1_lczero_proto_msgTypes[12]
+	mi := &file_api_v1_lczero_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1220,7 +1488,7 @@ func (x *MigrateCredentialsRequest) String() string {
 func (*MigrateCredentialsRequest) ProtoMessage() {}
 
 func (x *MigrateCredentialsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[12]
+	mi := &file_api_v1_lczero_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1233,7 +1501,7 @@ func (x *MigrateCredentialsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MigrateCredentialsRequest.ProtoReflect.Descriptor instead.
 func (*MigrateCredentialsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{12}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *MigrateCredentialsRequest) GetUsername() string {
@@ -1258,7 +1526,7 @@ type AnonymousTokenRequest struct {
 
 func (x *AnonymousTokenRequest) Reset() {
 	*x = AnonymousTokenRequest{}
-	mi := &file_api_v1_lczero_proto_msgTypes[13]
+	mi := &file_api_v1_lczero_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1270,7 +1538,7 @@ func (x *AnonymousTokenRequest) String() string {
 func (*AnonymousTokenRequest) ProtoMessage() {}
 
 func (x *AnonymousTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[13]
+	mi := &file_api_v1_lczero_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1283,7 +1551,7 @@ func (x *AnonymousTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnonymousTokenRequest.ProtoReflect.Descriptor instead.
 func (*AnonymousTokenRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{13}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{14}
 }
 
 type AuthResponse struct {
@@ -1295,7 +1563,7 @@ type AuthResponse struct {
 
 func (x *AuthResponse) Reset() {
 	*x = AuthResponse{}
-	mi := &file_api_v1_lczero_proto_msgTypes[14]
+	mi := &file_api_v1_lczero_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1307,7 +1575,7 @@ func (x *AuthResponse) String() string {
 func (*AuthResponse) ProtoMessage() {}
 
 func (x *AuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[14]
+	mi := &file_api_v1_lczero_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1320,7 +1588,7 @@ func (x *AuthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
 func (*AuthResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{14}
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AuthResponse) GetToken() string {
@@ -1330,29 +1598,35 @@ func (x *AuthResponse) GetToken() string {
 	return ""
 }
 
-type TaskRequest struct {
+type TokenInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	ClientInfo    *ClientInfo            `protobuf:"bytes,2,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                     // Identifies the token in RevokeTokenRequest
+	TokenPrefix   string                 `protobuf:"bytes,2,opt,name=token_prefix,json=tokenPrefix,proto3" json:"token_prefix,omitempty"` // First characters of the token, to tell tokens apart
+	Status        TokenStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=lczero.api.v1.TokenStatus" json:"status,omitempty"`
+	IssuedReason  string                 `protobuf:"bytes,4,opt,name=issued_reason,json=issuedReason,proto3" json:"issued_reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ClientHost    string                 `protobuf:"bytes,7,opt,name=client_host,json=clientHost,proto3" json:"client_host,omitempty"`
+	ClientVersion string                 `protobuf:"bytes,8,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskRequest) Reset() {
-	*x = TaskRequest{}
-	mi := &file_api_v1_lczero_proto_msgTypes[15]
+func (x *TokenInfo) Reset() {
+	*x = TokenInfo{}
+	mi := &file_api_v1_lczero_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskRequest) String() string {
+func (x *TokenInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskRequest) ProtoMessage() {}
+func (*TokenInfo) ProtoMessage() {}
 
-func (x *TaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[15]
+func (x *TokenInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1363,56 +1637,3040 @@ func (x *TaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskRequest.ProtoReflect.Descriptor instead.
-func (*TaskRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use TokenInfo.ProtoReflect.Descriptor instead.
+func (*TokenInfo) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *TaskRequest) GetToken() string {
+func (x *TokenInfo) GetId() uint64 {
 	if x != nil {
-		return x.Token
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *TaskRequest) GetClientInfo() *ClientInfo {
+func (x *TokenInfo) GetTokenPrefix() string {
 	if x != nil {
-		return x.ClientInfo
+		return x.TokenPrefix
 	}
-	return nil
+	return ""
 }
 
-type ProgressReport struct {
-	state  protoimpl.MessageState `protogen:"open.v1"`
-	Token  string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	TaskId string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+func (x *TokenInfo) GetStatus() TokenStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TokenStatus_TOKEN_STATUS_UNSPECIFIED
+}
+
+func (x *TokenInfo) GetIssuedReason() string {
+	if x != nil {
+		return x.IssuedReason
+	}
+	return ""
+}
+
+func (x *TokenInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TokenInfo) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *TokenInfo) GetClientHost() string {
+	if x != nil {
+		return x.ClientHost
+	}
+	return ""
+}
+
+func (x *TokenInfo) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+type RevokeTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       uint64                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"` // Token to revoke; 0 revokes the caller's own token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenRequest) Reset() {
+	*x = RevokeTokenRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenRequest) ProtoMessage() {}
+
+func (x *RevokeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RevokeTokenRequest) GetTokenId() uint64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+type RevokeTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenResponse) Reset() {
+	*x = RevokeTokenResponse{}
+	mi := &file_api_v1_lczero_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenResponse) ProtoMessage() {}
+
+func (x *RevokeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{18}
+}
+
+type ListTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokensRequest) Reset() {
+	*x = ListTokensRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokensRequest) ProtoMessage() {}
+
+func (x *ListTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListTokensRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{19}
+}
+
+type ListTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*TokenInfo           `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokensResponse) Reset() {
+	*x = ListTokensResponse{}
+	mi := &file_api_v1_lczero_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokensResponse) ProtoMessage() {}
+
+func (x *ListTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListTokensResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListTokensResponse) GetTokens() []*TokenInfo {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type RotateTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateTokenRequest) Reset() {
+	*x = RotateTokenRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateTokenRequest) ProtoMessage() {}
+
+func (x *RotateTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateTokenRequest.ProtoReflect.Descriptor instead.
+func (*RotateTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{21}
+}
+
+type TaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: send the token as "authorization: Bearer <token>" metadata.
+	// Only read when the metadata is missing.
+	Token         string      `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ClientInfo    *ClientInfo `protobuf:"bytes,2,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskRequest) Reset() {
+	*x = TaskRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRequest) ProtoMessage() {}
+
+func (x *TaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRequest.ProtoReflect.Descriptor instead.
+func (*TaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TaskRequest) GetClientInfo() *ClientInfo {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return nil
+}
+
+type ProgressReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: send the token as "authorization: Bearer <token>" metadata.
+	// Only read when the metadata is missing.
+	Token  string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TaskId string `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	// Types that are valid to be assigned to Progress:
 	//
 	//	*ProgressReport_Training
 	//	*ProgressReport_Match
 	//	*ProgressReport_Sprt
 	//	*ProgressReport_Tuning
-	Progress      isProgressReport_Progress `protobuf_oneof:"progress"`
-	CrashReports  []*CrashReport            `protobuf:"bytes,7,rep,name=crash_reports,json=crashReports,proto3" json:"crash_reports,omitempty"`
+	Progress     isProgressReport_Progress `protobuf_oneof:"progress"`
+	CrashReports []*CrashReport            `protobuf:"bytes,7,rep,name=crash_reports,json=crashReports,proto3" json:"crash_reports,omitempty"`
+	Final        bool                      `protobuf:"varint,8,opt,name=final,proto3" json:"final,omitempty"` // Set on the last report of an assignment; it is complete once recorded
+	// Numbers the training, SPRT and tuning reports of an assignment from 1 up.
+	// A retried report repeats its number and is counted once.
+	Sequence      uint64 `protobuf:"varint,9,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressReport) Reset() {
+	*x = ProgressReport{}
+	mi := &file_api_v1_lczero_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressReport) ProtoMessage() {}
+
+func (x *ProgressReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressReport.ProtoReflect.Descriptor instead.
+func (*ProgressReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProgressReport) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ProgressReport) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ProgressReport) GetProgress() isProgressReport_Progress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *ProgressReport) GetTraining() *TrainingProgress {
+	if x != nil {
+		if x, ok := x.Progress.(*ProgressReport_Training); ok {
+			return x.Training
+		}
+	}
+	return nil
+}
+
+func (x *ProgressReport) GetMatch() *MatchProgress {
+	if x != nil {
+		if x, ok := x.Progress.(*ProgressReport_Match); ok {
+			return x.Match
+		}
+	}
+	return nil
+}
+
+func (x *ProgressReport) GetSprt() *SprtProgress {
+	if x != nil {
+		if x, ok := x.Progress.(*ProgressReport_Sprt); ok {
+			return x.Sprt
+		}
+	}
+	return nil
+}
+
+func (x *ProgressReport) GetTuning() *TuningProgress {
+	if x != nil {
+		if x, ok := x.Progress.(*ProgressReport_Tuning); ok {
+			return x.Tuning
+		}
+	}
+	return nil
+}
+
+func (x *ProgressReport) GetCrashReports() []*CrashReport {
+	if x != nil {
+		return x.CrashReports
+	}
+	return nil
+}
+
+func (x *ProgressReport) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+func (x *ProgressReport) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type isProgressReport_Progress interface {
+	isProgressReport_Progress()
+}
+
+type ProgressReport_Training struct {
+	Training *TrainingProgress `protobuf:"bytes,3,opt,name=training,proto3,oneof"`
+}
+
+type ProgressReport_Match struct {
+	Match *MatchProgress `protobuf:"bytes,4,opt,name=match,proto3,oneof"`
+}
+
+type ProgressReport_Sprt struct {
+	Sprt *SprtProgress `protobuf:"bytes,5,opt,name=sprt,proto3,oneof"`
+}
+
+type ProgressReport_Tuning struct {
+	Tuning *TuningProgress `protobuf:"bytes,6,opt,name=tuning,proto3,oneof"`
+}
+
+func (*ProgressReport_Training) isProgressReport_Progress() {}
+
+func (*ProgressReport_Match) isProgressReport_Progress() {}
+
+func (*ProgressReport_Sprt) isProgressReport_Progress() {}
+
+func (*ProgressReport_Tuning) isProgressReport_Progress() {}
+
+type ProgressResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Status        ProgressResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=lczero.api.v1.ProgressResponse_Status" json:"status,omitempty"`
+	Upgrade       *UpgradeAdvisory        `protobuf:"bytes,2,opt,name=upgrade,proto3" json:"upgrade,omitempty"` // Set while the client is older than the next required versions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressResponse) Reset() {
+	*x = ProgressResponse{}
+	mi := &file_api_v1_lczero_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressResponse) ProtoMessage() {}
+
+func (x *ProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressResponse.ProtoReflect.Descriptor instead.
+func (*ProgressResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ProgressResponse) GetStatus() ProgressResponse_Status {
+	if x != nil {
+		return x.Status
+	}
+	return ProgressResponse_STATUS_UNSPECIFIED
+}
+
+func (x *ProgressResponse) GetUpgrade() *UpgradeAdvisory {
+	if x != nil {
+		return x.Upgrade
+	}
+	return nil
+}
+
+type GameData struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TrainingDataFrame []byte                 `protobuf:"bytes,1,opt,name=training_data_frame,json=trainingDataFrame,proto3" json:"training_data_frame,omitempty"`
+	CompressedPgn     []byte                 `protobuf:"bytes,2,opt,name=compressed_pgn,json=compressedPgn,proto3" json:"compressed_pgn,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GameData) Reset() {
+	*x = GameData{}
+	mi := &file_api_v1_lczero_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameData) ProtoMessage() {}
+
+func (x *GameData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameData.ProtoReflect.Descriptor instead.
+func (*GameData) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GameData) GetTrainingDataFrame() []byte {
+	if x != nil {
+		return x.TrainingDataFrame
+	}
+	return nil
+}
+
+func (x *GameData) GetCompressedPgn() []byte {
+	if x != nil {
+		return x.CompressedPgn
+	}
+	return nil
+}
+
+type CrashReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          CrashReport_CrashType  `protobuf:"varint,1,opt,name=type,proto3,enum=lczero.api.v1.CrashReport_CrashType" json:"type,omitempty"`
+	MachineInfo   string                 `protobuf:"bytes,2,opt,name=machine_info,json=machineInfo,proto3" json:"machine_info,omitempty"`                                          // Minimal, privacy-friendly machine info
+	Logs          map[string][]byte      `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // e.g., "stdout", "stderr", "engine_log"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrashReport) Reset() {
+	*x = CrashReport{}
+	mi := &file_api_v1_lczero_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashReport) ProtoMessage() {}
+
+func (x *CrashReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashReport.ProtoReflect.Descriptor instead.
+func (*CrashReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CrashReport) GetType() CrashReport_CrashType {
+	if x != nil {
+		return x.Type
+	}
+	return CrashReport_CRASH_TYPE_UNSPECIFIED
+}
+
+func (x *CrashReport) GetMachineInfo() string {
+	if x != nil {
+		return x.MachineInfo
+	}
+	return ""
+}
+
+func (x *CrashReport) GetLogs() map[string][]byte {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type TrainingProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Games         []*GameData            `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrainingProgress) Reset() {
+	*x = TrainingProgress{}
+	mi := &file_api_v1_lczero_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainingProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainingProgress) ProtoMessage() {}
+
+func (x *TrainingProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainingProgress.ProtoReflect.Descriptor instead.
+func (*TrainingProgress) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TrainingProgress) GetGames() []*GameData {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+type MatchGame struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Pgn              string                 `protobuf:"bytes,1,opt,name=pgn,proto3" json:"pgn,omitempty"`
+	ShortOutcome     ShortOutcome           `protobuf:"varint,2,opt,name=short_outcome,json=shortOutcome,proto3,enum=lczero.api.v1.ShortOutcome" json:"short_outcome,omitempty"`
+	DetailedOutcome  DetailedOutcome        `protobuf:"varint,3,opt,name=detailed_outcome,json=detailedOutcome,proto3,enum=lczero.api.v1.DetailedOutcome" json:"detailed_outcome,omitempty"`
+	CandidateIsWhite bool                   `protobuf:"varint,4,opt,name=candidate_is_white,json=candidateIsWhite,proto3" json:"candidate_is_white,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MatchGame) Reset() {
+	*x = MatchGame{}
+	mi := &file_api_v1_lczero_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchGame) ProtoMessage() {}
+
+func (x *MatchGame) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchGame.ProtoReflect.Descriptor instead.
+func (*MatchGame) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MatchGame) GetPgn() string {
+	if x != nil {
+		return x.Pgn
+	}
+	return ""
+}
+
+func (x *MatchGame) GetShortOutcome() ShortOutcome {
+	if x != nil {
+		return x.ShortOutcome
+	}
+	return ShortOutcome_SHORT_OUTCOME_UNSPECIFIED
+}
+
+func (x *MatchGame) GetDetailedOutcome() DetailedOutcome {
+	if x != nil {
+		return x.DetailedOutcome
+	}
+	return DetailedOutcome_DETAILED_OUTCOME_UNSPECIFIED
+}
+
+func (x *MatchGame) GetCandidateIsWhite() bool {
+	if x != nil {
+		return x.CandidateIsWhite
+	}
+	return false
+}
+
+type MatchProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Games         []*MatchGame           `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchProgress) Reset() {
+	*x = MatchProgress{}
+	mi := &file_api_v1_lczero_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchProgress) ProtoMessage() {}
+
+func (x *MatchProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchProgress.ProtoReflect.Descriptor instead.
+func (*MatchProgress) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MatchProgress) GetGames() []*MatchGame {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+// A pair is counted once both games are decided. If the second game is still
+// running, report it undecided: the server holds game1 until a later report
+// sends the pair again with game1 unset and game2 decided.
+type SprtPairReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Game1         *MatchGame             `protobuf:"bytes,1,opt,name=game1,proto3" json:"game1,omitempty"`       // Unset when completing a held pair
+	Game2         *MatchGame             `protobuf:"bytes,2,opt,name=game2,proto3,oneof" json:"game2,omitempty"` // The second game might not run if the first crashes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SprtPairReport) Reset() {
+	*x = SprtPairReport{}
+	mi := &file_api_v1_lczero_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SprtPairReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SprtPairReport) ProtoMessage() {}
+
+func (x *SprtPairReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SprtPairReport.ProtoReflect.Descriptor instead.
+func (*SprtPairReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SprtPairReport) GetGame1() *MatchGame {
+	if x != nil {
+		return x.Game1
+	}
+	return nil
+}
+
+func (x *SprtPairReport) GetGame2() *MatchGame {
+	if x != nil {
+		return x.Game2
+	}
+	return nil
+}
+
+type SprtProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pairs         []*SprtPairReport      `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	CurrentLlr    float64                `protobuf:"fixed64,2,opt,name=current_llr,json=currentLlr,proto3" json:"current_llr,omitempty"` // Current Log-Likelihood Ratio
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SprtProgress) Reset() {
+	*x = SprtProgress{}
+	mi := &file_api_v1_lczero_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SprtProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SprtProgress) ProtoMessage() {}
+
+func (x *SprtProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SprtProgress.ProtoReflect.Descriptor instead.
+func (*SprtProgress) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SprtProgress) GetPairs() []*SprtPairReport {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *SprtProgress) GetCurrentLlr() float64 {
+	if x != nil {
+		return x.CurrentLlr
+	}
+	return 0
+}
+
+type TuningPairResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Game1         *MatchGame             `protobuf:"bytes,1,opt,name=game1,proto3" json:"game1,omitempty"`
+	Game2         *MatchGame             `protobuf:"bytes,2,opt,name=game2,proto3,oneof" json:"game2,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TuningPairResult) Reset() {
+	*x = TuningPairResult{}
+	mi := &file_api_v1_lczero_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuningPairResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningPairResult) ProtoMessage() {}
+
+func (x *TuningPairResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningPairResult.ProtoReflect.Descriptor instead.
+func (*TuningPairResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TuningPairResult) GetGame1() *MatchGame {
+	if x != nil {
+		return x.Game1
+	}
+	return nil
+}
+
+func (x *TuningPairResult) GetGame2() *MatchGame {
+	if x != nil {
+		return x.Game2
+	}
+	return nil
+}
+
+type TuningParamSetResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParamSetId    string                 `protobuf:"bytes,1,opt,name=param_set_id,json=paramSetId,proto3" json:"param_set_id,omitempty"`
+	Pairs         []*TuningPairResult    `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TuningParamSetResult) Reset() {
+	*x = TuningParamSetResult{}
+	mi := &file_api_v1_lczero_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuningParamSetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningParamSetResult) ProtoMessage() {}
+
+func (x *TuningParamSetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningParamSetResult.ProtoReflect.Descriptor instead.
+func (*TuningParamSetResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TuningParamSetResult) GetParamSetId() string {
+	if x != nil {
+		return x.ParamSetId
+	}
+	return ""
+}
+
+func (x *TuningParamSetResult) GetPairs() []*TuningPairResult {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+type TuningProgress struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Results       []*TuningParamSetResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TuningProgress) Reset() {
+	*x = TuningProgress{}
+	mi := &file_api_v1_lczero_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuningProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningProgress) ProtoMessage() {}
+
+func (x *TuningProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningProgress.ProtoReflect.Descriptor instead.
+func (*TuningProgress) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TuningProgress) GetResults() []*TuningParamSetResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type TrainingRun struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	TrainParameters string                 `protobuf:"bytes,3,opt,name=train_parameters,json=trainParameters,proto3" json:"train_parameters,omitempty"`
+	MatchParameters string                 `protobuf:"bytes,4,opt,name=match_parameters,json=matchParameters,proto3" json:"match_parameters,omitempty"`
+	TrainBook       string                 `protobuf:"bytes,5,opt,name=train_book,json=trainBook,proto3" json:"train_book,omitempty"`
+	MatchBook       string                 `protobuf:"bytes,6,opt,name=match_book,json=matchBook,proto3" json:"match_book,omitempty"`
+	Active          bool                   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	BestNetworkId   uint64                 `protobuf:"varint,8,opt,name=best_network_id,json=bestNetworkId,proto3" json:"best_network_id,omitempty"` // 0 if none yet
+	LastNetwork     uint64                 `protobuf:"varint,9,opt,name=last_network,json=lastNetwork,proto3" json:"last_network,omitempty"`
+	LastGame        uint64                 `protobuf:"varint,10,opt,name=last_game,json=lastGame,proto3" json:"last_game,omitempty"`
+	PermissionExpr  string                 `protobuf:"bytes,11,opt,name=permission_expr,json=permissionExpr,proto3" json:"permission_expr,omitempty"`
+	MultiNetMode    bool                   `protobuf:"varint,12,opt,name=multi_net_mode,json=multiNetMode,proto3" json:"multi_net_mode,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TrainingRun) Reset() {
+	*x = TrainingRun{}
+	mi := &file_api_v1_lczero_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainingRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainingRun) ProtoMessage() {}
+
+func (x *TrainingRun) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainingRun.ProtoReflect.Descriptor instead.
+func (*TrainingRun) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TrainingRun) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TrainingRun) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TrainingRun) GetTrainParameters() string {
+	if x != nil {
+		return x.TrainParameters
+	}
+	return ""
+}
+
+func (x *TrainingRun) GetMatchParameters() string {
+	if x != nil {
+		return x.MatchParameters
+	}
+	return ""
+}
+
+func (x *TrainingRun) GetTrainBook() string {
+	if x != nil {
+		return x.TrainBook
+	}
+	return ""
+}
+
+func (x *TrainingRun) GetMatchBook() string {
+	if x != nil {
+		return x.MatchBook
+	}
+	return ""
+}
+
+func (x *TrainingRun) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *TrainingRun) GetBestNetworkId() uint64 {
+	if x != nil {
+		return x.BestNetworkId
+	}
+	return 0
+}
+
+func (x *TrainingRun) GetLastNetwork() uint64 {
+	if x != nil {
+		return x.LastNetwork
+	}
+	return 0
+}
+
+func (x *TrainingRun) GetLastGame() uint64 {
+	if x != nil {
+		return x.LastGame
+	}
+	return 0
+}
+
+func (x *TrainingRun) GetPermissionExpr() string {
+	if x != nil {
+		return x.PermissionExpr
+	}
+	return ""
+}
+
+func (x *TrainingRun) GetMultiNetMode() bool {
+	if x != nil {
+		return x.MultiNetMode
+	}
+	return false
+}
+
+type CreateTrainingRunRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Description     string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	TrainParameters string                 `protobuf:"bytes,2,opt,name=train_parameters,json=trainParameters,proto3" json:"train_parameters,omitempty"`
+	MatchParameters string                 `protobuf:"bytes,3,opt,name=match_parameters,json=matchParameters,proto3" json:"match_parameters,omitempty"`
+	TrainBook       string                 `protobuf:"bytes,4,opt,name=train_book,json=trainBook,proto3" json:"train_book,omitempty"`
+	MatchBook       string                 `protobuf:"bytes,5,opt,name=match_book,json=matchBook,proto3" json:"match_book,omitempty"`
+	BestNetworkId   uint64                 `protobuf:"varint,6,opt,name=best_network_id,json=bestNetworkId,proto3" json:"best_network_id,omitempty"` // Optional starting network
+	PermissionExpr  string                 `protobuf:"bytes,7,opt,name=permission_expr,json=permissionExpr,proto3" json:"permission_expr,omitempty"`
+	MultiNetMode    bool                   `protobuf:"varint,8,opt,name=multi_net_mode,json=multiNetMode,proto3" json:"multi_net_mode,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateTrainingRunRequest) Reset() {
+	*x = CreateTrainingRunRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTrainingRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTrainingRunRequest) ProtoMessage() {}
+
+func (x *CreateTrainingRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTrainingRunRequest.ProtoReflect.Descriptor instead.
+func (*CreateTrainingRunRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateTrainingRunRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTrainingRunRequest) GetTrainParameters() string {
+	if x != nil {
+		return x.TrainParameters
+	}
+	return ""
+}
+
+func (x *CreateTrainingRunRequest) GetMatchParameters() string {
+	if x != nil {
+		return x.MatchParameters
+	}
+	return ""
+}
+
+func (x *CreateTrainingRunRequest) GetTrainBook() string {
+	if x != nil {
+		return x.TrainBook
+	}
+	return ""
+}
+
+func (x *CreateTrainingRunRequest) GetMatchBook() string {
+	if x != nil {
+		return x.MatchBook
+	}
+	return ""
+}
+
+func (x *CreateTrainingRunRequest) GetBestNetworkId() uint64 {
+	if x != nil {
+		return x.BestNetworkId
+	}
+	return 0
+}
+
+func (x *CreateTrainingRunRequest) GetPermissionExpr() string {
+	if x != nil {
+		return x.PermissionExpr
+	}
+	return ""
+}
+
+func (x *CreateTrainingRunRequest) GetMultiNetMode() bool {
+	if x != nil {
+		return x.MultiNetMode
+	}
+	return false
+}
+
+type UpdateTrainingRunRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description     *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	TrainParameters *string                `protobuf:"bytes,3,opt,name=train_parameters,json=trainParameters,proto3,oneof" json:"train_parameters,omitempty"`
+	MatchParameters *string                `protobuf:"bytes,4,opt,name=match_parameters,json=matchParameters,proto3,oneof" json:"match_parameters,omitempty"`
+	TrainBook       *string                `protobuf:"bytes,5,opt,name=train_book,json=trainBook,proto3,oneof" json:"train_book,omitempty"`
+	MatchBook       *string                `protobuf:"bytes,6,opt,name=match_book,json=matchBook,proto3,oneof" json:"match_book,omitempty"`
+	BestNetworkId   *uint64                `protobuf:"varint,7,opt,name=best_network_id,json=bestNetworkId,proto3,oneof" json:"best_network_id,omitempty"`
+	PermissionExpr  *string                `protobuf:"bytes,8,opt,name=permission_expr,json=permissionExpr,proto3,oneof" json:"permission_expr,omitempty"`
+	MultiNetMode    *bool                  `protobuf:"varint,9,opt,name=multi_net_mode,json=multiNetMode,proto3,oneof" json:"multi_net_mode,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateTrainingRunRequest) Reset() {
+	*x = UpdateTrainingRunRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTrainingRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTrainingRunRequest) ProtoMessage() {}
+
+func (x *UpdateTrainingRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTrainingRunRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTrainingRunRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateTrainingRunRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTrainingRunRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTrainingRunRequest) GetTrainParameters() string {
+	if x != nil && x.TrainParameters != nil {
+		return *x.TrainParameters
+	}
+	return ""
+}
+
+func (x *UpdateTrainingRunRequest) GetMatchParameters() string {
+	if x != nil && x.MatchParameters != nil {
+		return *x.MatchParameters
+	}
+	return ""
+}
+
+func (x *UpdateTrainingRunRequest) GetTrainBook() string {
+	if x != nil && x.TrainBook != nil {
+		return *x.TrainBook
+	}
+	return ""
+}
+
+func (x *UpdateTrainingRunRequest) GetMatchBook() string {
+	if x != nil && x.MatchBook != nil {
+		return *x.MatchBook
+	}
+	return ""
+}
+
+func (x *UpdateTrainingRunRequest) GetBestNetworkId() uint64 {
+	if x != nil && x.BestNetworkId != nil {
+		return *x.BestNetworkId
+	}
+	return 0
+}
+
+func (x *UpdateTrainingRunRequest) GetPermissionExpr() string {
+	if x != nil && x.PermissionExpr != nil {
+		return *x.PermissionExpr
+	}
+	return ""
+}
+
+func (x *UpdateTrainingRunRequest) GetMultiNetMode() bool {
+	if x != nil && x.MultiNetMode != nil {
+		return *x.MultiNetMode
+	}
+	return false
+}
+
+type SetActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetActiveRequest) Reset() {
+	*x = SetActiveRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetActiveRequest) ProtoMessage() {}
+
+func (x *SetActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetActiveRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetActiveRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type ListTrainingRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrainingRunsRequest) Reset() {
+	*x = ListTrainingRunsRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrainingRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrainingRunsRequest) ProtoMessage() {}
+
+func (x *ListTrainingRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrainingRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListTrainingRunsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{39}
+}
+
+type ListTrainingRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrainingRuns  []*TrainingRun         `protobuf:"bytes,1,rep,name=training_runs,json=trainingRuns,proto3" json:"training_runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrainingRunsResponse) Reset() {
+	*x = ListTrainingRunsResponse{}
+	mi := &file_api_v1_lczero_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrainingRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrainingRunsResponse) ProtoMessage() {}
+
+func (x *ListTrainingRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrainingRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListTrainingRunsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListTrainingRunsResponse) GetTrainingRuns() []*TrainingRun {
+	if x != nil {
+		return x.TrainingRuns
+	}
+	return nil
+}
+
+type TrainingTaskConfig struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId          uint64                 `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // Base task, as in task assignments
+	TrainingRunId   uint64                 `protobuf:"varint,3,opt,name=training_run_id,json=trainingRunId,proto3" json:"training_run_id,omitempty"`
+	TrainBookId     uint64                 `protobuf:"varint,4,opt,name=train_book_id,json=trainBookId,proto3" json:"train_book_id,omitempty"` // 0 if none
+	MatchBookId     uint64                 `protobuf:"varint,5,opt,name=match_book_id,json=matchBookId,proto3" json:"match_book_id,omitempty"` // 0 if none
+	TrainParameters string                 `protobuf:"bytes,6,opt,name=train_parameters,json=trainParameters,proto3" json:"train_parameters,omitempty"`
+	MatchParameters string                 `protobuf:"bytes,7,opt,name=match_parameters,json=matchParameters,proto3" json:"match_parameters,omitempty"`
+	BestNetworkId   uint64                 `protobuf:"varint,8,opt,name=best_network_id,json=bestNetworkId,proto3" json:"best_network_id,omitempty"`
+	Active          bool                   `protobuf:"varint,9,opt,name=active,proto3" json:"active,omitempty"`
+	Description     string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TrainingTaskConfig) Reset() {
+	*x = TrainingTaskConfig{}
+	mi := &file_api_v1_lczero_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainingTaskConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainingTaskConfig) ProtoMessage() {}
+
+func (x *TrainingTaskConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainingTaskConfig.ProtoReflect.Descriptor instead.
+func (*TrainingTaskConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TrainingTaskConfig) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TrainingTaskConfig) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TrainingTaskConfig) GetTrainingRunId() uint64 {
+	if x != nil {
+		return x.TrainingRunId
+	}
+	return 0
+}
+
+func (x *TrainingTaskConfig) GetTrainBookId() uint64 {
+	if x != nil {
+		return x.TrainBookId
+	}
+	return 0
+}
+
+func (x *TrainingTaskConfig) GetMatchBookId() uint64 {
+	if x != nil {
+		return x.MatchBookId
+	}
+	return 0
+}
+
+func (x *TrainingTaskConfig) GetTrainParameters() string {
+	if x != nil {
+		return x.TrainParameters
+	}
+	return ""
+}
+
+func (x *TrainingTaskConfig) GetMatchParameters() string {
+	if x != nil {
+		return x.MatchParameters
+	}
+	return ""
+}
+
+func (x *TrainingTaskConfig) GetBestNetworkId() uint64 {
+	if x != nil {
+		return x.BestNetworkId
+	}
+	return 0
+}
+
+func (x *TrainingTaskConfig) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *TrainingTaskConfig) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CreateTrainingTaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TrainingRunId   uint64                 `protobuf:"varint,1,opt,name=training_run_id,json=trainingRunId,proto3" json:"training_run_id,omitempty"`
+	TrainBookId     uint64                 `protobuf:"varint,2,opt,name=train_book_id,json=trainBookId,proto3" json:"train_book_id,omitempty"`
+	MatchBookId     uint64                 `protobuf:"varint,3,opt,name=match_book_id,json=matchBookId,proto3" json:"match_book_id,omitempty"`
+	TrainParameters string                 `protobuf:"bytes,4,opt,name=train_parameters,json=trainParameters,proto3" json:"train_parameters,omitempty"`
+	MatchParameters string                 `protobuf:"bytes,5,opt,name=match_parameters,json=matchParameters,proto3" json:"match_parameters,omitempty"`
+	BestNetworkId   uint64                 `protobuf:"varint,6,opt,name=best_network_id,json=bestNetworkId,proto3" json:"best_network_id,omitempty"`
+	Description     string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateTrainingTaskRequest) Reset() {
+	*x = CreateTrainingTaskRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTrainingTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTrainingTaskRequest) ProtoMessage() {}
+
+func (x *CreateTrainingTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTrainingTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTrainingTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateTrainingTaskRequest) GetTrainingRunId() uint64 {
+	if x != nil {
+		return x.TrainingRunId
+	}
+	return 0
+}
+
+func (x *CreateTrainingTaskRequest) GetTrainBookId() uint64 {
+	if x != nil {
+		return x.TrainBookId
+	}
+	return 0
+}
+
+func (x *CreateTrainingTaskRequest) GetMatchBookId() uint64 {
+	if x != nil {
+		return x.MatchBookId
+	}
+	return 0
+}
+
+func (x *CreateTrainingTaskRequest) GetTrainParameters() string {
+	if x != nil {
+		return x.TrainParameters
+	}
+	return ""
+}
+
+func (x *CreateTrainingTaskRequest) GetMatchParameters() string {
+	if x != nil {
+		return x.MatchParameters
+	}
+	return ""
+}
+
+func (x *CreateTrainingTaskRequest) GetBestNetworkId() uint64 {
+	if x != nil {
+		return x.BestNetworkId
+	}
+	return 0
+}
+
+func (x *CreateTrainingTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type UpdateTrainingTaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TrainBookId     *uint64                `protobuf:"varint,2,opt,name=train_book_id,json=trainBookId,proto3,oneof" json:"train_book_id,omitempty"`
+	MatchBookId     *uint64                `protobuf:"varint,3,opt,name=match_book_id,json=matchBookId,proto3,oneof" json:"match_book_id,omitempty"`
+	TrainParameters *string                `protobuf:"bytes,4,opt,name=train_parameters,json=trainParameters,proto3,oneof" json:"train_parameters,omitempty"`
+	MatchParameters *string                `protobuf:"bytes,5,opt,name=match_parameters,json=matchParameters,proto3,oneof" json:"match_parameters,omitempty"`
+	BestNetworkId   *uint64                `protobuf:"varint,6,opt,name=best_network_id,json=bestNetworkId,proto3,oneof" json:"best_network_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateTrainingTaskRequest) Reset() {
+	*x = UpdateTrainingTaskRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTrainingTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTrainingTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTrainingTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTrainingTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTrainingTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateTrainingTaskRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTrainingTaskRequest) GetTrainBookId() uint64 {
+	if x != nil && x.TrainBookId != nil {
+		return *x.TrainBookId
+	}
+	return 0
+}
+
+func (x *UpdateTrainingTaskRequest) GetMatchBookId() uint64 {
+	if x != nil && x.MatchBookId != nil {
+		return *x.MatchBookId
+	}
+	return 0
+}
+
+func (x *UpdateTrainingTaskRequest) GetTrainParameters() string {
+	if x != nil && x.TrainParameters != nil {
+		return *x.TrainParameters
+	}
+	return ""
+}
+
+func (x *UpdateTrainingTaskRequest) GetMatchParameters() string {
+	if x != nil && x.MatchParameters != nil {
+		return *x.MatchParameters
+	}
+	return ""
+}
+
+func (x *UpdateTrainingTaskRequest) GetBestNetworkId() uint64 {
+	if x != nil && x.BestNetworkId != nil {
+		return *x.BestNetworkId
+	}
+	return 0
+}
+
+type ListTrainingTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrainingRunId uint64                 `protobuf:"varint,1,opt,name=training_run_id,json=trainingRunId,proto3" json:"training_run_id,omitempty"` // 0 lists the tasks of every run
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrainingTasksRequest) Reset() {
+	*x = ListTrainingTasksRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrainingTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrainingTasksRequest) ProtoMessage() {}
+
+func (x *ListTrainingTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrainingTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTrainingTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListTrainingTasksRequest) GetTrainingRunId() uint64 {
+	if x != nil {
+		return x.TrainingRunId
+	}
+	return 0
+}
+
+type ListTrainingTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrainingTasks []*TrainingTaskConfig  `protobuf:"bytes,1,rep,name=training_tasks,json=trainingTasks,proto3" json:"training_tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrainingTasksResponse) Reset() {
+	*x = ListTrainingTasksResponse{}
+	mi := &file_api_v1_lczero_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrainingTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrainingTasksResponse) ProtoMessage() {}
+
+func (x *ListTrainingTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrainingTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTrainingTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListTrainingTasksResponse) GetTrainingTasks() []*TrainingTaskConfig {
+	if x != nil {
+		return x.TrainingTasks
+	}
+	return nil
+}
+
+type CreateSprtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Baseline      *EngineConfiguration   `protobuf:"bytes,1,opt,name=baseline,proto3" json:"baseline,omitempty"` // Network is looked up by sha256; custom builds are not supported
+	Candidate     *EngineConfiguration   `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	OpeningBook   *ResourceSpec          `protobuf:"bytes,3,opt,name=opening_book,json=openingBook,proto3" json:"opening_book,omitempty"` // Looked up by sha256
+	TimeControl   *TimeControl           `protobuf:"bytes,4,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"`
+	Elo0          float64                `protobuf:"fixed64,5,opt,name=elo0,proto3" json:"elo0,omitempty"`   // Null hypothesis, normalized Elo
+	Elo1          float64                `protobuf:"fixed64,6,opt,name=elo1,proto3" json:"elo1,omitempty"`   // Alternative hypothesis, normalized Elo
+	Alpha         float64                `protobuf:"fixed64,7,opt,name=alpha,proto3" json:"alpha,omitempty"` // 0 means 0.05
+	Beta          float64                `protobuf:"fixed64,8,opt,name=beta,proto3" json:"beta,omitempty"`   // 0 means 0.05
+	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSprtRequest) Reset() {
+	*x = CreateSprtRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSprtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSprtRequest) ProtoMessage() {}
+
+func (x *CreateSprtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSprtRequest.ProtoReflect.Descriptor instead.
+func (*CreateSprtRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateSprtRequest) GetBaseline() *EngineConfiguration {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *CreateSprtRequest) GetCandidate() *EngineConfiguration {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+func (x *CreateSprtRequest) GetOpeningBook() *ResourceSpec {
+	if x != nil {
+		return x.OpeningBook
+	}
+	return nil
+}
+
+func (x *CreateSprtRequest) GetTimeControl() *TimeControl {
+	if x != nil {
+		return x.TimeControl
+	}
+	return nil
+}
+
+func (x *CreateSprtRequest) GetElo0() float64 {
+	if x != nil {
+		return x.Elo0
+	}
+	return 0
+}
+
+func (x *CreateSprtRequest) GetElo1() float64 {
+	if x != nil {
+		return x.Elo1
+	}
+	return 0
+}
+
+func (x *CreateSprtRequest) GetAlpha() float64 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *CreateSprtRequest) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *CreateSprtRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type SprtTest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`        // Base task ID
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // ACTIVE, DONE or CANCELLED
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Elo0          float64                `protobuf:"fixed64,4,opt,name=elo0,proto3" json:"elo0,omitempty"`
+	Elo1          float64                `protobuf:"fixed64,5,opt,name=elo1,proto3" json:"elo1,omitempty"`
+	Alpha         float64                `protobuf:"fixed64,6,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta          float64                `protobuf:"fixed64,7,opt,name=beta,proto3" json:"beta,omitempty"`
+	Pentanomial   []int32                `protobuf:"varint,8,rep,packed,name=pentanomial,proto3" json:"pentanomial,omitempty"` // LL, LD, DD, DW, WW pair counts from the candidate's side
+	Unpaired      []int32                `protobuf:"varint,9,rep,packed,name=unpaired,proto3" json:"unpaired,omitempty"`       // Losses, draws and wins of games without a finished partner
+	Llr           float64                `protobuf:"fixed64,10,opt,name=llr,proto3" json:"llr,omitempty"`
+	LowerBound    float64                `protobuf:"fixed64,11,opt,name=lower_bound,json=lowerBound,proto3" json:"lower_bound,omitempty"` // The test fails once llr drops to this
+	UpperBound    float64                `protobuf:"fixed64,12,opt,name=upper_bound,json=upperBound,proto3" json:"upper_bound,omitempty"` // The test passes once llr reaches this
+	Verdict       string                 `protobuf:"bytes,13,opt,name=verdict,proto3" json:"verdict,omitempty"`                           // Empty while running, then ACCEPTED or REJECTED
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SprtTest) Reset() {
+	*x = SprtTest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SprtTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SprtTest) ProtoMessage() {}
+
+func (x *SprtTest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SprtTest.ProtoReflect.Descriptor instead.
+func (*SprtTest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SprtTest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SprtTest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SprtTest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SprtTest) GetElo0() float64 {
+	if x != nil {
+		return x.Elo0
+	}
+	return 0
+}
+
+func (x *SprtTest) GetElo1() float64 {
+	if x != nil {
+		return x.Elo1
+	}
+	return 0
+}
+
+func (x *SprtTest) GetAlpha() float64 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *SprtTest) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *SprtTest) GetPentanomial() []int32 {
+	if x != nil {
+		return x.Pentanomial
+	}
+	return nil
+}
+
+func (x *SprtTest) GetUnpaired() []int32 {
+	if x != nil {
+		return x.Unpaired
+	}
+	return nil
+}
+
+func (x *SprtTest) GetLlr() float64 {
+	if x != nil {
+		return x.Llr
+	}
+	return 0
+}
+
+func (x *SprtTest) GetLowerBound() float64 {
+	if x != nil {
+		return x.LowerBound
+	}
+	return 0
+}
+
+func (x *SprtTest) GetUpperBound() float64 {
+	if x != nil {
+		return x.UpperBound
+	}
+	return 0
+}
+
+func (x *SprtTest) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+func (x *SprtTest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetSprtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSprtRequest) Reset() {
+	*x = GetSprtRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSprtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSprtRequest) ProtoMessage() {}
+
+func (x *GetSprtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSprtRequest.ProtoReflect.Descriptor instead.
+func (*GetSprtRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetSprtRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListSprtsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeFinished bool                   `protobuf:"varint,1,opt,name=include_finished,json=includeFinished,proto3" json:"include_finished,omitempty"` // Also list DONE and CANCELLED tests
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListSprtsRequest) Reset() {
+	*x = ListSprtsRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSprtsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSprtsRequest) ProtoMessage() {}
+
+func (x *ListSprtsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSprtsRequest.ProtoReflect.Descriptor instead.
+func (*ListSprtsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListSprtsRequest) GetIncludeFinished() bool {
+	if x != nil {
+		return x.IncludeFinished
+	}
+	return false
+}
+
+type ListSprtsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sprts         []*SprtTest            `protobuf:"bytes,1,rep,name=sprts,proto3" json:"sprts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSprtsResponse) Reset() {
+	*x = ListSprtsResponse{}
+	mi := &file_api_v1_lczero_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSprtsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSprtsResponse) ProtoMessage() {}
+
+func (x *ListSprtsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSprtsResponse.ProtoReflect.Descriptor instead.
+func (*ListSprtsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListSprtsResponse) GetSprts() []*SprtTest {
+	if x != nil {
+		return x.Sprts
+	}
+	return nil
+}
+
+type CancelSprtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSprtRequest) Reset() {
+	*x = CancelSprtRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSprtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSprtRequest) ProtoMessage() {}
+
+func (x *CancelSprtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSprtRequest.ProtoReflect.Descriptor instead.
+func (*CancelSprtRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CancelSprtRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Bayesian optimization settings, as in chess-tuning-tools. A Gaussian process
+// with a Matérn 5/2 kernel is fitted to the Elo of every finished parameter set
+// and its measurement error, and the next batch maximizes the acquisition.
+type BayesianConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InitialPoints int32                  `protobuf:"varint,1,opt,name=initial_points,json=initialPoints,proto3" json:"initial_points,omitempty"` // Random parameter sets before the first fit; 0 means 16
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`             // Parameter sets proposed per fit; 0 means 4
+	MaxPoints     int32                  `protobuf:"varint,3,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`             // Parameter sets to measure in total, including the initial ones
+	Acquisition   Acquisition            `protobuf:"varint,4,opt,name=acquisition,proto3,enum=lczero.api.v1.Acquisition" json:"acquisition,omitempty"`
+	Kappa         float64                `protobuf:"fixed64,5,opt,name=kappa,proto3" json:"kappa,omitempty"` // ACQUISITION_UCB exploration weight; 0 means 1.96
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BayesianConfig) Reset() {
+	*x = BayesianConfig{}
+	mi := &file_api_v1_lczero_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BayesianConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BayesianConfig) ProtoMessage() {}
+
+func (x *BayesianConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BayesianConfig.ProtoReflect.Descriptor instead.
+func (*BayesianConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *BayesianConfig) GetInitialPoints() int32 {
+	if x != nil {
+		return x.InitialPoints
+	}
+	return 0
+}
+
+func (x *BayesianConfig) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *BayesianConfig) GetMaxPoints() int32 {
+	if x != nil {
+		return x.MaxPoints
+	}
+	return 0
+}
+
+func (x *BayesianConfig) GetAcquisition() Acquisition {
+	if x != nil {
+		return x.Acquisition
+	}
+	return Acquisition_ACQUISITION_UNSPECIFIED
+}
+
+func (x *BayesianConfig) GetKappa() float64 {
+	if x != nil {
+		return x.Kappa
+	}
+	return 0
+}
+
+type TuningObservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParamSetId    string                 `protobuf:"bytes,1,opt,name=param_set_id,json=paramSetId,proto3" json:"param_set_id,omitempty"`
+	Values        map[string]float64     `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Elo           float64                `protobuf:"fixed64,3,opt,name=elo,proto3" json:"elo,omitempty"`
+	EloStddev     float64                `protobuf:"fixed64,4,opt,name=elo_stddev,json=eloStddev,proto3" json:"elo_stddev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TuningObservation) Reset() {
+	*x = TuningObservation{}
+	mi := &file_api_v1_lczero_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuningObservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningObservation) ProtoMessage() {}
+
+func (x *TuningObservation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningObservation.ProtoReflect.Descriptor instead.
+func (*TuningObservation) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TuningObservation) GetParamSetId() string {
+	if x != nil {
+		return x.ParamSetId
+	}
+	return ""
+}
+
+func (x *TuningObservation) GetValues() map[string]float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *TuningObservation) GetElo() float64 {
+	if x != nil {
+		return x.Elo
+	}
+	return 0
+}
+
+func (x *TuningObservation) GetEloStddev() float64 {
+	if x != nil {
+		return x.EloStddev
+	}
+	return 0
+}
+
+type TuningOptimum struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                            // Base task ID
+	UciOptions    map[string]string      `protobuf:"bytes,2,rep,name=uci_options,json=uciOptions,proto3" json:"uci_options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // The values with the highest predicted Elo
+	Elo           float64                `protobuf:"fixed64,3,opt,name=elo,proto3" json:"elo,omitempty"`                                                                                                         // Predicted Elo there
+	EloStddev     float64                `protobuf:"fixed64,4,opt,name=elo_stddev,json=eloStddev,proto3" json:"elo_stddev,omitempty"`                                                                            // Standard deviation of the prediction
+	Observations  []*TuningObservation   `protobuf:"bytes,5,rep,name=observations,proto3" json:"observations,omitempty"`                                                                                         // Finished parameter sets the model was fitted to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TuningOptimum) Reset() {
+	*x = TuningOptimum{}
+	mi := &file_api_v1_lczero_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuningOptimum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningOptimum) ProtoMessage() {}
+
+func (x *TuningOptimum) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningOptimum.ProtoReflect.Descriptor instead.
+func (*TuningOptimum) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TuningOptimum) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TuningOptimum) GetUciOptions() map[string]string {
+	if x != nil {
+		return x.UciOptions
+	}
+	return nil
+}
+
+func (x *TuningOptimum) GetElo() float64 {
+	if x != nil {
+		return x.Elo
+	}
+	return 0
+}
+
+func (x *TuningOptimum) GetEloStddev() float64 {
+	if x != nil {
+		return x.EloStddev
+	}
+	return 0
+}
+
+func (x *TuningOptimum) GetObservations() []*TuningObservation {
+	if x != nil {
+		return x.Observations
+	}
+	return nil
+}
+
+type TuningParameter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // UCI option name
+	Min           float64                `protobuf:"fixed64,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
+	Step          float64                `protobuf:"fixed64,4,opt,name=step,proto3" json:"step,omitempty"`         // 0 samples the range continuously; TUNING_RANDOM only
+	Values        []string               `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty"`       // Explicit values, instead of a range
+	Start         *float64               `protobuf:"fixed64,6,opt,name=start,proto3,oneof" json:"start,omitempty"` // TUNING_SPSA start value; the middle of the range if unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TuningParameter) Reset() {
+	*x = TuningParameter{}
+	mi := &file_api_v1_lczero_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuningParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningParameter) ProtoMessage() {}
+
+func (x *TuningParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningParameter.ProtoReflect.Descriptor instead.
+func (*TuningParameter) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *TuningParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TuningParameter) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *TuningParameter) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *TuningParameter) GetStep() float64 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *TuningParameter) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *TuningParameter) GetStart() float64 {
+	if x != nil && x.Start != nil {
+		return *x.Start
+	}
+	return 0
+}
+
+// SPSA gains. At iteration k values are perturbed by c / (k+1)^gamma and moved
+// by a / (k+1+stability)^alpha times the estimated gradient, both in units of
+// each parameter's range. The gradient is the plus set's wins minus losses.
+type SpsaConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	A             float64                `protobuf:"fixed64,1,opt,name=a,proto3" json:"a,omitempty"`
+	C             float64                `protobuf:"fixed64,2,opt,name=c,proto3" json:"c,omitempty"`
+	Stability     float64                `protobuf:"fixed64,3,opt,name=stability,proto3" json:"stability,omitempty"`  // Spall's "A"; 0 means a tenth of iterations
+	Alpha         float64                `protobuf:"fixed64,4,opt,name=alpha,proto3" json:"alpha,omitempty"`          // 0 means 0.602
+	Gamma         float64                `protobuf:"fixed64,5,opt,name=gamma,proto3" json:"gamma,omitempty"`          // 0 means 0.101
+	Iterations    int32                  `protobuf:"varint,6,opt,name=iterations,proto3" json:"iterations,omitempty"` // Iterations of games_per_param_set games each
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpsaConfig) Reset() {
+	*x = SpsaConfig{}
+	mi := &file_api_v1_lczero_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpsaConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpsaConfig) ProtoMessage() {}
+
+func (x *SpsaConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpsaConfig.ProtoReflect.Descriptor instead.
+func (*SpsaConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SpsaConfig) GetA() float64 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *SpsaConfig) GetC() float64 {
+	if x != nil {
+		return x.C
+	}
+	return 0
+}
+
+func (x *SpsaConfig) GetStability() float64 {
+	if x != nil {
+		return x.Stability
+	}
+	return 0
+}
+
+func (x *SpsaConfig) GetAlpha() float64 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *SpsaConfig) GetGamma() float64 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *SpsaConfig) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+type SpsaIterationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Iteration     int32                  `protobuf:"varint,1,opt,name=iteration,proto3" json:"iteration,omitempty"`                                                                      // Number of completed iterations, including this one
+	Values        map[string]float64     `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // Parameter values after the iteration
+	GamesPlayed   int32                  `protobuf:"varint,3,opt,name=games_played,json=gamesPlayed,proto3" json:"games_played,omitempty"`
+	Score         int32                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"` // Wins minus losses of the plus set
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpsaIterationResult) Reset() {
+	*x = SpsaIterationResult{}
+	mi := &file_api_v1_lczero_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpsaIterationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpsaIterationResult) ProtoMessage() {}
+
+func (x *SpsaIterationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpsaIterationResult.ProtoReflect.Descriptor instead.
+func (*SpsaIterationResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SpsaIterationResult) GetIteration() int32 {
+	if x != nil {
+		return x.Iteration
+	}
+	return 0
+}
+
+func (x *SpsaIterationResult) GetValues() map[string]float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *SpsaIterationResult) GetGamesPlayed() int32 {
+	if x != nil {
+		return x.GamesPlayed
+	}
+	return 0
+}
+
+func (x *SpsaIterationResult) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SpsaIterationResult) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type SpsaState struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Config              *SpsaConfig            `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	CompletedIterations int32                  `protobuf:"varint,2,opt,name=completed_iterations,json=completedIterations,proto3" json:"completed_iterations,omitempty"`
+	Values              map[string]float64     `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // Current parameter values; only set by GetTuning
+	History             []*SpsaIterationResult `protobuf:"bytes,4,rep,name=history,proto3" json:"history,omitempty"`                                                                           // Only set by GetTuning
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SpsaState) Reset() {
+	*x = SpsaState{}
+	mi := &file_api_v1_lczero_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpsaState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpsaState) ProtoMessage() {}
+
+func (x *SpsaState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpsaState.ProtoReflect.Descriptor instead.
+func (*SpsaState) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SpsaState) GetConfig() *SpsaConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *SpsaState) GetCompletedIterations() int32 {
+	if x != nil {
+		return x.CompletedIterations
+	}
+	return 0
+}
+
+func (x *SpsaState) GetValues() map[string]float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *SpsaState) GetHistory() []*SpsaIterationResult {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type CreateTuningRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Build            *BuildSpec             `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
+	Network          *ResourceSpec          `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`                            // Looked up by sha256
+	OpeningBook      *ResourceSpec          `protobuf:"bytes,3,opt,name=opening_book,json=openingBook,proto3" json:"opening_book,omitempty"` // Looked up by sha256
+	TimeControl      *TimeControl           `protobuf:"bytes,4,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"`
+	GamesPerParamSet int32                  `protobuf:"varint,5,opt,name=games_per_param_set,json=gamesPerParamSet,proto3" json:"games_per_param_set,omitempty"`
+	BaseParams       *EngineParams          `protobuf:"bytes,6,opt,name=base_params,json=baseParams,proto3" json:"base_params,omitempty"` // Args and UCI options shared by every parameter set
+	Parameters       []*TuningParameter     `protobuf:"bytes,7,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Mode             TuningMode             `protobuf:"varint,8,opt,name=mode,proto3,enum=lczero.api.v1.TuningMode" json:"mode,omitempty"`
+	NumSamples       int32                  `protobuf:"varint,9,opt,name=num_samples,json=numSamples,proto3" json:"num_samples,omitempty"` // Parameter sets to draw in TUNING_RANDOM mode
+	Seed             uint64                 `protobuf:"varint,10,opt,name=seed,proto3" json:"seed,omitempty"`                              // TUNING_RANDOM seed; 0 picks one
+	Description      string                 `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	Spsa             *SpsaConfig            `protobuf:"bytes,12,opt,name=spsa,proto3" json:"spsa,omitempty"`         // Required for TUNING_SPSA
+	Bayesian         *BayesianConfig        `protobuf:"bytes,13,opt,name=bayesian,proto3" json:"bayesian,omitempty"` // Required for TUNING_BAYESIAN
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateTuningRequest) Reset() {
+	*x = CreateTuningRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTuningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTuningRequest) ProtoMessage() {}
+
+func (x *CreateTuningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTuningRequest.ProtoReflect.Descriptor instead.
+func (*CreateTuningRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateTuningRequest) GetBuild() *BuildSpec {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+func (x *CreateTuningRequest) GetNetwork() *ResourceSpec {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+func (x *CreateTuningRequest) GetOpeningBook() *ResourceSpec {
+	if x != nil {
+		return x.OpeningBook
+	}
+	return nil
+}
+
+func (x *CreateTuningRequest) GetTimeControl() *TimeControl {
+	if x != nil {
+		return x.TimeControl
+	}
+	return nil
+}
+
+func (x *CreateTuningRequest) GetGamesPerParamSet() int32 {
+	if x != nil {
+		return x.GamesPerParamSet
+	}
+	return 0
+}
+
+func (x *CreateTuningRequest) GetBaseParams() *EngineParams {
+	if x != nil {
+		return x.BaseParams
+	}
+	return nil
+}
+
+func (x *CreateTuningRequest) GetParameters() []*TuningParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *CreateTuningRequest) GetMode() TuningMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TuningMode_TUNING_MODE_UNSPECIFIED
+}
+
+func (x *CreateTuningRequest) GetNumSamples() int32 {
+	if x != nil {
+		return x.NumSamples
+	}
+	return 0
+}
+
+func (x *CreateTuningRequest) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *CreateTuningRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTuningRequest) GetSpsa() *SpsaConfig {
+	if x != nil {
+		return x.Spsa
+	}
+	return nil
+}
+
+func (x *CreateTuningRequest) GetBayesian() *BayesianConfig {
+	if x != nil {
+		return x.Bayesian
+	}
+	return nil
+}
+
+type TunedParamSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParamSetId    string                 `protobuf:"bytes,1,opt,name=param_set_id,json=paramSetId,proto3" json:"param_set_id,omitempty"`
+	Params        *EngineParams          `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	GamesPlayed   int32                  `protobuf:"varint,3,opt,name=games_played,json=gamesPlayed,proto3" json:"games_played,omitempty"`
+	Pentanomial   []int32                `protobuf:"varint,4,rep,packed,name=pentanomial,proto3" json:"pentanomial,omitempty"` // LL, LD, DD, DW, WW pair counts from this set's side
+	Elo           float64                `protobuf:"fixed64,5,opt,name=elo,proto3" json:"elo,omitempty"`
+	EloLower      float64                `protobuf:"fixed64,6,opt,name=elo_lower,json=eloLower,proto3" json:"elo_lower,omitempty"` // 95% confidence interval
+	EloUpper      float64                `protobuf:"fixed64,7,opt,name=elo_upper,json=eloUpper,proto3" json:"elo_upper,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProgressReport) Reset() {
-	*x = ProgressReport{}
-	mi := &file_api_v1_lczero_proto_msgTypes[16]
+func (x *TunedParamSet) Reset() {
+	*x = TunedParamSet{}
+	mi := &file_api_v1_lczero_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProgressReport) String() string {
+func (x *TunedParamSet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProgressReport) ProtoMessage() {}
+func (*TunedParamSet) ProtoMessage() {}
 
-func (x *ProgressReport) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[16]
+func (x *TunedParamSet) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1423,125 +4681,190 @@ func (x *ProgressReport) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProgressReport.ProtoReflect.Descriptor instead.
-func (*ProgressReport) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use TunedParamSet.ProtoReflect.Descriptor instead.
+func (*TunedParamSet) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ProgressReport) GetToken() string {
+func (x *TunedParamSet) GetParamSetId() string {
 	if x != nil {
-		return x.Token
+		return x.ParamSetId
 	}
 	return ""
 }
 
-func (x *ProgressReport) GetTaskId() string {
+func (x *TunedParamSet) GetParams() *EngineParams {
 	if x != nil {
-		return x.TaskId
+		return x.Params
 	}
-	return ""
+	return nil
 }
 
-func (x *ProgressReport) GetProgress() isProgressReport_Progress {
+func (x *TunedParamSet) GetGamesPlayed() int32 {
 	if x != nil {
-		return x.Progress
+		return x.GamesPlayed
 	}
-	return nil
+	return 0
 }
 
-func (x *ProgressReport) GetTraining() *TrainingProgress {
+func (x *TunedParamSet) GetPentanomial() []int32 {
 	if x != nil {
-		if x, ok := x.Progress.(*ProgressReport_Training); ok {
-			return x.Training
-		}
+		return x.Pentanomial
 	}
 	return nil
 }
 
-func (x *ProgressReport) GetMatch() *MatchProgress {
+func (x *TunedParamSet) GetElo() float64 {
 	if x != nil {
-		if x, ok := x.Progress.(*ProgressReport_Match); ok {
-			return x.Match
-		}
+		return x.Elo
 	}
-	return nil
+	return 0
 }
 
-func (x *ProgressReport) GetSprt() *SprtProgress {
+func (x *TunedParamSet) GetEloLower() float64 {
 	if x != nil {
-		if x, ok := x.Progress.(*ProgressReport_Sprt); ok {
-			return x.Sprt
-		}
+		return x.EloLower
 	}
-	return nil
+	return 0
 }
 
-func (x *ProgressReport) GetTuning() *TuningProgress {
+func (x *TunedParamSet) GetEloUpper() float64 {
 	if x != nil {
-		if x, ok := x.Progress.(*ProgressReport_Tuning); ok {
-			return x.Tuning
-		}
+		return x.EloUpper
 	}
-	return nil
+	return 0
 }
 
-func (x *ProgressReport) GetCrashReports() []*CrashReport {
+type TuningJob struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`        // Base task ID
+	Status           string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // ACTIVE, DONE or CANCELLED
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Mode             TuningMode             `protobuf:"varint,4,opt,name=mode,proto3,enum=lczero.api.v1.TuningMode" json:"mode,omitempty"`
+	GamesPerParamSet int32                  `protobuf:"varint,5,opt,name=games_per_param_set,json=gamesPerParamSet,proto3" json:"games_per_param_set,omitempty"`
+	ParamSets        []*TunedParamSet       `protobuf:"bytes,6,rep,name=param_sets,json=paramSets,proto3" json:"param_sets,omitempty"` // Best estimated Elo first; only set by GetTuning, never in SPSA mode
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Spsa             *SpsaState             `protobuf:"bytes,8,opt,name=spsa,proto3" json:"spsa,omitempty"`         // Only in SPSA mode
+	Bayesian         *BayesianConfig        `protobuf:"bytes,9,opt,name=bayesian,proto3" json:"bayesian,omitempty"` // Only in Bayesian mode
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TuningJob) Reset() {
+	*x = TuningJob{}
+	mi := &file_api_v1_lczero_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuningJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningJob) ProtoMessage() {}
+
+func (x *TuningJob) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[61]
 	if x != nil {
-		return x.CrashReports
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type isProgressReport_Progress interface {
-	isProgressReport_Progress()
+// Deprecated: Use TuningJob.ProtoReflect.Descriptor instead.
+func (*TuningJob) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{61}
 }
 
-type ProgressReport_Training struct {
-	Training *TrainingProgress `protobuf:"bytes,3,opt,name=training,proto3,oneof"`
+func (x *TuningJob) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
 }
 
-type ProgressReport_Match struct {
-	Match *MatchProgress `protobuf:"bytes,4,opt,name=match,proto3,oneof"`
+func (x *TuningJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-type ProgressReport_Sprt struct {
-	Sprt *SprtProgress `protobuf:"bytes,5,opt,name=sprt,proto3,oneof"`
+func (x *TuningJob) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-type ProgressReport_Tuning struct {
-	Tuning *TuningProgress `protobuf:"bytes,6,opt,name=tuning,proto3,oneof"`
+func (x *TuningJob) GetMode() TuningMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TuningMode_TUNING_MODE_UNSPECIFIED
 }
 
-func (*ProgressReport_Training) isProgressReport_Progress() {}
+func (x *TuningJob) GetGamesPerParamSet() int32 {
+	if x != nil {
+		return x.GamesPerParamSet
+	}
+	return 0
+}
 
-func (*ProgressReport_Match) isProgressReport_Progress() {}
+func (x *TuningJob) GetParamSets() []*TunedParamSet {
+	if x != nil {
+		return x.ParamSets
+	}
+	return nil
+}
 
-func (*ProgressReport_Sprt) isProgressReport_Progress() {}
+func (x *TuningJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
 
-func (*ProgressReport_Tuning) isProgressReport_Progress() {}
+func (x *TuningJob) GetSpsa() *SpsaState {
+	if x != nil {
+		return x.Spsa
+	}
+	return nil
+}
 
-type ProgressResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Status        ProgressResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=lczero.api.v1.ProgressResponse_Status" json:"status,omitempty"`
+func (x *TuningJob) GetBayesian() *BayesianConfig {
+	if x != nil {
+		return x.Bayesian
+	}
+	return nil
+}
+
+type GetTuningRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProgressResponse) Reset() {
-	*x = ProgressResponse{}
-	mi := &file_api_v1_lczero_proto_msgTypes[17]
+func (x *GetTuningRequest) Reset() {
+	*x = GetTuningRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProgressResponse) String() string {
+func (x *GetTuningRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProgressResponse) ProtoMessage() {}
+func (*GetTuningRequest) ProtoMessage() {}
 
-func (x *ProgressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[17]
+func (x *GetTuningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1552,41 +4875,40 @@ func (x *ProgressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProgressResponse.ProtoReflect.Descriptor instead.
-func (*ProgressResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use GetTuningRequest.ProtoReflect.Descriptor instead.
+func (*GetTuningRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *ProgressResponse) GetStatus() ProgressResponse_Status {
+func (x *GetTuningRequest) GetId() uint64 {
 	if x != nil {
-		return x.Status
+		return x.Id
 	}
-	return ProgressResponse_STATUS_UNSPECIFIED
+	return 0
 }
 
-type GameData struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	TrainingDataFrame []byte                 `protobuf:"bytes,1,opt,name=training_data_frame,json=trainingDataFrame,proto3" json:"training_data_frame,omitempty"`
-	CompressedPgn     []byte                 `protobuf:"bytes,2,opt,name=compressed_pgn,json=compressedPgn,proto3" json:"compressed_pgn,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type ListTuningsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeFinished bool                   `protobuf:"varint,1,opt,name=include_finished,json=includeFinished,proto3" json:"include_finished,omitempty"` // Also list DONE and CANCELLED jobs
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GameData) Reset() {
-	*x = GameData{}
-	mi := &file_api_v1_lczero_proto_msgTypes[18]
+func (x *ListTuningsRequest) Reset() {
+	*x = ListTuningsRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameData) String() string {
+func (x *ListTuningsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameData) ProtoMessage() {}
+func (*ListTuningsRequest) ProtoMessage() {}
 
-func (x *GameData) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[18]
+func (x *ListTuningsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1597,49 +4919,40 @@ func (x *GameData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameData.ProtoReflect.Descriptor instead.
-func (*GameData) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *GameData) GetTrainingDataFrame() []byte {
-	if x != nil {
-		return x.TrainingDataFrame
-	}
-	return nil
+// Deprecated: Use ListTuningsRequest.ProtoReflect.Descriptor instead.
+func (*ListTuningsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *GameData) GetCompressedPgn() []byte {
+func (x *ListTuningsRequest) GetIncludeFinished() bool {
 	if x != nil {
-		return x.CompressedPgn
+		return x.IncludeFinished
 	}
-	return nil
+	return false
 }
 
-type CrashReport struct {
+type ListTuningsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          CrashReport_CrashType  `protobuf:"varint,1,opt,name=type,proto3,enum=lczero.api.v1.CrashReport_CrashType" json:"type,omitempty"`
-	MachineInfo   string                 `protobuf:"bytes,2,opt,name=machine_info,json=machineInfo,proto3" json:"machine_info,omitempty"`                                          // Minimal, privacy-friendly machine info
-	Logs          map[string][]byte      `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // e.g., "stdout", "stderr", "engine_log"
+	Tunings       []*TuningJob           `protobuf:"bytes,1,rep,name=tunings,proto3" json:"tunings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CrashReport) Reset() {
-	*x = CrashReport{}
-	mi := &file_api_v1_lczero_proto_msgTypes[19]
+func (x *ListTuningsResponse) Reset() {
+	*x = ListTuningsResponse{}
+	mi := &file_api_v1_lczero_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CrashReport) String() string {
+func (x *ListTuningsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CrashReport) ProtoMessage() {}
+func (*ListTuningsResponse) ProtoMessage() {}
 
-func (x *CrashReport) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[19]
+func (x *ListTuningsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1650,54 +4963,84 @@ func (x *CrashReport) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CrashReport.ProtoReflect.Descriptor instead.
-func (*CrashReport) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ListTuningsResponse.ProtoReflect.Descriptor instead.
+func (*ListTuningsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *CrashReport) GetType() CrashReport_CrashType {
+func (x *ListTuningsResponse) GetTunings() []*TuningJob {
 	if x != nil {
-		return x.Type
+		return x.Tunings
 	}
-	return CrashReport_CRASH_TYPE_UNSPECIFIED
+	return nil
 }
 
-func (x *CrashReport) GetMachineInfo() string {
+type CancelTuningRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTuningRequest) Reset() {
+	*x = CancelTuningRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTuningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTuningRequest) ProtoMessage() {}
+
+func (x *CancelTuningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[65]
 	if x != nil {
-		return x.MachineInfo
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CrashReport) GetLogs() map[string][]byte {
+// Deprecated: Use CancelTuningRequest.ProtoReflect.Descriptor instead.
+func (*CancelTuningRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CancelTuningRequest) GetId() uint64 {
 	if x != nil {
-		return x.Logs
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type TrainingProgress struct {
+type ClientVersionReportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Games         []*GameData            `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	Days          int32                  `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"` // Count clients given tasks in this many days; 0 means 7
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TrainingProgress) Reset() {
-	*x = TrainingProgress{}
-	mi := &file_api_v1_lczero_proto_msgTypes[20]
+func (x *ClientVersionReportRequest) Reset() {
+	*x = ClientVersionReportRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TrainingProgress) String() string {
+func (x *ClientVersionReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TrainingProgress) ProtoMessage() {}
+func (*ClientVersionReportRequest) ProtoMessage() {}
 
-func (x *TrainingProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[20]
+func (x *ClientVersionReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1708,43 +5051,45 @@ func (x *TrainingProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TrainingProgress.ProtoReflect.Descriptor instead.
-func (*TrainingProgress) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use ClientVersionReportRequest.ProtoReflect.Descriptor instead.
+func (*ClientVersionReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *TrainingProgress) GetGames() []*GameData {
+func (x *ClientVersionReportRequest) GetDays() int32 {
 	if x != nil {
-		return x.Games
+		return x.Days
 	}
-	return nil
+	return 0
 }
 
-type MatchGame struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Pgn              string                 `protobuf:"bytes,1,opt,name=pgn,proto3" json:"pgn,omitempty"`
-	ShortOutcome     ShortOutcome           `protobuf:"varint,2,opt,name=short_outcome,json=shortOutcome,proto3,enum=lczero.api.v1.ShortOutcome" json:"short_outcome,omitempty"`
-	DetailedOutcome  DetailedOutcome        `protobuf:"varint,3,opt,name=detailed_outcome,json=detailedOutcome,proto3,enum=lczero.api.v1.DetailedOutcome" json:"detailed_outcome,omitempty"`
-	CandidateIsWhite bool                   `protobuf:"varint,4,opt,name=candidate_is_white,json=candidateIsWhite,proto3" json:"candidate_is_white,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type ClientVersionCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientVersion string                 `protobuf:"bytes,1,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	EngineVersion string                 `protobuf:"bytes,2,opt,name=engine_version,json=engineVersion,proto3" json:"engine_version,omitempty"`
+	Clients       int64                  `protobuf:"varint,3,opt,name=clients,proto3" json:"clients,omitempty"` // Tokens whose latest assignment used these versions
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	BelowMin      bool                   `protobuf:"varint,5,opt,name=below_min,json=belowMin,proto3" json:"below_min,omitempty"`    // No longer gets tasks
+	BelowNext     bool                   `protobuf:"varint,6,opt,name=below_next,json=belowNext,proto3" json:"below_next,omitempty"` // Gets upgrade advisories
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MatchGame) Reset() {
-	*x = MatchGame{}
-	mi := &file_api_v1_lczero_proto_msgTypes[21]
+func (x *ClientVersionCount) Reset() {
+	*x = ClientVersionCount{}
+	mi := &file_api_v1_lczero_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MatchGame) String() string {
+func (x *ClientVersionCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MatchGame) ProtoMessage() {}
+func (*ClientVersionCount) ProtoMessage() {}
 
-func (x *MatchGame) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[21]
+func (x *ClientVersionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,61 +5100,82 @@ func (x *MatchGame) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MatchGame.ProtoReflect.Descriptor instead.
-func (*MatchGame) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ClientVersionCount.ProtoReflect.Descriptor instead.
+func (*ClientVersionCount) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *MatchGame) GetPgn() string {
+func (x *ClientVersionCount) GetClientVersion() string {
 	if x != nil {
-		return x.Pgn
+		return x.ClientVersion
 	}
 	return ""
 }
 
-func (x *MatchGame) GetShortOutcome() ShortOutcome {
+func (x *ClientVersionCount) GetEngineVersion() string {
 	if x != nil {
-		return x.ShortOutcome
+		return x.EngineVersion
 	}
-	return ShortOutcome_SHORT_OUTCOME_UNSPECIFIED
+	return ""
 }
 
-func (x *MatchGame) GetDetailedOutcome() DetailedOutcome {
+func (x *ClientVersionCount) GetClients() int64 {
 	if x != nil {
-		return x.DetailedOutcome
+		return x.Clients
 	}
-	return DetailedOutcome_DETAILED_OUTCOME_UNSPECIFIED
+	return 0
 }
 
-func (x *MatchGame) GetCandidateIsWhite() bool {
+func (x *ClientVersionCount) GetLastSeen() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CandidateIsWhite
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *ClientVersionCount) GetBelowMin() bool {
+	if x != nil {
+		return x.BelowMin
 	}
 	return false
 }
 
-type MatchProgress struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Games         []*MatchGame           `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ClientVersionCount) GetBelowNext() bool {
+	if x != nil {
+		return x.BelowNext
+	}
+	return false
 }
 
-func (x *MatchProgress) Reset() {
-	*x = MatchProgress{}
-	mi := &file_api_v1_lczero_proto_msgTypes[22]
+type ClientVersionReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Versions          []*ClientVersionCount  `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"` // Most clients first
+	TotalClients      int64                  `protobuf:"varint,2,opt,name=total_clients,json=totalClients,proto3" json:"total_clients,omitempty"`
+	ClientsAtNext     int64                  `protobuf:"varint,3,opt,name=clients_at_next,json=clientsAtNext,proto3" json:"clients_at_next,omitempty"` // Clients already on the next versions or newer
+	MinClientVersion  string                 `protobuf:"bytes,4,opt,name=min_client_version,json=minClientVersion,proto3" json:"min_client_version,omitempty"`
+	NextClientVersion string                 `protobuf:"bytes,5,opt,name=next_client_version,json=nextClientVersion,proto3" json:"next_client_version,omitempty"`
+	MinEngineVersion  string                 `protobuf:"bytes,6,opt,name=min_engine_version,json=minEngineVersion,proto3" json:"min_engine_version,omitempty"`
+	NextEngineVersion string                 `protobuf:"bytes,7,opt,name=next_engine_version,json=nextEngineVersion,proto3" json:"next_engine_version,omitempty"`
+	RequiredBy        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=required_by,json=requiredBy,proto3" json:"required_by,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ClientVersionReport) Reset() {
+	*x = ClientVersionReport{}
+	mi := &file_api_v1_lczero_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MatchProgress) String() string {
+func (x *ClientVersionReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MatchProgress) ProtoMessage() {}
+func (*ClientVersionReport) ProtoMessage() {}
 
-func (x *MatchProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[22]
+func (x *ClientVersionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1820,93 +5186,90 @@ func (x *MatchProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MatchProgress.ProtoReflect.Descriptor instead.
-func (*MatchProgress) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ClientVersionReport.ProtoReflect.Descriptor instead.
+func (*ClientVersionReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *MatchProgress) GetGames() []*MatchGame {
+func (x *ClientVersionReport) GetVersions() []*ClientVersionCount {
 	if x != nil {
-		return x.Games
+		return x.Versions
 	}
 	return nil
 }
 
-type SprtPairReport struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Game1         *MatchGame             `protobuf:"bytes,1,opt,name=game1,proto3" json:"game1,omitempty"`
-	Game2         *MatchGame             `protobuf:"bytes,2,opt,name=game2,proto3,oneof" json:"game2,omitempty"` // The second game might not run if the first crashes
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ClientVersionReport) GetTotalClients() int64 {
+	if x != nil {
+		return x.TotalClients
+	}
+	return 0
 }
 
-func (x *SprtPairReport) Reset() {
-	*x = SprtPairReport{}
-	mi := &file_api_v1_lczero_proto_msgTypes[23]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ClientVersionReport) GetClientsAtNext() int64 {
+	if x != nil {
+		return x.ClientsAtNext
+	}
+	return 0
 }
 
-func (x *SprtPairReport) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ClientVersionReport) GetMinClientVersion() string {
+	if x != nil {
+		return x.MinClientVersion
+	}
+	return ""
 }
 
-func (*SprtPairReport) ProtoMessage() {}
-
-func (x *SprtPairReport) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[23]
+func (x *ClientVersionReport) GetNextClientVersion() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.NextClientVersion
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use SprtPairReport.ProtoReflect.Descriptor instead.
-func (*SprtPairReport) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{23}
+func (x *ClientVersionReport) GetMinEngineVersion() string {
+	if x != nil {
+		return x.MinEngineVersion
+	}
+	return ""
 }
 
-func (x *SprtPairReport) GetGame1() *MatchGame {
+func (x *ClientVersionReport) GetNextEngineVersion() string {
 	if x != nil {
-		return x.Game1
+		return x.NextEngineVersion
 	}
-	return nil
+	return ""
 }
 
-func (x *SprtPairReport) GetGame2() *MatchGame {
+func (x *ClientVersionReport) GetRequiredBy() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Game2
+		return x.RequiredBy
 	}
 	return nil
 }
 
-type SprtProgress struct {
+type SetTaskWeightRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pairs         []*SprtPairReport      `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
-	CurrentLlr    float64                `protobuf:"fixed64,2,opt,name=current_llr,json=currentLlr,proto3" json:"current_llr,omitempty"` // Current Log-Likelihood Ratio
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`          // Base task ID
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"` // 0 pauses the task
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SprtProgress) Reset() {
-	*x = SprtProgress{}
-	mi := &file_api_v1_lczero_proto_msgTypes[24]
+func (x *SetTaskWeightRequest) Reset() {
+	*x = SetTaskWeightRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SprtProgress) String() string {
+func (x *SetTaskWeightRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SprtProgress) ProtoMessage() {}
+func (*SetTaskWeightRequest) ProtoMessage() {}
 
-func (x *SprtProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[24]
+func (x *SetTaskWeightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1917,48 +5280,54 @@ func (x *SprtProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SprtProgress.ProtoReflect.Descriptor instead.
-func (*SprtProgress) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use SetTaskWeightRequest.ProtoReflect.Descriptor instead.
+func (*SetTaskWeightRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *SprtProgress) GetPairs() []*SprtPairReport {
+func (x *SetTaskWeightRequest) GetId() uint64 {
 	if x != nil {
-		return x.Pairs
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-func (x *SprtProgress) GetCurrentLlr() float64 {
+func (x *SetTaskWeightRequest) GetWeight() float64 {
 	if x != nil {
-		return x.CurrentLlr
+		return x.Weight
 	}
 	return 0
 }
 
-type TuningPairResult struct {
+type TaskShare struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Game1         *MatchGame             `protobuf:"bytes,1,opt,name=game1,proto3" json:"game1,omitempty"`
-	Game2         *MatchGame             `protobuf:"bytes,2,opt,name=game2,proto3,oneof" json:"game2,omitempty"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                 // Base task ID
+	Type          TaskType               `protobuf:"varint,2,opt,name=type,proto3,enum=lczero.api.v1.TaskType" json:"type,omitempty"` // Matches count towards their TRAINING task
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Weight        float64                `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	TargetShare   float64                `protobuf:"fixed64,5,opt,name=target_share,json=targetShare,proto3" json:"target_share,omitempty"`       // Fraction of the total weight of the tasks taking clients
+	Assignments   int64                  `protobuf:"varint,6,opt,name=assignments,proto3" json:"assignments,omitempty"`                           // Over the schedule window
+	ObservedShare float64                `protobuf:"fixed64,7,opt,name=observed_share,json=observedShare,proto3" json:"observed_share,omitempty"` // Fraction of the games over the schedule window
+	Games         int64                  `protobuf:"varint,8,opt,name=games,proto3" json:"games,omitempty"`                                       // Played over the schedule window; assignments without results count as one
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TuningPairResult) Reset() {
-	*x = TuningPairResult{}
-	mi := &file_api_v1_lczero_proto_msgTypes[25]
+func (x *TaskShare) Reset() {
+	*x = TaskShare{}
+	mi := &file_api_v1_lczero_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TuningPairResult) String() string {
+func (x *TaskShare) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TuningPairResult) ProtoMessage() {}
+func (*TaskShare) ProtoMessage() {}
 
-func (x *TuningPairResult) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[25]
+func (x *TaskShare) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1969,48 +5338,88 @@ func (x *TuningPairResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TuningPairResult.ProtoReflect.Descriptor instead.
-func (*TuningPairResult) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use TaskShare.ProtoReflect.Descriptor instead.
+func (*TaskShare) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *TuningPairResult) GetGame1() *MatchGame {
+func (x *TaskShare) GetId() uint64 {
 	if x != nil {
-		return x.Game1
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-func (x *TuningPairResult) GetGame2() *MatchGame {
+func (x *TaskShare) GetType() TaskType {
 	if x != nil {
-		return x.Game2
+		return x.Type
 	}
-	return nil
+	return TaskType_TASK_TYPE_UNSPECIFIED
 }
 
-type TuningParamSetResult struct {
+func (x *TaskShare) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TaskShare) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *TaskShare) GetTargetShare() float64 {
+	if x != nil {
+		return x.TargetShare
+	}
+	return 0
+}
+
+func (x *TaskShare) GetAssignments() int64 {
+	if x != nil {
+		return x.Assignments
+	}
+	return 0
+}
+
+func (x *TaskShare) GetObservedShare() float64 {
+	if x != nil {
+		return x.ObservedShare
+	}
+	return 0
+}
+
+func (x *TaskShare) GetGames() int64 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+type ListTaskSharesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ParamSetId    string                 `protobuf:"bytes,1,opt,name=param_set_id,json=paramSetId,proto3" json:"param_set_id,omitempty"`
-	Pairs         []*TuningPairResult    `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TuningParamSetResult) Reset() {
-	*x = TuningParamSetResult{}
-	mi := &file_api_v1_lczero_proto_msgTypes[26]
+func (x *ListTaskSharesRequest) Reset() {
+	*x = ListTaskSharesRequest{}
+	mi := &file_api_v1_lczero_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TuningParamSetResult) String() string {
+func (x *ListTaskSharesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TuningParamSetResult) ProtoMessage() {}
+func (*ListTaskSharesRequest) ProtoMessage() {}
 
-func (x *TuningParamSetResult) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[26]
+func (x *ListTaskSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2021,47 +5430,34 @@ func (x *TuningParamSetResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TuningParamSetResult.ProtoReflect.Descriptor instead.
-func (*TuningParamSetResult) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{26}
-}
-
-func (x *TuningParamSetResult) GetParamSetId() string {
-	if x != nil {
-		return x.ParamSetId
-	}
-	return ""
-}
-
-func (x *TuningParamSetResult) GetPairs() []*TuningPairResult {
-	if x != nil {
-		return x.Pairs
-	}
-	return nil
+// Deprecated: Use ListTaskSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskSharesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{71}
 }
 
-type TuningProgress struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Results       []*TuningParamSetResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+type ListTaskSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*TaskShare           `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`                                     // Only tasks that can take clients, oldest first
+	WindowSeconds int64                  `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"` // How far back assignments are counted
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TuningProgress) Reset() {
-	*x = TuningProgress{}
-	mi := &file_api_v1_lczero_proto_msgTypes[27]
+func (x *ListTaskSharesResponse) Reset() {
+	*x = ListTaskSharesResponse{}
+	mi := &file_api_v1_lczero_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TuningProgress) String() string {
+func (x *ListTaskSharesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TuningProgress) ProtoMessage() {}
+func (*ListTaskSharesResponse) ProtoMessage() {}
 
-func (x *TuningProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[27]
+func (x *ListTaskSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_lczero_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2072,18 +5468,25 @@ func (x *TuningProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TuningProgress.ProtoReflect.Descriptor instead.
-func (*TuningProgress) Descriptor() ([]byte, []int) {
-	return file_api_v1_lczero_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use ListTaskSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskSharesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_lczero_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *TuningProgress) GetResults() []*TuningParamSetResult {
+func (x *ListTaskSharesResponse) GetShares() []*TaskShare {
 	if x != nil {
-		return x.Results
+		return x.Shares
 	}
 	return nil
 }
 
+func (x *ListTaskSharesResponse) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
 type TimeControl_TimeBased struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	BaseTimeSeconds  float32                `protobuf:"fixed32,1,opt,name=base_time_seconds,json=baseTimeSeconds,proto3" json:"base_time_seconds,omitempty"`
@@ -2094,7 +5497,7 @@ type TimeControl_TimeBased struct {
 
 func (x *TimeControl_TimeBased) Reset() {
 	*x = TimeControl_TimeBased{}
-	mi := &file_api_v1_lczero_proto_msgTypes[30]
+	mi := &file_api_v1_lczero_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2106,7 +5509,7 @@ func (x *TimeControl_TimeBased) String() string {
 func (*TimeControl_TimeBased) ProtoMessage() {}
 
 func (x *TimeControl_TimeBased) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_lczero_proto_msgTypes[30]
+	mi := &file_api_v1_lczero_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2140,14 +5543,15 @@ var File_api_v1_lczero_proto protoreflect.FileDescriptor
 
 const file_api_v1_lczero_proto_rawDesc = "" +
 	"\n" +
-	"\x13api/v1/lczero.proto\x12\rlczero.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\xbf\x01\n" +
+	"\x13api/v1/lczero.proto\x12\rlczero.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\xe6\x01\n" +
 	"\n" +
 	"ClientInfo\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1a\n" +
 	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x19\n" +
 	"\bgpu_type\x18\x03 \x01(\tR\agpuType\x12\x15\n" +
 	"\x06gpu_id\x18\x04 \x01(\x05R\x05gpuId\x12I\n" +
-	"\x14supported_task_types\x18\x05 \x03(\x0e2\x17.lczero.api.v1.TaskTypeR\x12supportedTaskTypes\"\xa0\x01\n" +
+	"\x14supported_task_types\x18\x05 \x03(\x0e2\x17.lczero.api.v1.TaskTypeR\x12supportedTaskTypes\x12%\n" +
+	"\x0eengine_version\x18\x06 \x01(\tR\rengineVersion\"\xa0\x01\n" +
 	"\fResourceSpec\x12\x16\n" +
 	"\x06sha256\x18\x01 \x01(\tR\x06sha256\x12\x10\n" +
 	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1d\n" +
@@ -2177,14 +5581,23 @@ const file_api_v1_lczero_proto_rawDesc = "" +
 	"\tTimeBased\x12*\n" +
 	"\x11base_time_seconds\x18\x01 \x01(\x02R\x0fbaseTimeSeconds\x12+\n" +
 	"\x11increment_seconds\x18\x02 \x01(\x02R\x10incrementSecondsB\t\n" +
-	"\acontrol\"\x80\x02\n" +
+	"\acontrol\"\xba\x02\n" +
 	"\fTaskResponse\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x129\n" +
 	"\btraining\x18\x02 \x01(\v2\x1b.lczero.api.v1.TrainingTaskH\x00R\btraining\x120\n" +
 	"\x05match\x18\x03 \x01(\v2\x18.lczero.api.v1.MatchTaskH\x00R\x05match\x12-\n" +
 	"\x04sprt\x18\x04 \x01(\v2\x17.lczero.api.v1.SprtTaskH\x00R\x04sprt\x123\n" +
-	"\x06tuning\x18\x05 \x01(\v2\x19.lczero.api.v1.TuningTaskH\x00R\x06tuningB\x06\n" +
-	"\x04task\"\xb1\x01\n" +
+	"\x06tuning\x18\x05 \x01(\v2\x19.lczero.api.v1.TuningTaskH\x00R\x06tuning\x128\n" +
+	"\aupgrade\x18\x06 \x01(\v2\x1e.lczero.api.v1.UpgradeAdvisoryR\aupgradeB\x06\n" +
+	"\x04task\"\x96\x02\n" +
+	"\x0fUpgradeAdvisory\x12%\n" +
+	"\x0eclient_version\x18\x01 \x01(\tR\rclientVersion\x12%\n" +
+	"\x0eengine_version\x18\x02 \x01(\tR\rengineVersion\x12;\n" +
+	"\vrequired_by\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"requiredBy\x12.\n" +
+	"\x13client_download_url\x18\x04 \x01(\tR\x11clientDownloadUrl\x12.\n" +
+	"\x13engine_download_url\x18\x05 \x01(\tR\x11engineDownloadUrl\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\"\xb1\x01\n" +
 	"\x13EngineConfiguration\x12.\n" +
 	"\x05build\x18\x01 \x01(\v2\x18.lczero.api.v1.BuildSpecR\x05build\x125\n" +
 	"\anetwork\x18\x02 \x01(\v2\x1b.lczero.api.v1.ResourceSpecR\anetwork\x123\n" +
@@ -2192,12 +5605,13 @@ const file_api_v1_lczero_proto_rawDesc = "" +
 	"\fTrainingTask\x12:\n" +
 	"\x06engine\x18\x01 \x01(\v2\".lczero.api.v1.EngineConfigurationR\x06engine\x12>\n" +
 	"\fopening_book\x18\x02 \x01(\v2\x1b.lczero.api.v1.ResourceSpecR\vopeningBook\x12$\n" +
-	"\x0enodes_per_move\x18\x03 \x01(\x03R\fnodesPerMove\"\xf3\x01\n" +
+	"\x0enodes_per_move\x18\x03 \x01(\x03R\fnodesPerMove\"\xa1\x02\n" +
 	"\tMatchTask\x12>\n" +
 	"\bbaseline\x18\x01 \x01(\v2\".lczero.api.v1.EngineConfigurationR\bbaseline\x12@\n" +
 	"\tcandidate\x18\x02 \x01(\v2\".lczero.api.v1.EngineConfigurationR\tcandidate\x12>\n" +
 	"\fopening_book\x18\x03 \x01(\v2\x1b.lczero.api.v1.ResourceSpecR\vopeningBook\x12$\n" +
-	"\x0enodes_per_move\x18\x04 \x01(\x03R\fnodesPerMove\"\x8b\x02\n" +
+	"\x0enodes_per_move\x18\x04 \x01(\x03R\fnodesPerMove\x12,\n" +
+	"\x12candidate_is_white\x18\x05 \x01(\bR\x10candidateIsWhite\"\x8b\x02\n" +
 	"\bSprtTask\x12>\n" +
 	"\bbaseline\x18\x01 \x01(\v2\".lczero.api.v1.EngineConfigurationR\bbaseline\x12@\n" +
 	"\tcandidate\x18\x02 \x01(\v2\".lczero.api.v1.EngineConfigurationR\tcandidate\x12>\n" +
@@ -2221,11 +5635,30 @@ const file_api_v1_lczero_proto_rawDesc = "" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x17\n" +
 	"\x15AnonymousTokenRequest\"$\n" +
 	"\fAuthResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"_\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xd8\x02\n" +
+	"\tTokenInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12!\n" +
+	"\ftoken_prefix\x18\x02 \x01(\tR\vtokenPrefix\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.lczero.api.v1.TokenStatusR\x06status\x12#\n" +
+	"\rissued_reason\x18\x04 \x01(\tR\fissuedReason\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x12\x1f\n" +
+	"\vclient_host\x18\a \x01(\tR\n" +
+	"clientHost\x12%\n" +
+	"\x0eclient_version\x18\b \x01(\tR\rclientVersion\"/\n" +
+	"\x12RevokeTokenRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\"\x15\n" +
+	"\x13RevokeTokenResponse\"\x13\n" +
+	"\x11ListTokensRequest\"F\n" +
+	"\x12ListTokensResponse\x120\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x18.lczero.api.v1.TokenInfoR\x06tokens\"\x14\n" +
+	"\x12RotateTokenRequest\"_\n" +
 	"\vTaskRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12:\n" +
 	"\vclient_info\x18\x02 \x01(\v2\x19.lczero.api.v1.ClientInfoR\n" +
-	"clientInfo\"\xed\x02\n" +
+	"clientInfo\"\x9f\x03\n" +
 	"\x0eProgressReport\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
 	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12=\n" +
@@ -2233,11 +5666,14 @@ const file_api_v1_lczero_proto_rawDesc = "" +
 	"\x05match\x18\x04 \x01(\v2\x1c.lczero.api.v1.MatchProgressH\x00R\x05match\x121\n" +
 	"\x04sprt\x18\x05 \x01(\v2\x1b.lczero.api.v1.SprtProgressH\x00R\x04sprt\x127\n" +
 	"\x06tuning\x18\x06 \x01(\v2\x1d.lczero.api.v1.TuningProgressH\x00R\x06tuning\x12?\n" +
-	"\rcrash_reports\x18\a \x03(\v2\x1a.lczero.api.v1.CrashReportR\fcrashReportsB\n" +
+	"\rcrash_reports\x18\a \x03(\v2\x1a.lczero.api.v1.CrashReportR\fcrashReports\x12\x14\n" +
+	"\x05final\x18\b \x01(\bR\x05final\x12\x1a\n" +
+	"\bsequence\x18\t \x01(\x04R\bsequenceB\n" +
 	"\n" +
-	"\bprogress\"\x8f\x01\n" +
+	"\bprogress\"\xc9\x01\n" +
 	"\x10ProgressResponse\x12>\n" +
-	"\x06status\x18\x01 \x01(\x0e2&.lczero.api.v1.ProgressResponse.StatusR\x06status\";\n" +
+	"\x06status\x18\x01 \x01(\x0e2&.lczero.api.v1.ProgressResponse.StatusR\x06status\x128\n" +
+	"\aupgrade\x18\x02 \x01(\v2\x1e.lczero.api.v1.UpgradeAdvisoryR\aupgrade\";\n" +
 	"\x06Status\x12\x16\n" +
 	"\x12STATUS_UNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
@@ -2284,7 +5720,281 @@ const file_api_v1_lczero_proto_rawDesc = "" +
 	"paramSetId\x125\n" +
 	"\x05pairs\x18\x02 \x03(\v2\x1f.lczero.api.v1.TuningPairResultR\x05pairs\"O\n" +
 	"\x0eTuningProgress\x12=\n" +
-	"\aresults\x18\x01 \x03(\v2#.lczero.api.v1.TuningParamSetResultR\aresults*T\n" +
+	"\aresults\x18\x01 \x03(\v2#.lczero.api.v1.TuningParamSetResultR\aresults\"\xa2\x03\n" +
+	"\vTrainingRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12)\n" +
+	"\x10train_parameters\x18\x03 \x01(\tR\x0ftrainParameters\x12)\n" +
+	"\x10match_parameters\x18\x04 \x01(\tR\x0fmatchParameters\x12\x1d\n" +
+	"\n" +
+	"train_book\x18\x05 \x01(\tR\ttrainBook\x12\x1d\n" +
+	"\n" +
+	"match_book\x18\x06 \x01(\tR\tmatchBook\x12\x16\n" +
+	"\x06active\x18\a \x01(\bR\x06active\x12&\n" +
+	"\x0fbest_network_id\x18\b \x01(\x04R\rbestNetworkId\x12!\n" +
+	"\flast_network\x18\t \x01(\x04R\vlastNetwork\x12\x1b\n" +
+	"\tlast_game\x18\n" +
+	" \x01(\x04R\blastGame\x12'\n" +
+	"\x0fpermission_expr\x18\v \x01(\tR\x0epermissionExpr\x12$\n" +
+	"\x0emulti_net_mode\x18\f \x01(\bR\fmultiNetMode\"\xc7\x02\n" +
+	"\x18CreateTrainingRunRequest\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12)\n" +
+	"\x10train_parameters\x18\x02 \x01(\tR\x0ftrainParameters\x12)\n" +
+	"\x10match_parameters\x18\x03 \x01(\tR\x0fmatchParameters\x12\x1d\n" +
+	"\n" +
+	"train_book\x18\x04 \x01(\tR\ttrainBook\x12\x1d\n" +
+	"\n" +
+	"match_book\x18\x05 \x01(\tR\tmatchBook\x12&\n" +
+	"\x0fbest_network_id\x18\x06 \x01(\x04R\rbestNetworkId\x12'\n" +
+	"\x0fpermission_expr\x18\a \x01(\tR\x0epermissionExpr\x12$\n" +
+	"\x0emulti_net_mode\x18\b \x01(\bR\fmultiNetMode\"\x92\x04\n" +
+	"\x18UpdateTrainingRunRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12.\n" +
+	"\x10train_parameters\x18\x03 \x01(\tH\x01R\x0ftrainParameters\x88\x01\x01\x12.\n" +
+	"\x10match_parameters\x18\x04 \x01(\tH\x02R\x0fmatchParameters\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"train_book\x18\x05 \x01(\tH\x03R\ttrainBook\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"match_book\x18\x06 \x01(\tH\x04R\tmatchBook\x88\x01\x01\x12+\n" +
+	"\x0fbest_network_id\x18\a \x01(\x04H\x05R\rbestNetworkId\x88\x01\x01\x12,\n" +
+	"\x0fpermission_expr\x18\b \x01(\tH\x06R\x0epermissionExpr\x88\x01\x01\x12)\n" +
+	"\x0emulti_net_mode\x18\t \x01(\bH\aR\fmultiNetMode\x88\x01\x01B\x0e\n" +
+	"\f_descriptionB\x13\n" +
+	"\x11_train_parametersB\x13\n" +
+	"\x11_match_parametersB\r\n" +
+	"\v_train_bookB\r\n" +
+	"\v_match_bookB\x12\n" +
+	"\x10_best_network_idB\x12\n" +
+	"\x10_permission_exprB\x11\n" +
+	"\x0f_multi_net_mode\":\n" +
+	"\x10SetActiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"\x19\n" +
+	"\x17ListTrainingRunsRequest\"[\n" +
+	"\x18ListTrainingRunsResponse\x12?\n" +
+	"\rtraining_runs\x18\x01 \x03(\v2\x1a.lczero.api.v1.TrainingRunR\ftrainingRuns\"\xe5\x02\n" +
+	"\x12TrainingTaskConfig\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x04R\x06taskId\x12&\n" +
+	"\x0ftraining_run_id\x18\x03 \x01(\x04R\rtrainingRunId\x12\"\n" +
+	"\rtrain_book_id\x18\x04 \x01(\x04R\vtrainBookId\x12\"\n" +
+	"\rmatch_book_id\x18\x05 \x01(\x04R\vmatchBookId\x12)\n" +
+	"\x10train_parameters\x18\x06 \x01(\tR\x0ftrainParameters\x12)\n" +
+	"\x10match_parameters\x18\a \x01(\tR\x0fmatchParameters\x12&\n" +
+	"\x0fbest_network_id\x18\b \x01(\x04R\rbestNetworkId\x12\x16\n" +
+	"\x06active\x18\t \x01(\bR\x06active\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\"\xab\x02\n" +
+	"\x19CreateTrainingTaskRequest\x12&\n" +
+	"\x0ftraining_run_id\x18\x01 \x01(\x04R\rtrainingRunId\x12\"\n" +
+	"\rtrain_book_id\x18\x02 \x01(\x04R\vtrainBookId\x12\"\n" +
+	"\rmatch_book_id\x18\x03 \x01(\x04R\vmatchBookId\x12)\n" +
+	"\x10train_parameters\x18\x04 \x01(\tR\x0ftrainParameters\x12)\n" +
+	"\x10match_parameters\x18\x05 \x01(\tR\x0fmatchParameters\x12&\n" +
+	"\x0fbest_network_id\x18\x06 \x01(\x04R\rbestNetworkId\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\xec\x02\n" +
+	"\x19UpdateTrainingTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12'\n" +
+	"\rtrain_book_id\x18\x02 \x01(\x04H\x00R\vtrainBookId\x88\x01\x01\x12'\n" +
+	"\rmatch_book_id\x18\x03 \x01(\x04H\x01R\vmatchBookId\x88\x01\x01\x12.\n" +
+	"\x10train_parameters\x18\x04 \x01(\tH\x02R\x0ftrainParameters\x88\x01\x01\x12.\n" +
+	"\x10match_parameters\x18\x05 \x01(\tH\x03R\x0fmatchParameters\x88\x01\x01\x12+\n" +
+	"\x0fbest_network_id\x18\x06 \x01(\x04H\x04R\rbestNetworkId\x88\x01\x01B\x10\n" +
+	"\x0e_train_book_idB\x10\n" +
+	"\x0e_match_book_idB\x13\n" +
+	"\x11_train_parametersB\x13\n" +
+	"\x11_match_parametersB\x12\n" +
+	"\x10_best_network_id\"B\n" +
+	"\x18ListTrainingTasksRequest\x12&\n" +
+	"\x0ftraining_run_id\x18\x01 \x01(\x04R\rtrainingRunId\"e\n" +
+	"\x19ListTrainingTasksResponse\x12H\n" +
+	"\x0etraining_tasks\x18\x01 \x03(\v2!.lczero.api.v1.TrainingTaskConfigR\rtrainingTasks\"\x88\x03\n" +
+	"\x11CreateSprtRequest\x12>\n" +
+	"\bbaseline\x18\x01 \x01(\v2\".lczero.api.v1.EngineConfigurationR\bbaseline\x12@\n" +
+	"\tcandidate\x18\x02 \x01(\v2\".lczero.api.v1.EngineConfigurationR\tcandidate\x12>\n" +
+	"\fopening_book\x18\x03 \x01(\v2\x1b.lczero.api.v1.ResourceSpecR\vopeningBook\x12=\n" +
+	"\ftime_control\x18\x04 \x01(\v2\x1a.lczero.api.v1.TimeControlR\vtimeControl\x12\x12\n" +
+	"\x04elo0\x18\x05 \x01(\x01R\x04elo0\x12\x12\n" +
+	"\x04elo1\x18\x06 \x01(\x01R\x04elo1\x12\x14\n" +
+	"\x05alpha\x18\a \x01(\x01R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\b \x01(\x01R\x04beta\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\"\x8d\x03\n" +
+	"\bSprtTest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04elo0\x18\x04 \x01(\x01R\x04elo0\x12\x12\n" +
+	"\x04elo1\x18\x05 \x01(\x01R\x04elo1\x12\x14\n" +
+	"\x05alpha\x18\x06 \x01(\x01R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\a \x01(\x01R\x04beta\x12 \n" +
+	"\vpentanomial\x18\b \x03(\x05R\vpentanomial\x12\x1a\n" +
+	"\bunpaired\x18\t \x03(\x05R\bunpaired\x12\x10\n" +
+	"\x03llr\x18\n" +
+	" \x01(\x01R\x03llr\x12\x1f\n" +
+	"\vlower_bound\x18\v \x01(\x01R\n" +
+	"lowerBound\x12\x1f\n" +
+	"\vupper_bound\x18\f \x01(\x01R\n" +
+	"upperBound\x12\x18\n" +
+	"\averdict\x18\r \x01(\tR\averdict\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\" \n" +
+	"\x0eGetSprtRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"=\n" +
+	"\x10ListSprtsRequest\x12)\n" +
+	"\x10include_finished\x18\x01 \x01(\bR\x0fincludeFinished\"B\n" +
+	"\x11ListSprtsResponse\x12-\n" +
+	"\x05sprts\x18\x01 \x03(\v2\x17.lczero.api.v1.SprtTestR\x05sprts\"#\n" +
+	"\x11CancelSprtRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\xc9\x01\n" +
+	"\x0eBayesianConfig\x12%\n" +
+	"\x0einitial_points\x18\x01 \x01(\x05R\rinitialPoints\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x12\x1d\n" +
+	"\n" +
+	"max_points\x18\x03 \x01(\x05R\tmaxPoints\x12<\n" +
+	"\vacquisition\x18\x04 \x01(\x0e2\x1a.lczero.api.v1.AcquisitionR\vacquisition\x12\x14\n" +
+	"\x05kappa\x18\x05 \x01(\x01R\x05kappa\"\xe7\x01\n" +
+	"\x11TuningObservation\x12 \n" +
+	"\fparam_set_id\x18\x01 \x01(\tR\n" +
+	"paramSetId\x12D\n" +
+	"\x06values\x18\x02 \x03(\v2,.lczero.api.v1.TuningObservation.ValuesEntryR\x06values\x12\x10\n" +
+	"\x03elo\x18\x03 \x01(\x01R\x03elo\x12\x1d\n" +
+	"\n" +
+	"elo_stddev\x18\x04 \x01(\x01R\teloStddev\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xa4\x02\n" +
+	"\rTuningOptimum\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12M\n" +
+	"\vuci_options\x18\x02 \x03(\v2,.lczero.api.v1.TuningOptimum.UciOptionsEntryR\n" +
+	"uciOptions\x12\x10\n" +
+	"\x03elo\x18\x03 \x01(\x01R\x03elo\x12\x1d\n" +
+	"\n" +
+	"elo_stddev\x18\x04 \x01(\x01R\teloStddev\x12D\n" +
+	"\fobservations\x18\x05 \x03(\v2 .lczero.api.v1.TuningObservationR\fobservations\x1a=\n" +
+	"\x0fUciOptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9a\x01\n" +
+	"\x0fTuningParameter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03min\x18\x02 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x03 \x01(\x01R\x03max\x12\x12\n" +
+	"\x04step\x18\x04 \x01(\x01R\x04step\x12\x16\n" +
+	"\x06values\x18\x05 \x03(\tR\x06values\x12\x19\n" +
+	"\x05start\x18\x06 \x01(\x01H\x00R\x05start\x88\x01\x01B\b\n" +
+	"\x06_start\"\x92\x01\n" +
+	"\n" +
+	"SpsaConfig\x12\f\n" +
+	"\x01a\x18\x01 \x01(\x01R\x01a\x12\f\n" +
+	"\x01c\x18\x02 \x01(\x01R\x01c\x12\x1c\n" +
+	"\tstability\x18\x03 \x01(\x01R\tstability\x12\x14\n" +
+	"\x05alpha\x18\x04 \x01(\x01R\x05alpha\x12\x14\n" +
+	"\x05gamma\x18\x05 \x01(\x01R\x05gamma\x12\x1e\n" +
+	"\n" +
+	"iterations\x18\x06 \x01(\x05R\n" +
+	"iterations\"\xae\x02\n" +
+	"\x13SpsaIterationResult\x12\x1c\n" +
+	"\titeration\x18\x01 \x01(\x05R\titeration\x12F\n" +
+	"\x06values\x18\x02 \x03(\v2..lczero.api.v1.SpsaIterationResult.ValuesEntryR\x06values\x12!\n" +
+	"\fgames_played\x18\x03 \x01(\x05R\vgamesPlayed\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\x12=\n" +
+	"\fcompleted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xa8\x02\n" +
+	"\tSpsaState\x121\n" +
+	"\x06config\x18\x01 \x01(\v2\x19.lczero.api.v1.SpsaConfigR\x06config\x121\n" +
+	"\x14completed_iterations\x18\x02 \x01(\x05R\x13completedIterations\x12<\n" +
+	"\x06values\x18\x03 \x03(\v2$.lczero.api.v1.SpsaState.ValuesEntryR\x06values\x12<\n" +
+	"\ahistory\x18\x04 \x03(\v2\".lczero.api.v1.SpsaIterationResultR\ahistory\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x98\x05\n" +
+	"\x13CreateTuningRequest\x12.\n" +
+	"\x05build\x18\x01 \x01(\v2\x18.lczero.api.v1.BuildSpecR\x05build\x125\n" +
+	"\anetwork\x18\x02 \x01(\v2\x1b.lczero.api.v1.ResourceSpecR\anetwork\x12>\n" +
+	"\fopening_book\x18\x03 \x01(\v2\x1b.lczero.api.v1.ResourceSpecR\vopeningBook\x12=\n" +
+	"\ftime_control\x18\x04 \x01(\v2\x1a.lczero.api.v1.TimeControlR\vtimeControl\x12-\n" +
+	"\x13games_per_param_set\x18\x05 \x01(\x05R\x10gamesPerParamSet\x12<\n" +
+	"\vbase_params\x18\x06 \x01(\v2\x1b.lczero.api.v1.EngineParamsR\n" +
+	"baseParams\x12>\n" +
+	"\n" +
+	"parameters\x18\a \x03(\v2\x1e.lczero.api.v1.TuningParameterR\n" +
+	"parameters\x12-\n" +
+	"\x04mode\x18\b \x01(\x0e2\x19.lczero.api.v1.TuningModeR\x04mode\x12\x1f\n" +
+	"\vnum_samples\x18\t \x01(\x05R\n" +
+	"numSamples\x12\x12\n" +
+	"\x04seed\x18\n" +
+	" \x01(\x04R\x04seed\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\x12-\n" +
+	"\x04spsa\x18\f \x01(\v2\x19.lczero.api.v1.SpsaConfigR\x04spsa\x129\n" +
+	"\bbayesian\x18\r \x01(\v2\x1d.lczero.api.v1.BayesianConfigR\bbayesian\"\xf7\x01\n" +
+	"\rTunedParamSet\x12 \n" +
+	"\fparam_set_id\x18\x01 \x01(\tR\n" +
+	"paramSetId\x123\n" +
+	"\x06params\x18\x02 \x01(\v2\x1b.lczero.api.v1.EngineParamsR\x06params\x12!\n" +
+	"\fgames_played\x18\x03 \x01(\x05R\vgamesPlayed\x12 \n" +
+	"\vpentanomial\x18\x04 \x03(\x05R\vpentanomial\x12\x10\n" +
+	"\x03elo\x18\x05 \x01(\x01R\x03elo\x12\x1b\n" +
+	"\telo_lower\x18\x06 \x01(\x01R\beloLower\x12\x1b\n" +
+	"\telo_upper\x18\a \x01(\x01R\beloUpper\"\x94\x03\n" +
+	"\tTuningJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12-\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x19.lczero.api.v1.TuningModeR\x04mode\x12-\n" +
+	"\x13games_per_param_set\x18\x05 \x01(\x05R\x10gamesPerParamSet\x12;\n" +
+	"\n" +
+	"param_sets\x18\x06 \x03(\v2\x1c.lczero.api.v1.TunedParamSetR\tparamSets\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12,\n" +
+	"\x04spsa\x18\b \x01(\v2\x18.lczero.api.v1.SpsaStateR\x04spsa\x129\n" +
+	"\bbayesian\x18\t \x01(\v2\x1d.lczero.api.v1.BayesianConfigR\bbayesian\"\"\n" +
+	"\x10GetTuningRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"?\n" +
+	"\x12ListTuningsRequest\x12)\n" +
+	"\x10include_finished\x18\x01 \x01(\bR\x0fincludeFinished\"I\n" +
+	"\x13ListTuningsResponse\x122\n" +
+	"\atunings\x18\x01 \x03(\v2\x18.lczero.api.v1.TuningJobR\atunings\"%\n" +
+	"\x13CancelTuningRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"0\n" +
+	"\x1aClientVersionReportRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\"\xf1\x01\n" +
+	"\x12ClientVersionCount\x12%\n" +
+	"\x0eclient_version\x18\x01 \x01(\tR\rclientVersion\x12%\n" +
+	"\x0eengine_version\x18\x02 \x01(\tR\rengineVersion\x12\x18\n" +
+	"\aclients\x18\x03 \x01(\x03R\aclients\x127\n" +
+	"\tlast_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x1b\n" +
+	"\tbelow_min\x18\x05 \x01(\bR\bbelowMin\x12\x1d\n" +
+	"\n" +
+	"below_next\x18\x06 \x01(\bR\tbelowNext\"\x9a\x03\n" +
+	"\x13ClientVersionReport\x12=\n" +
+	"\bversions\x18\x01 \x03(\v2!.lczero.api.v1.ClientVersionCountR\bversions\x12#\n" +
+	"\rtotal_clients\x18\x02 \x01(\x03R\ftotalClients\x12&\n" +
+	"\x0fclients_at_next\x18\x03 \x01(\x03R\rclientsAtNext\x12,\n" +
+	"\x12min_client_version\x18\x04 \x01(\tR\x10minClientVersion\x12.\n" +
+	"\x13next_client_version\x18\x05 \x01(\tR\x11nextClientVersion\x12,\n" +
+	"\x12min_engine_version\x18\x06 \x01(\tR\x10minEngineVersion\x12.\n" +
+	"\x13next_engine_version\x18\a \x01(\tR\x11nextEngineVersion\x12;\n" +
+	"\vrequired_by\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"requiredBy\">\n" +
+	"\x14SetTaskWeightRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\"\x84\x02\n" +
+	"\tTaskShare\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12+\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x17.lczero.api.v1.TaskTypeR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x01R\x06weight\x12!\n" +
+	"\ftarget_share\x18\x05 \x01(\x01R\vtargetShare\x12 \n" +
+	"\vassignments\x18\x06 \x01(\x03R\vassignments\x12%\n" +
+	"\x0eobserved_share\x18\a \x01(\x01R\robservedShare\x12\x14\n" +
+	"\x05games\x18\b \x01(\x03R\x05games\"\x17\n" +
+	"\x15ListTaskSharesRequest\"q\n" +
+	"\x16ListTaskSharesResponse\x120\n" +
+	"\x06shares\x18\x01 \x03(\v2\x18.lczero.api.v1.TaskShareR\x06shares\x12%\n" +
+	"\x0ewindow_seconds\x18\x02 \x01(\x03R\rwindowSeconds*T\n" +
 	"\bTaskType\x12\x19\n" +
 	"\x15TASK_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bTRAINING\x10\x01\x12\t\n" +
@@ -2295,7 +6005,12 @@ const file_api_v1_lczero_proto_rawDesc = "" +
 	"\fResourceType\x12\x1d\n" +
 	"\x19RESOURCE_TYPE_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aNETWORK\x10\x01\x12\b\n" +
-	"\x04BOOK\x10\x02*U\n" +
+	"\x04BOOK\x10\x02*e\n" +
+	"\vTokenStatus\x12\x1c\n" +
+	"\x18TOKEN_STATUS_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTOKEN_ACTIVE\x10\x01\x12\x11\n" +
+	"\rTOKEN_REVOKED\x10\x02\x12\x13\n" +
+	"\x0fTOKEN_SUSPENDED\x10\x03*U\n" +
 	"\fShortOutcome\x12\x1d\n" +
 	"\x19SHORT_OUTCOME_UNSPECIFIED\x10\x00\x12\r\n" +
 	"\tWHITE_WIN\x10\x01\x12\r\n" +
@@ -2307,13 +6022,51 @@ const file_api_v1_lczero_proto_rawDesc = "" +
 	"\tCHECKMATE\x10\x02\x12\x10\n" +
 	"\fADJUDICATION\x10\x03\x12\v\n" +
 	"\aTIMEOUT\x10\x04\x12\x0f\n" +
-	"\vRESIGNATION\x10\x052\xc2\x01\n" +
+	"\vRESIGNATION\x10\x05*s\n" +
+	"\n" +
+	"TuningMode\x12\x1b\n" +
+	"\x17TUNING_MODE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vTUNING_GRID\x10\x01\x12\x11\n" +
+	"\rTUNING_RANDOM\x10\x02\x12\x0f\n" +
+	"\vTUNING_SPSA\x10\x03\x12\x13\n" +
+	"\x0fTUNING_BAYESIAN\x10\x04*S\n" +
+	"\vAcquisition\x12\x1b\n" +
+	"\x17ACQUISITION_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eACQUISITION_EI\x10\x01\x12\x13\n" +
+	"\x0fACQUISITION_UCB\x10\x022\xba\x03\n" +
 	"\vAuthService\x12[\n" +
 	"\x12MigrateCredentials\x12(.lczero.api.v1.MigrateCredentialsRequest\x1a\x1b.lczero.api.v1.AuthResponse\x12V\n" +
-	"\x11GetAnonymousToken\x12$.lczero.api.v1.AnonymousTokenRequest\x1a\x1b.lczero.api.v1.AuthResponse2\xa7\x01\n" +
+	"\x11GetAnonymousToken\x12$.lczero.api.v1.AnonymousTokenRequest\x1a\x1b.lczero.api.v1.AuthResponse\x12T\n" +
+	"\vRevokeToken\x12!.lczero.api.v1.RevokeTokenRequest\x1a\".lczero.api.v1.RevokeTokenResponse\x12Q\n" +
+	"\n" +
+	"ListTokens\x12 .lczero.api.v1.ListTokensRequest\x1a!.lczero.api.v1.ListTokensResponse\x12M\n" +
+	"\vRotateToken\x12!.lczero.api.v1.RotateTokenRequest\x1a\x1b.lczero.api.v1.AuthResponse2\xa7\x01\n" +
 	"\vTaskService\x12F\n" +
 	"\vGetNextTask\x12\x1a.lczero.api.v1.TaskRequest\x1a\x1b.lczero.api.v1.TaskResponse\x12P\n" +
-	"\x0eReportProgress\x12\x1d.lczero.api.v1.ProgressReport\x1a\x1f.lczero.api.v1.ProgressResponseB7Z5github.com/leelachesszero/lczero-server/api/v1/lczerob\x06proto3"
+	"\x0eReportProgress\x12\x1d.lczero.api.v1.ProgressReport\x1a\x1f.lczero.api.v1.ProgressResponse2\xd1\r\n" +
+	"\fAdminService\x12X\n" +
+	"\x11CreateTrainingRun\x12'.lczero.api.v1.CreateTrainingRunRequest\x1a\x1a.lczero.api.v1.TrainingRun\x12X\n" +
+	"\x11UpdateTrainingRun\x12'.lczero.api.v1.UpdateTrainingRunRequest\x1a\x1a.lczero.api.v1.TrainingRun\x12S\n" +
+	"\x14SetTrainingRunActive\x12\x1f.lczero.api.v1.SetActiveRequest\x1a\x1a.lczero.api.v1.TrainingRun\x12c\n" +
+	"\x10ListTrainingRuns\x12&.lczero.api.v1.ListTrainingRunsRequest\x1a'.lczero.api.v1.ListTrainingRunsResponse\x12a\n" +
+	"\x12CreateTrainingTask\x12(.lczero.api.v1.CreateTrainingTaskRequest\x1a!.lczero.api.v1.TrainingTaskConfig\x12a\n" +
+	"\x12UpdateTrainingTask\x12(.lczero.api.v1.UpdateTrainingTaskRequest\x1a!.lczero.api.v1.TrainingTaskConfig\x12[\n" +
+	"\x15SetTrainingTaskActive\x12\x1f.lczero.api.v1.SetActiveRequest\x1a!.lczero.api.v1.TrainingTaskConfig\x12f\n" +
+	"\x11ListTrainingTasks\x12'.lczero.api.v1.ListTrainingTasksRequest\x1a(.lczero.api.v1.ListTrainingTasksResponse\x12G\n" +
+	"\n" +
+	"CreateSprt\x12 .lczero.api.v1.CreateSprtRequest\x1a\x17.lczero.api.v1.SprtTest\x12A\n" +
+	"\aGetSprt\x12\x1d.lczero.api.v1.GetSprtRequest\x1a\x17.lczero.api.v1.SprtTest\x12N\n" +
+	"\tListSprts\x12\x1f.lczero.api.v1.ListSprtsRequest\x1a .lczero.api.v1.ListSprtsResponse\x12G\n" +
+	"\n" +
+	"CancelSprt\x12 .lczero.api.v1.CancelSprtRequest\x1a\x17.lczero.api.v1.SprtTest\x12L\n" +
+	"\fCreateTuning\x12\".lczero.api.v1.CreateTuningRequest\x1a\x18.lczero.api.v1.TuningJob\x12F\n" +
+	"\tGetTuning\x12\x1f.lczero.api.v1.GetTuningRequest\x1a\x18.lczero.api.v1.TuningJob\x12T\n" +
+	"\vListTunings\x12!.lczero.api.v1.ListTuningsRequest\x1a\".lczero.api.v1.ListTuningsResponse\x12L\n" +
+	"\fCancelTuning\x12\".lczero.api.v1.CancelTuningRequest\x1a\x18.lczero.api.v1.TuningJob\x12Q\n" +
+	"\x10GetTuningOptimum\x12\x1f.lczero.api.v1.GetTuningRequest\x1a\x1c.lczero.api.v1.TuningOptimum\x12g\n" +
+	"\x16GetClientVersionReport\x12).lczero.api.v1.ClientVersionReportRequest\x1a\".lczero.api.v1.ClientVersionReport\x12N\n" +
+	"\rSetTaskWeight\x12#.lczero.api.v1.SetTaskWeightRequest\x1a\x18.lczero.api.v1.TaskShare\x12]\n" +
+	"\x0eListTaskShares\x12$.lczero.api.v1.ListTaskSharesRequest\x1a%.lczero.api.v1.ListTaskSharesResponseB7Z5github.com/leelachesszero/lczero-server/api/v1/lczerob\x06proto3"
 
 var (
 	file_api_v1_lczero_proto_rawDescOnce sync.Once
@@ -2327,109 +6080,253 @@ func file_api_v1_lczero_proto_rawDescGZIP() []byte {
 	return file_api_v1_lczero_proto_rawDescData
 }
 
-var file_api_v1_lczero_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_api_v1_lczero_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_api_v1_lczero_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_api_v1_lczero_proto_msgTypes = make([]protoimpl.MessageInfo, 81)
 var file_api_v1_lczero_proto_goTypes = []any{
-	(TaskType)(0),                     // 0: lczero.api.v1.TaskType
-	(ResourceType)(0),                 // 1: lczero.api.v1.ResourceType
-	(ShortOutcome)(0),                 // 2: lczero.api.v1.ShortOutcome
-	(DetailedOutcome)(0),              // 3: lczero.api.v1.DetailedOutcome
-	(ProgressResponse_Status)(0),      // 4: lczero.api.v1.ProgressResponse.Status
-	(CrashReport_CrashType)(0),        // 5: lczero.api.v1.CrashReport.CrashType
-	(*ClientInfo)(nil),                // 6: lczero.api.v1.ClientInfo
-	(*ResourceSpec)(nil),              // 7: lczero.api.v1.ResourceSpec
-	(*EngineParams)(nil),              // 8: lczero.api.v1.EngineParams
-	(*BuildSpec)(nil),                 // 9: lczero.api.v1.BuildSpec
-	(*TimeControl)(nil),               // 10: lczero.api.v1.TimeControl
-	(*TaskResponse)(nil),              // 11: lczero.api.v1.TaskResponse
-	(*EngineConfiguration)(nil),       // 12: lczero.api.v1.EngineConfiguration
-	(*TrainingTask)(nil),              // 13: lczero.api.v1.TrainingTask
-	(*MatchTask)(nil),                 // 14: lczero.api.v1.MatchTask
-	(*SprtTask)(nil),                  // 15: lczero.api.v1.SprtTask
-	(*TuningTask)(nil),                // 16: lczero.api.v1.TuningTask
-	(*ParamSet)(nil),                  // 17: lczero.api.v1.ParamSet
-	(*MigrateCredentialsRequest)(nil), // 18: lczero.api.v1.MigrateCredentialsRequest
-	(*AnonymousTokenRequest)(nil),     // 19: lczero.api.v1.AnonymousTokenRequest
-	(*AuthResponse)(nil),              // 20: lczero.api.v1.AuthResponse
-	(*TaskRequest)(nil),               // 21: lczero.api.v1.TaskRequest
-	(*ProgressReport)(nil),            // 22: lczero.api.v1.ProgressReport
-	(*ProgressResponse)(nil),          // 23: lczero.api.v1.ProgressResponse
-	(*GameData)(nil),                  // 24: lczero.api.v1.GameData
-	(*CrashReport)(nil),               // 25: lczero.api.v1.CrashReport
-	(*TrainingProgress)(nil),          // 26: lczero.api.v1.TrainingProgress
-	(*MatchGame)(nil),                 // 27: lczero.api.v1.MatchGame
-	(*MatchProgress)(nil),             // 28: lczero.api.v1.MatchProgress
-	(*SprtPairReport)(nil),            // 29: lczero.api.v1.SprtPairReport
-	(*SprtProgress)(nil),              // 30: lczero.api.v1.SprtProgress
-	(*TuningPairResult)(nil),          // 31: lczero.api.v1.TuningPairResult
-	(*TuningParamSetResult)(nil),      // 32: lczero.api.v1.TuningParamSetResult
-	(*TuningProgress)(nil),            // 33: lczero.api.v1.TuningProgress
-	nil,                               // 34: lczero.api.v1.EngineParams.UciOptionsEntry
-	nil,                               // 35: lczero.api.v1.BuildSpec.BuildParamsEntry
-	(*TimeControl_TimeBased)(nil),     // 36: lczero.api.v1.TimeControl.TimeBased
-	nil,                               // 37: lczero.api.v1.CrashReport.LogsEntry
+	(TaskType)(0),                      // 0: lczero.api.v1.TaskType
+	(ResourceType)(0),                  // 1: lczero.api.v1.ResourceType
+	(TokenStatus)(0),                   // 2: lczero.api.v1.TokenStatus
+	(ShortOutcome)(0),                  // 3: lczero.api.v1.ShortOutcome
+	(DetailedOutcome)(0),               // 4: lczero.api.v1.DetailedOutcome
+	(TuningMode)(0),                    // 5: lczero.api.v1.TuningMode
+	(Acquisition)(0),                   // 6: lczero.api.v1.Acquisition
+	(ProgressResponse_Status)(0),       // 7: lczero.api.v1.ProgressResponse.Status
+	(CrashReport_CrashType)(0),         // 8: lczero.api.v1.CrashReport.CrashType
+	(*ClientInfo)(nil),                 // 9: lczero.api.v1.ClientInfo
+	(*ResourceSpec)(nil),               // 10: lczero.api.v1.ResourceSpec
+	(*EngineParams)(nil),               // 11: lczero.api.v1.EngineParams
+	(*BuildSpec)(nil),                  // 12: lczero.api.v1.BuildSpec
+	(*TimeControl)(nil),                // 13: lczero.api.v1.TimeControl
+	(*TaskResponse)(nil),               // 14: lczero.api.v1.TaskResponse
+	(*UpgradeAdvisory)(nil),            // 15: lczero.api.v1.UpgradeAdvisory
+	(*EngineConfiguration)(nil),        // 16: lczero.api.v1.EngineConfiguration
+	(*TrainingTask)(nil),               // 17: lczero.api.v1.TrainingTask
+	(*MatchTask)(nil),                  // 18: lczero.api.v1.MatchTask
+	(*SprtTask)(nil),                   // 19: lczero.api.v1.SprtTask
+	(*TuningTask)(nil),                 // 20: lczero.api.v1.TuningTask
+	(*ParamSet)(nil),                   // 21: lczero.api.v1.ParamSet
+	(*MigrateCredentialsRequest)(nil),  // 22: lczero.api.v1.MigrateCredentialsRequest
+	(*AnonymousTokenRequest)(nil),      // 23: lczero.api.v1.AnonymousTokenRequest
+	(*AuthResponse)(nil),               // 24: lczero.api.v1.AuthResponse
+	(*TokenInfo)(nil),                  // 25: lczero.api.v1.TokenInfo
+	(*RevokeTokenRequest)(nil),         // 26: lczero.api.v1.RevokeTokenRequest
+	(*RevokeTokenResponse)(nil),        // 27: lczero.api.v1.RevokeTokenResponse
+	(*ListTokensRequest)(nil),          // 28: lczero.api.v1.ListTokensRequest
+	(*ListTokensResponse)(nil),         // 29: lczero.api.v1.ListTokensResponse
+	(*RotateTokenRequest)(nil),         // 30: lczero.api.v1.RotateTokenRequest
+	(*TaskRequest)(nil),                // 31: lczero.api.v1.TaskRequest
+	(*ProgressReport)(nil),             // 32: lczero.api.v1.ProgressReport
+	(*ProgressResponse)(nil),           // 33: lczero.api.v1.ProgressResponse
+	(*GameData)(nil),                   // 34: lczero.api.v1.GameData
+	(*CrashReport)(nil),                // 35: lczero.api.v1.CrashReport
+	(*TrainingProgress)(nil),           // 36: lczero.api.v1.TrainingProgress
+	(*MatchGame)(nil),                  // 37: lczero.api.v1.MatchGame
+	(*MatchProgress)(nil),              // 38: lczero.api.v1.MatchProgress
+	(*SprtPairReport)(nil),             // 39: lczero.api.v1.SprtPairReport
+	(*SprtProgress)(nil),               // 40: lczero.api.v1.SprtProgress
+	(*TuningPairResult)(nil),           // 41: lczero.api.v1.TuningPairResult
+	(*TuningParamSetResult)(nil),       // 42: lczero.api.v1.TuningParamSetResult
+	(*TuningProgress)(nil),             // 43: lczero.api.v1.TuningProgress
+	(*TrainingRun)(nil),                // 44: lczero.api.v1.TrainingRun
+	(*CreateTrainingRunRequest)(nil),   // 45: lczero.api.v1.CreateTrainingRunRequest
+	(*UpdateTrainingRunRequest)(nil),   // 46: lczero.api.v1.UpdateTrainingRunRequest
+	(*SetActiveRequest)(nil),           // 47: lczero.api.v1.SetActiveRequest
+	(*ListTrainingRunsRequest)(nil),    // 48: lczero.api.v1.ListTrainingRunsRequest
+	(*ListTrainingRunsResponse)(nil),   // 49: lczero.api.v1.ListTrainingRunsResponse
+	(*TrainingTaskConfig)(nil),         // 50: lczero.api.v1.TrainingTaskConfig
+	(*CreateTrainingTaskRequest)(nil),  // 51: lczero.api.v1.CreateTrainingTaskRequest
+	(*UpdateTrainingTaskRequest)(nil),  // 52: lczero.api.v1.UpdateTrainingTaskRequest
+	(*ListTrainingTasksRequest)(nil),   // 53: lczero.api.v1.ListTrainingTasksRequest
+	(*ListTrainingTasksResponse)(nil),  // 54: lczero.api.v1.ListTrainingTasksResponse
+	(*CreateSprtRequest)(nil),          // 55: lczero.api.v1.CreateSprtRequest
+	(*SprtTest)(nil),                   // 56: lczero.api.v1.SprtTest
+	(*GetSprtRequest)(nil),             // 57: lczero.api.v1.GetSprtRequest
+	(*ListSprtsRequest)(nil),           // 58: lczero.api.v1.ListSprtsRequest
+	(*ListSprtsResponse)(nil),          // 59: lczero.api.v1.ListSprtsResponse
+	(*CancelSprtRequest)(nil),          // 60: lczero.api.v1.CancelSprtRequest
+	(*BayesianConfig)(nil),             // 61: lczero.api.v1.BayesianConfig
+	(*TuningObservation)(nil),          // 62: lczero.api.v1.TuningObservation
+	(*TuningOptimum)(nil),              // 63: lczero.api.v1.TuningOptimum
+	(*TuningParameter)(nil),            // 64: lczero.api.v1.TuningParameter
+	(*SpsaConfig)(nil),                 // 65: lczero.api.v1.SpsaConfig
+	(*SpsaIterationResult)(nil),        // 66: lczero.api.v1.SpsaIterationResult
+	(*SpsaState)(nil),                  // 67: lczero.api.v1.SpsaState
+	(*CreateTuningRequest)(nil),        // 68: lczero.api.v1.CreateTuningRequest
+	(*TunedParamSet)(nil),              // 69: lczero.api.v1.TunedParamSet
+	(*TuningJob)(nil),                  // 70: lczero.api.v1.TuningJob
+	(*GetTuningRequest)(nil),           // 71: lczero.api.v1.GetTuningRequest
+	(*ListTuningsRequest)(nil),         // 72: lczero.api.v1.ListTuningsRequest
+	(*ListTuningsResponse)(nil),        // 73: lczero.api.v1.ListTuningsResponse
+	(*CancelTuningRequest)(nil),        // 74: lczero.api.v1.CancelTuningRequest
+	(*ClientVersionReportRequest)(nil), // 75: lczero.api.v1.ClientVersionReportRequest
+	(*ClientVersionCount)(nil),         // 76: lczero.api.v1.ClientVersionCount
+	(*ClientVersionReport)(nil),        // 77: lczero.api.v1.ClientVersionReport
+	(*SetTaskWeightRequest)(nil),       // 78: lczero.api.v1.SetTaskWeightRequest
+	(*TaskShare)(nil),                  // 79: lczero.api.v1.TaskShare
+	(*ListTaskSharesRequest)(nil),      // 80: lczero.api.v1.ListTaskSharesRequest
+	(*ListTaskSharesResponse)(nil),     // 81: lczero.api.v1.ListTaskSharesResponse
+	nil,                                // 82: lczero.api.v1.EngineParams.UciOptionsEntry
+	nil,                                // 83: lczero.api.v1.BuildSpec.BuildParamsEntry
+	(*TimeControl_TimeBased)(nil),      // 84: lczero.api.v1.TimeControl.TimeBased
+	nil,                                // 85: lczero.api.v1.CrashReport.LogsEntry
+	nil,                                // 86: lczero.api.v1.TuningObservation.ValuesEntry
+	nil,                                // 87: lczero.api.v1.TuningOptimum.UciOptionsEntry
+	nil,                                // 88: lczero.api.v1.SpsaIterationResult.ValuesEntry
+	nil,                                // 89: lczero.api.v1.SpsaState.ValuesEntry
+	(*timestamppb.Timestamp)(nil),      // 90: google.protobuf.Timestamp
 }
 var file_api_v1_lczero_proto_depIdxs = []int32{
-	0,  // 0: lczero.api.v1.ClientInfo.supported_task_types:type_name -> lczero.api.v1.TaskType
-	1,  // 1: lczero.api.v1.ResourceSpec.type:type_name -> lczero.api.v1.ResourceType
-	34, // 2: lczero.api.v1.EngineParams.uci_options:type_name -> lczero.api.v1.EngineParams.UciOptionsEntry
-	35, // 3: lczero.api.v1.BuildSpec.build_params:type_name -> lczero.api.v1.BuildSpec.BuildParamsEntry
-	36, // 4: lczero.api.v1.TimeControl.time_based:type_name -> lczero.api.v1.TimeControl.TimeBased
-	13, // 5: lczero.api.v1.TaskResponse.training:type_name -> lczero.api.v1.TrainingTask
-	14, // 6: lczero.api.v1.TaskResponse.match:type_name -> lczero.api.v1.MatchTask
-	15, // 7: lczero.api.v1.TaskResponse.sprt:type_name -> lczero.api.v1.SprtTask
-	16, // 8: lczero.api.v1.TaskResponse.tuning:type_name -> lczero.api.v1.TuningTask
-	9,  // 9: lczero.api.v1.EngineConfiguration.build:type_name -> lczero.api.v1.BuildSpec
-	7,  // 10: lczero.api.v1.EngineConfiguration.network:type_name -> lczero.api.v1.ResourceSpec
-	8,  // 11: lczero.api.v1.EngineConfiguration.params:type_name -> lczero.api.v1.EngineParams
-	12, // 12: lczero.api.v1.TrainingTask.engine:type_name -> lczero.api.v1.EngineConfiguration
-	7,  // 13: lczero.api.v1.TrainingTask.opening_book:type_name -> lczero.api.v1.ResourceSpec
-	12, // 14: lczero.api.v1.MatchTask.baseline:type_name -> lczero.api.v1.EngineConfiguration
-	12, // 15: lczero.api.v1.MatchTask.candidate:type_name -> lczero.api.v1.EngineConfiguration
-	7,  // 16: lczero.api.v1.MatchTask.opening_book:type_name -> lczero.api.v1.ResourceSpec
-	12, // 17: lczero.api.v1.SprtTask.baseline:type_name -> lczero.api.v1.EngineConfiguration
-	12, // 18: lczero.api.v1.SprtTask.candidate:type_name -> lczero.api.v1.EngineConfiguration
-	7,  // 19: lczero.api.v1.SprtTask.opening_book:type_name -> lczero.api.v1.ResourceSpec
-	10, // 20: lczero.api.v1.SprtTask.time_control:type_name -> lczero.api.v1.TimeControl
-	9,  // 21: lczero.api.v1.TuningTask.build:type_name -> lczero.api.v1.BuildSpec
-	7,  // 22: lczero.api.v1.TuningTask.network:type_name -> lczero.api.v1.ResourceSpec
-	7,  // 23: lczero.api.v1.TuningTask.opening_book:type_name -> lczero.api.v1.ResourceSpec
-	17, // 24: lczero.api.v1.TuningTask.param_sets:type_name -> lczero.api.v1.ParamSet
-	10, // 25: lczero.api.v1.TuningTask.time_control:type_name -> lczero.api.v1.TimeControl
-	8,  // 26: lczero.api.v1.ParamSet.params:type_name -> lczero.api.v1.EngineParams
-	6,  // 27: lczero.api.v1.TaskRequest.client_info:type_name -> lczero.api.v1.ClientInfo
-	26, // 28: lczero.api.v1.ProgressReport.training:type_name -> lczero.api.v1.TrainingProgress
-	28, // 29: lczero.api.v1.ProgressReport.match:type_name -> lczero.api.v1.MatchProgress
-	30, // 30: lczero.api.v1.ProgressReport.sprt:type_name -> lczero.api.v1.SprtProgress
-	33, // 31: lczero.api.v1.ProgressReport.tuning:type_name -> lczero.api.v1.TuningProgress
-	25, // 32: lczero.api.v1.ProgressReport.crash_reports:type_name -> lczero.api.v1.CrashReport
-	4,  // 33: lczero.api.v1.ProgressResponse.status:type_name -> lczero.api.v1.ProgressResponse.Status
-	5,  // 34: lczero.api.v1.CrashReport.type:type_name -> lczero.api.v1.CrashReport.CrashType
-	37, // 35: lczero.api.v1.CrashReport.logs:type_name -> lczero.api.v1.CrashReport.LogsEntry
-	24, // 36: lczero.api.v1.TrainingProgress.games:type_name -> lczero.api.v1.GameData
-	2,  // 37: lczero.api.v1.MatchGame.short_outcome:type_name -> lczero.api.v1.ShortOutcome
-	3,  // 38: lczero.api.v1.MatchGame.detailed_outcome:type_name -> lczero.api.v1.DetailedOutcome
-	27, // 39: lczero.api.v1.MatchProgress.games:type_name -> lczero.api.v1.MatchGame
-	27, // 40: lczero.api.v1.SprtPairReport.game1:type_name -> lczero.api.v1.MatchGame
-	27, // 41: lczero.api.v1.SprtPairReport.game2:type_name -> lczero.api.v1.MatchGame
-	29, // 42: lczero.api.v1.SprtProgress.pairs:type_name -> lczero.api.v1.SprtPairReport
-	27, // 43: lczero.api.v1.TuningPairResult.game1:type_name -> lczero.api.v1.MatchGame
-	27, // 44: lczero.api.v1.TuningPairResult.game2:type_name -> lczero.api.v1.MatchGame
-	31, // 45: lczero.api.v1.TuningParamSetResult.pairs:type_name -> lczero.api.v1.TuningPairResult
-	32, // 46: lczero.api.v1.TuningProgress.results:type_name -> lczero.api.v1.TuningParamSetResult
-	18, // 47: lczero.api.v1.AuthService.MigrateCredentials:input_type -> lczero.api.v1.MigrateCredentialsRequest
-	19, // 48: lczero.api.v1.AuthService.GetAnonymousToken:input_type -> lczero.api.v1.AnonymousTokenRequest
-	21, // 49: lczero.api.v1.TaskService.GetNextTask:input_type -> lczero.api.v1.TaskRequest
-	22, // 50: lczero.api.v1.TaskService.ReportProgress:input_type -> lczero.api.v1.ProgressReport
-	20, // 51: lczero.api.v1.AuthService.MigrateCredentials:output_type -> lczero.api.v1.AuthResponse
-	20, // 52: lczero.api.v1.AuthService.GetAnonymousToken:output_type -> lczero.api.v1.AuthResponse
-	11, // 53: lczero.api.v1.TaskService.GetNextTask:output_type -> lczero.api.v1.TaskResponse
-	23, // 54: lczero.api.v1.TaskService.ReportProgress:output_type -> lczero.api.v1.ProgressResponse
-	51, // [51:55] is the sub-list for method output_type
-	47, // [47:51] is the sub-list for method input_type
-	47, // [47:47] is the sub-list for extension type_name
-	47, // [47:47] is the sub-list for extension extendee
-	0,  // [0:47] is the sub-list for field type_name
+	0,   // 0: lczero.api.v1.ClientInfo.supported_task_types:type_name -> lczero.api.v1.TaskType
+	1,   // 1: lczero.api.v1.ResourceSpec.type:type_name -> lczero.api.v1.ResourceType
+	82,  // 2: lczero.api.v1.EngineParams.uci_options:type_name -> lczero.api.v1.EngineParams.UciOptionsEntry
+	83,  // 3: lczero.api.v1.BuildSpec.build_params:type_name -> lczero.api.v1.BuildSpec.BuildParamsEntry
+	84,  // 4: lczero.api.v1.TimeControl.time_based:type_name -> lczero.api.v1.TimeControl.TimeBased
+	17,  // 5: lczero.api.v1.TaskResponse.training:type_name -> lczero.api.v1.TrainingTask
+	18,  // 6: lczero.api.v1.TaskResponse.match:type_name -> lczero.api.v1.MatchTask
+	19,  // 7: lczero.api.v1.TaskResponse.sprt:type_name -> lczero.api.v1.SprtTask
+	20,  // 8: lczero.api.v1.TaskResponse.tuning:type_name -> lczero.api.v1.TuningTask
+	15,  // 9: lczero.api.v1.TaskResponse.upgrade:type_name -> lczero.api.v1.UpgradeAdvisory
+	90,  // 10: lczero.api.v1.UpgradeAdvisory.required_by:type_name -> google.protobuf.Timestamp
+	12,  // 11: lczero.api.v1.EngineConfiguration.build:type_name -> lczero.api.v1.BuildSpec
+	10,  // 12: lczero.api.v1.EngineConfiguration.network:type_name -> lczero.api.v1.ResourceSpec
+	11,  // 13: lczero.api.v1.EngineConfiguration.params:type_name -> lczero.api.v1.EngineParams
+	16,  // 14: lczero.api.v1.TrainingTask.engine:type_name -> lczero.api.v1.EngineConfiguration
+	10,  // 15: lczero.api.v1.TrainingTask.opening_book:type_name -> lczero.api.v1.ResourceSpec
+	16,  // 16: lczero.api.v1.MatchTask.baseline:type_name -> lczero.api.v1.EngineConfiguration
+	16,  // 17: lczero.api.v1.MatchTask.candidate:type_name -> lczero.api.v1.EngineConfiguration
+	10,  // 18: lczero.api.v1.MatchTask.opening_book:type_name -> lczero.api.v1.ResourceSpec
+	16,  // 19: lczero.api.v1.SprtTask.baseline:type_name -> lczero.api.v1.EngineConfiguration
+	16,  // 20: lczero.api.v1.SprtTask.candidate:type_name -> lczero.api.v1.EngineConfiguration
+	10,  // 21: lczero.api.v1.SprtTask.opening_book:type_name -> lczero.api.v1.ResourceSpec
+	13,  // 22: lczero.api.v1.SprtTask.time_control:type_name -> lczero.api.v1.TimeControl
+	12,  // 23: lczero.api.v1.TuningTask.build:type_name -> lczero.api.v1.BuildSpec
+	10,  // 24: lczero.api.v1.TuningTask.network:type_name -> lczero.api.v1.ResourceSpec
+	10,  // 25: lczero.api.v1.TuningTask.opening_book:type_name -> lczero.api.v1.ResourceSpec
+	21,  // 26: lczero.api.v1.TuningTask.param_sets:type_name -> lczero.api.v1.ParamSet
+	13,  // 27: lczero.api.v1.TuningTask.time_control:type_name -> lczero.api.v1.TimeControl
+	11,  // 28: lczero.api.v1.ParamSet.params:type_name -> lczero.api.v1.EngineParams
+	2,   // 29: lczero.api.v1.TokenInfo.status:type_name -> lczero.api.v1.TokenStatus
+	90,  // 30: lczero.api.v1.TokenInfo.created_at:type_name -> google.protobuf.Timestamp
+	90,  // 31: lczero.api.v1.TokenInfo.last_used_at:type_name -> google.protobuf.Timestamp
+	25,  // 32: lczero.api.v1.ListTokensResponse.tokens:type_name -> lczero.api.v1.TokenInfo
+	9,   // 33: lczero.api.v1.TaskRequest.client_info:type_name -> lczero.api.v1.ClientInfo
+	36,  // 34: lczero.api.v1.ProgressReport.training:type_name -> lczero.api.v1.TrainingProgress
+	38,  // 35: lczero.api.v1.ProgressReport.match:type_name -> lczero.api.v1.MatchProgress
+	40,  // 36: lczero.api.v1.ProgressReport.sprt:type_name -> lczero.api.v1.SprtProgress
+	43,  // 37: lczero.api.v1.ProgressReport.tuning:type_name -> lczero.api.v1.TuningProgress
+	35,  // 38: lczero.api.v1.ProgressReport.crash_reports:type_name -> lczero.api.v1.CrashReport
+	7,   // 39: lczero.api.v1.ProgressResponse.status:type_name -> lczero.api.v1.ProgressResponse.Status
+	15,  // 40: lczero.api.v1.ProgressResponse.upgrade:type_name -> lczero.api.v1.UpgradeAdvisory
+	8,   // 41: lczero.api.v1.CrashReport.type:type_name -> lczero.api.v1.CrashReport.CrashType
+	85,  // 42: lczero.api.v1.CrashReport.logs:type_name -> lczero.api.v1.CrashReport.LogsEntry
+	34,  // 43: lczero.api.v1.TrainingProgress.games:type_name -> lczero.api.v1.GameData
+	3,   // 44: lczero.api.v1.MatchGame.short_outcome:type_name -> lczero.api.v1.ShortOutcome
+	4,   // 45: lczero.api.v1.MatchGame.detailed_outcome:type_name -> lczero.api.v1.DetailedOutcome
+	37,  // 46: lczero.api.v1.MatchProgress.games:type_name -> lczero.api.v1.MatchGame
+	37,  // 47: lczero.api.v1.SprtPairReport.game1:type_name -> lczero.api.v1.MatchGame
+	37,  // 48: lczero.api.v1.SprtPairReport.game2:type_name -> lczero.api.v1.MatchGame
+	39,  // 49: lczero.api.v1.SprtProgress.pairs:type_name -> lczero.api.v1.SprtPairReport
+	37,  // 50: lczero.api.v1.TuningPairResult.game1:type_name -> lczero.api.v1.MatchGame
+	37,  // 51: lczero.api.v1.TuningPairResult.game2:type_name -> lczero.api.v1.MatchGame
+	41,  // 52: lczero.api.v1.TuningParamSetResult.pairs:type_name -> lczero.api.v1.TuningPairResult
+	42,  // 53: lczero.api.v1.TuningProgress.results:type_name -> lczero.api.v1.TuningParamSetResult
+	44,  // 54: lczero.api.v1.ListTrainingRunsResponse.training_runs:type_name -> lczero.api.v1.TrainingRun
+	50,  // 55: lczero.api.v1.ListTrainingTasksResponse.training_tasks:type_name -> lczero.api.v1.TrainingTaskConfig
+	16,  // 56: lczero.api.v1.CreateSprtRequest.baseline:type_name -> lczero.api.v1.EngineConfiguration
+	16,  // 57: lczero.api.v1.CreateSprtRequest.candidate:type_name -> lczero.api.v1.EngineConfiguration
+	10,  // 58: lczero.api.v1.CreateSprtRequest.opening_book:type_name -> lczero.api.v1.ResourceSpec
+	13,  // 59: lczero.api.v1.CreateSprtRequest.time_control:type_name -> lczero.api.v1.TimeControl
+	90,  // 60: lczero.api.v1.SprtTest.created_at:type_name -> google.protobuf.Timestamp
+	56,  // 61: lczero.api.v1.ListSprtsResponse.sprts:type_name -> lczero.api.v1.SprtTest
+	6,   // 62: lczero.api.v1.BayesianConfig.acquisition:type_name -> lczero.api.v1.Acquisition
+	86,  // 63: lczero.api.v1.TuningObservation.values:type_name -> lczero.api.v1.TuningObservation.ValuesEntry
+	87,  // 64: lczero.api.v1.TuningOptimum.uci_options:type_name -> lczero.api.v1.TuningOptimum.UciOptionsEntry
+	62,  // 65: lczero.api.v1.TuningOptimum.observations:type_name -> lczero.api.v1.TuningObservation
+	88,  // 66: lczero.api.v1.SpsaIterationResult.values:type_name -> lczero.api.v1.SpsaIterationResult.ValuesEntry
+	90,  // 67: lczero.api.v1.SpsaIterationResult.completed_at:type_name -> google.protobuf.Timestamp
+	65,  // 68: lczero.api.v1.SpsaState.config:type_name -> lczero.api.v1.SpsaConfig
+	89,  // 69: lczero.api.v1.SpsaState.values:type_name -> lczero.api.v1.SpsaState.ValuesEntry
+	66,  // 70: lczero.api.v1.SpsaState.history:type_name -> lczero.api.v1.SpsaIterationResult
+	12,  // 71: lczero.api.v1.CreateTuningRequest.build:type_name -> lczero.api.v1.BuildSpec
+	10,  // 72: lczero.api.v1.CreateTuningRequest.network:type_name -> lczero.api.v1.ResourceSpec
+	10,  // 73: lczero.api.v1.CreateTuningRequest.opening_book:type_name -> lczero.api.v1.ResourceSpec
+	13,  // 74: lczero.api.v1.CreateTuningRequest.time_control:type_name -> lczero.api.v1.TimeControl
+	11,  // 75: lczero.api.v1.CreateTuningRequest.base_params:type_name -> lczero.api.v1.EngineParams
+	64,  // 76: lczero.api.v1.CreateTuningRequest.parameters:type_name -> lczero.api.v1.TuningParameter
+	5,   // 77: lczero.api.v1.CreateTuningRequest.mode:type_name -> lczero.api.v1.TuningMode
+	65,  // 78: lczero.api.v1.CreateTuningRequest.spsa:type_name -> lczero.api.v1.SpsaConfig
+	61,  // 79: lczero.api.v1.CreateTuningRequest.bayesian:type_name -> lczero.api.v1.BayesianConfig
+	11,  // 80: lczero.api.v1.TunedParamSet.params:type_name -> lczero.api.v1.EngineParams
+	5,   // 81: lczero.api.v1.TuningJob.mode:type_name -> lczero.api.v1.TuningMode
+	69,  // 82: lczero.api.v1.TuningJob.param_sets:type_name -> lczero.api.v1.TunedParamSet
+	90,  // 83: lczero.api.v1.TuningJob.created_at:type_name -> google.protobuf.Timestamp
+	67,  // 84: lczero.api.v1.TuningJob.spsa:type_name -> lczero.api.v1.SpsaState
+	61,  // 85: lczero.api.v1.TuningJob.bayesian:type_name -> lczero.api.v1.BayesianConfig
+	70,  // 86: lczero.api.v1.ListTuningsResponse.tunings:type_name -> lczero.api.v1.TuningJob
+	90,  // 87: lczero.api.v1.ClientVersionCount.last_seen:type_name -> google.protobuf.Timestamp
+	76,  // 88: lczero.api.v1.ClientVersionReport.versions:type_name -> lczero.api.v1.ClientVersionCount
+	90,  // 89: lczero.api.v1.ClientVersionReport.required_by:type_name -> google.protobuf.Timestamp
+	0,   // 90: lczero.api.v1.TaskShare.type:type_name -> lczero.api.v1.TaskType
+	79,  // 91: lczero.api.v1.ListTaskSharesResponse.shares:type_name -> lczero.api.v1.TaskShare
+	22,  // 92: lczero.api.v1.AuthService.MigrateCredentials:input_type -> lczero.api.v1.MigrateCredentialsRequest
+	23,  // 93: lczero.api.v1.AuthService.GetAnonymousToken:input_type -> lczero.api.v1.AnonymousTokenRequest
+	26,  // 94: lczero.api.v1.AuthService.RevokeToken:input_type -> lczero.api.v1.RevokeTokenRequest
+	28,  // 95: lczero.api.v1.AuthService.ListTokens:input_type -> lczero.api.v1.ListTokensRequest
+	30,  // 96: lczero.api.v1.AuthService.RotateToken:input_type -> lczero.api.v1.RotateTokenRequest
+	31,  // 97: lczero.api.v1.TaskService.GetNextTask:input_type -> lczero.api.v1.TaskRequest
+	32,  // 98: lczero.api.v1.TaskService.ReportProgress:input_type -> lczero.api.v1.ProgressReport
+	45,  // 99: lczero.api.v1.AdminService.CreateTrainingRun:input_type -> lczero.api.v1.CreateTrainingRunRequest
+	46,  // 100: lczero.api.v1.AdminService.UpdateTrainingRun:input_type -> lczero.api.v1.UpdateTrainingRunRequest
+	47,  // 101: lczero.api.v1.AdminService.SetTrainingRunActive:input_type -> lczero.api.v1.SetActiveRequest
+	48,  // 102: lczero.api.v1.AdminService.ListTrainingRuns:input_type -> lczero.api.v1.ListTrainingRunsRequest
+	51,  // 103: lczero.api.v1.AdminService.CreateTrainingTask:input_type -> lczero.api.v1.CreateTrainingTaskRequest
+	52,  // 104: lczero.api.v1.AdminService.UpdateTrainingTask:input_type -> lczero.api.v1.UpdateTrainingTaskRequest
+	47,  // 105: lczero.api.v1.AdminService.SetTrainingTaskActive:input_type -> lczero.api.v1.SetActiveRequest
+	53,  // 106: lczero.api.v1.AdminService.ListTrainingTasks:input_type -> lczero.api.v1.ListTrainingTasksRequest
+	55,  // 107: lczero.api.v1.AdminService.CreateSprt:input_type -> lczero.api.v1.CreateSprtRequest
+	57,  // 108: lczero.api.v1.AdminService.GetSprt:input_type -> lczero.api.v1.GetSprtRequest
+	58,  // 109: lczero.api.v1.AdminService.ListSprts:input_type -> lczero.api.v1.ListSprtsRequest
+	60,  // 110: lczero.api.v1.AdminService.CancelSprt:input_type -> lczero.api.v1.CancelSprtRequest
+	68,  // 111: lczero.api.v1.AdminService.CreateTuning:input_type -> lczero.api.v1.CreateTuningRequest
+	71,  // 112: lczero.api.v1.AdminService.GetTuning:input_type -> lczero.api.v1.GetTuningRequest
+	72,  // 113: lczero.api.v1.AdminService.ListTunings:input_type -> lczero.api.v1.ListTuningsRequest
+	74,  // 114: lczero.api.v1.AdminService.CancelTuning:input_type -> lczero.api.v1.CancelTuningRequest
+	71,  // 115: lczero.api.v1.AdminService.GetTuningOptimum:input_type -> lczero.api.v1.GetTuningRequest
+	75,  // 116: lczero.api.v1.AdminService.GetClientVersionReport:input_type -> lczero.api.v1.ClientVersionReportRequest
+	78,  // 117: lczero.api.v1.AdminService.SetTaskWeight:input_type -> lczero.api.v1.SetTaskWeightRequest
+	80,  // 118: lczero.api.v1.AdminService.ListTaskShares:input_type -> lczero.api.v1.ListTaskSharesRequest
+	24,  // 119: lczero.api.v1.AuthService.MigrateCredentials:output_type -> lczero.api.v1.AuthResponse
+	24,  // 120: lczero.api.v1.AuthService.GetAnonymousToken:output_type -> lczero.api.v1.AuthResponse
+	27,  // 121: lczero.api.v1.AuthService.RevokeToken:output_type -> lczero.api.v1.RevokeTokenResponse
+	29,  // 122: lczero.api.v1.AuthService.ListTokens:output_type -> lczero.api.v1.ListTokensResponse
+	24,  // 123: lczero.api.v1.AuthService.RotateToken:output_type -> lczero.api.v1.AuthResponse
+	14,  // 124: lczero.api.v1.TaskService.GetNextTask:output_type -> lczero.api.v1.TaskResponse
+	33,  // 125: lczero.api.v1.TaskService.ReportProgress:output_type -> lczero.api.v1.ProgressResponse
+	44,  // 126: lczero.api.v1.AdminService.CreateTrainingRun:output_type -> lczero.api.v1.TrainingRun
+	44,  // 127: lczero.api.v1.AdminService.UpdateTrainingRun:output_type -> lczero.api.v1.TrainingRun
+	44,  // 128: lczero.api.v1.AdminService.SetTrainingRunActive:output_type -> lczero.api.v1.TrainingRun
+	49,  // 129: lczero.api.v1.AdminService.ListTrainingRuns:output_type -> lczero.api.v1.ListTrainingRunsResponse
+	50,  // 130: lczero.api.v1.AdminService.CreateTrainingTask:output_type -> lczero.api.v1.TrainingTaskConfig
+	50,  // 131: lczero.api.v1.AdminService.UpdateTrainingTask:output_type -> lczero.api.v1.TrainingTaskConfig
+	50,  // 132: lczero.api.v1.AdminService.SetTrainingTaskActive:output_type -> lczero.api.v1.TrainingTaskConfig
+	54,  // 133: lczero.api.v1.AdminService.ListTrainingTasks:output_type -> lczero.api.v1.ListTrainingTasksResponse
+	56,  // 134: lczero.api.v1.AdminService.CreateSprt:output_type -> lczero.api.v1.SprtTest
+	56,  // 135: lczero.api.v1.AdminService.GetSprt:output_type -> lczero.api.v1.SprtTest
+	59,  // 136: lczero.api.v1.AdminService.ListSprts:output_type -> lczero.api.v1.ListSprtsResponse
+	56,  // 137: lczero.api.v1.AdminService.CancelSprt:output_type -> lczero.api.v1.SprtTest
+	70,  // 138: lczero.api.v1.AdminService.CreateTuning:output_type -> lczero.api.v1.TuningJob
+	70,  // 139: lczero.api.v1.AdminService.GetTuning:output_type -> lczero.api.v1.TuningJob
+	73,  // 140: lczero.api.v1.AdminService.ListTunings:output_type -> lczero.api.v1.ListTuningsResponse
+	70,  // 141: lczero.api.v1.AdminService.CancelTuning:output_type -> lczero.api.v1.TuningJob
+	63,  // 142: lczero.api.v1.AdminService.GetTuningOptimum:output_type -> lczero.api.v1.TuningOptimum
+	77,  // 143: lczero.api.v1.AdminService.GetClientVersionReport:output_type -> lczero.api.v1.ClientVersionReport
+	79,  // 144: lczero.api.v1.AdminService.SetTaskWeight:output_type -> lczero.api.v1.TaskShare
+	81,  // 145: lczero.api.v1.AdminService.ListTaskShares:output_type -> lczero.api.v1.ListTaskSharesResponse
+	119, // [119:146] is the sub-list for method output_type
+	92,  // [92:119] is the sub-list for method input_type
+	92,  // [92:92] is the sub-list for extension type_name
+	92,  // [92:92] is the sub-list for extension extendee
+	0,   // [0:92] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_lczero_proto_init() }
@@ -2447,23 +6344,26 @@ func file_api_v1_lczero_proto_init() {
 		(*TaskResponse_Sprt)(nil),
 		(*TaskResponse_Tuning)(nil),
 	}
-	file_api_v1_lczero_proto_msgTypes[16].OneofWrappers = []any{
+	file_api_v1_lczero_proto_msgTypes[23].OneofWrappers = []any{
 		(*ProgressReport_Training)(nil),
 		(*ProgressReport_Match)(nil),
 		(*ProgressReport_Sprt)(nil),
 		(*ProgressReport_Tuning)(nil),
 	}
-	file_api_v1_lczero_proto_msgTypes[23].OneofWrappers = []any{}
-	file_api_v1_lczero_proto_msgTypes[25].OneofWrappers = []any{}
+	file_api_v1_lczero_proto_msgTypes[30].OneofWrappers = []any{}
+	file_api_v1_lczero_proto_msgTypes[32].OneofWrappers = []any{}
+	file_api_v1_lczero_proto_msgTypes[37].OneofWrappers = []any{}
+	file_api_v1_lczero_proto_msgTypes[43].OneofWrappers = []any{}
+	file_api_v1_lczero_proto_msgTypes[55].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_lczero_proto_rawDesc), len(file_api_v1_lczero_proto_rawDesc)),
-			NumEnums:      6,
-			NumMessages:   32,
+			NumEnums:      9,
+			NumMessages:   81,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_api_v1_lczero_proto_goTypes,
 		DependencyIndexes: file_api_v1_lczero_proto_depIdxs,
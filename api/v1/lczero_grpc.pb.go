@@ -21,6 +21,9 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	AuthService_MigrateCredentials_FullMethodName = "/lczero.api.v1.AuthService/MigrateCredentials"
 	AuthService_GetAnonymousToken_FullMethodName  = "/lczero.api.v1.AuthService/GetAnonymousToken"
+	AuthService_RevokeToken_FullMethodName        = "/lczero.api.v1.AuthService/RevokeToken"
+	AuthService_ListTokens_FullMethodName         = "/lczero.api.v1.AuthService/ListTokens"
+	AuthService_RotateToken_FullMethodName        = "/lczero.api.v1.AuthService/RotateToken"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -33,6 +36,12 @@ type AuthServiceClient interface {
 	MigrateCredentials(ctx context.Context, in *MigrateCredentialsRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	// Requests a token for an anonymous user.
 	GetAnonymousToken(ctx context.Context, in *AnonymousTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// Revokes a token owned by the caller's user (or the caller's own token).
+	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevokeTokenResponse, error)
+	// Lists the tokens owned by the caller's user.
+	ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	// Issues a new token for the caller and revokes the one used to call.
+	RotateToken(ctx context.Context, in *RotateTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 }
 
 type authServiceClient struct {
@@ -63,6 +72,36 @@ func (c *authServiceClient) GetAnonymousToken(ctx context.Context, in *Anonymous
 	return out, nil
 }
 
+func (c *authServiceClient) RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevokeTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeTokenResponse)
+	err := c.cc.Invoke(ctx, AuthService_RevokeToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTokensResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RotateToken(ctx context.Context, in *RotateTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_RotateToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -73,6 +112,12 @@ type AuthServiceServer interface {
 	MigrateCredentials(context.Context, *MigrateCredentialsRequest) (*AuthResponse, error)
 	// Requests a token for an anonymous user.
 	GetAnonymousToken(context.Context, *AnonymousTokenRequest) (*AuthResponse, error)
+	// Revokes a token owned by the caller's user (or the caller's own token).
+	RevokeToken(context.Context, *RevokeTokenRequest) (*RevokeTokenResponse, error)
+	// Lists the tokens owned by the caller's user.
+	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
+	// Issues a new token for the caller and revokes the one used to call.
+	RotateToken(context.Context, *RotateTokenRequest) (*AuthResponse, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -89,6 +134,15 @@ func (UnimplementedAuthServiceServer) MigrateCredentials(context.Context, *Migra
 func (UnimplementedAuthServiceServer) GetAnonymousToken(context.Context, *AnonymousTokenRequest) (*AuthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAnonymousToken not implemented")
 }
+func (UnimplementedAuthServiceServer) RevokeToken(context.Context, *RevokeTokenRequest) (*RevokeTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeToken not implemented")
+}
+func (UnimplementedAuthServiceServer) ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTokens not implemented")
+}
+func (UnimplementedAuthServiceServer) RotateToken(context.Context, *RotateTokenRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateToken not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -146,6 +200,60 @@ func _AuthService_GetAnonymousToken_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeToken(ctx, req.(*RevokeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListTokens(ctx, req.(*ListTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RotateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RotateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RotateToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RotateToken(ctx, req.(*RotateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +269,18 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAnonymousToken",
 			Handler:    _AuthService_GetAnonymousToken_Handler,
 		},
+		{
+			MethodName: "RevokeToken",
+			Handler:    _AuthService_RevokeToken_Handler,
+		},
+		{
+			MethodName: "ListTokens",
+			Handler:    _AuthService_ListTokens_Handler,
+		},
+		{
+			MethodName: "RotateToken",
+			Handler:    _AuthService_RotateToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/lczero.proto",
@@ -313,3 +433,883 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/lczero.proto",
 }
+
+const (
+	AdminService_CreateTrainingRun_FullMethodName      = "/lczero.api.v1.AdminService/CreateTrainingRun"
+	AdminService_UpdateTrainingRun_FullMethodName      = "/lczero.api.v1.AdminService/UpdateTrainingRun"
+	AdminService_SetTrainingRunActive_FullMethodName   = "/lczero.api.v1.AdminService/SetTrainingRunActive"
+	AdminService_ListTrainingRuns_FullMethodName       = "/lczero.api.v1.AdminService/ListTrainingRuns"
+	AdminService_CreateTrainingTask_FullMethodName     = "/lczero.api.v1.AdminService/CreateTrainingTask"
+	AdminService_UpdateTrainingTask_FullMethodName     = "/lczero.api.v1.AdminService/UpdateTrainingTask"
+	AdminService_SetTrainingTaskActive_FullMethodName  = "/lczero.api.v1.AdminService/SetTrainingTaskActive"
+	AdminService_ListTrainingTasks_FullMethodName      = "/lczero.api.v1.AdminService/ListTrainingTasks"
+	AdminService_CreateSprt_FullMethodName             = "/lczero.api.v1.AdminService/CreateSprt"
+	AdminService_GetSprt_FullMethodName                = "/lczero.api.v1.AdminService/GetSprt"
+	AdminService_ListSprts_FullMethodName              = "/lczero.api.v1.AdminService/ListSprts"
+	AdminService_CancelSprt_FullMethodName             = "/lczero.api.v1.AdminService/CancelSprt"
+	AdminService_CreateTuning_FullMethodName           = "/lczero.api.v1.AdminService/CreateTuning"
+	AdminService_GetTuning_FullMethodName              = "/lczero.api.v1.AdminService/GetTuning"
+	AdminService_ListTunings_FullMethodName            = "/lczero.api.v1.AdminService/ListTunings"
+	AdminService_CancelTuning_FullMethodName           = "/lczero.api.v1.AdminService/CancelTuning"
+	AdminService_GetTuningOptimum_FullMethodName       = "/lczero.api.v1.AdminService/GetTuningOptimum"
+	AdminService_GetClientVersionReport_FullMethodName = "/lczero.api.v1.AdminService/GetClientVersionReport"
+	AdminService_SetTaskWeight_FullMethodName          = "/lczero.api.v1.AdminService/SetTaskWeight"
+	AdminService_ListTaskShares_FullMethodName         = "/lczero.api.v1.AdminService/ListTaskShares"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService manages training runs and tasks. Every call needs an
+// admin-scoped token.
+type AdminServiceClient interface {
+	// Creates an inactive training run.
+	CreateTrainingRun(ctx context.Context, in *CreateTrainingRunRequest, opts ...grpc.CallOption) (*TrainingRun, error)
+	// Changes the fields that are set in the request.
+	UpdateTrainingRun(ctx context.Context, in *UpdateTrainingRunRequest, opts ...grpc.CallOption) (*TrainingRun, error)
+	// Activates or deactivates a training run.
+	SetTrainingRunActive(ctx context.Context, in *SetActiveRequest, opts ...grpc.CallOption) (*TrainingRun, error)
+	// Lists all training runs.
+	ListTrainingRuns(ctx context.Context, in *ListTrainingRunsRequest, opts ...grpc.CallOption) (*ListTrainingRunsResponse, error)
+	// Creates an inactive training task for a training run.
+	CreateTrainingTask(ctx context.Context, in *CreateTrainingTaskRequest, opts ...grpc.CallOption) (*TrainingTaskConfig, error)
+	// Changes the fields that are set in the request.
+	UpdateTrainingTask(ctx context.Context, in *UpdateTrainingTaskRequest, opts ...grpc.CallOption) (*TrainingTaskConfig, error)
+	// Activates or deactivates a training task. Active training tasks share
+	// clients with the other active tasks by the weight set with SetTaskWeight.
+	SetTrainingTaskActive(ctx context.Context, in *SetActiveRequest, opts ...grpc.CallOption) (*TrainingTaskConfig, error)
+	// Lists the training tasks, optionally of one training run.
+	ListTrainingTasks(ctx context.Context, in *ListTrainingTasksRequest, opts ...grpc.CallOption) (*ListTrainingTasksResponse, error)
+	// Submits an SPRT test. It starts handing out games right away.
+	CreateSprt(ctx context.Context, in *CreateSprtRequest, opts ...grpc.CallOption) (*SprtTest, error)
+	// Returns an SPRT test with its current results.
+	GetSprt(ctx context.Context, in *GetSprtRequest, opts ...grpc.CallOption) (*SprtTest, error)
+	// Lists SPRT tests, newest first.
+	ListSprts(ctx context.Context, in *ListSprtsRequest, opts ...grpc.CallOption) (*ListSprtsResponse, error)
+	// Stops an SPRT test. Clients working on it are told to stop.
+	CancelSprt(ctx context.Context, in *CancelSprtRequest, opts ...grpc.CallOption) (*SprtTest, error)
+	// Submits a tuning job, generating its parameter sets from a parameter
+	// space. It starts handing out games right away.
+	CreateTuning(ctx context.Context, in *CreateTuningRequest, opts ...grpc.CallOption) (*TuningJob, error)
+	// Returns a tuning job with its parameter sets, best first.
+	GetTuning(ctx context.Context, in *GetTuningRequest, opts ...grpc.CallOption) (*TuningJob, error)
+	// Lists tuning jobs, newest first, without their parameter sets.
+	ListTunings(ctx context.Context, in *ListTuningsRequest, opts ...grpc.CallOption) (*ListTuningsResponse, error)
+	// Stops a tuning job. Clients working on it are told to stop.
+	CancelTuning(ctx context.Context, in *CancelTuningRequest, opts ...grpc.CallOption) (*TuningJob, error)
+	// Returns the best parameters of a Bayesian tuning job according to its
+	// surrogate model, and the results the model was fitted to.
+	GetTuningOptimum(ctx context.Context, in *GetTuningRequest, opts ...grpc.CallOption) (*TuningOptimum, error)
+	// Counts recently active clients per client and engine version, against
+	// the configured minimum and next versions.
+	GetClientVersionReport(ctx context.Context, in *ClientVersionReportRequest, opts ...grpc.CallOption) (*ClientVersionReport, error)
+	// Sets the share of client throughput a task gets relative to the other
+	// active tasks.
+	SetTaskWeight(ctx context.Context, in *SetTaskWeightRequest, opts ...grpc.CallOption) (*TaskShare, error)
+	// Lists the tasks taking clients with their configured and observed shares.
+	ListTaskShares(ctx context.Context, in *ListTaskSharesRequest, opts ...grpc.CallOption) (*ListTaskSharesResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) CreateTrainingRun(ctx context.Context, in *CreateTrainingRunRequest, opts ...grpc.CallOption) (*TrainingRun, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrainingRun)
+	err := c.cc.Invoke(ctx, AdminService_CreateTrainingRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateTrainingRun(ctx context.Context, in *UpdateTrainingRunRequest, opts ...grpc.CallOption) (*TrainingRun, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrainingRun)
+	err := c.cc.Invoke(ctx, AdminService_UpdateTrainingRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetTrainingRunActive(ctx context.Context, in *SetActiveRequest, opts ...grpc.CallOption) (*TrainingRun, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrainingRun)
+	err := c.cc.Invoke(ctx, AdminService_SetTrainingRunActive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListTrainingRuns(ctx context.Context, in *ListTrainingRunsRequest, opts ...grpc.CallOption) (*ListTrainingRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrainingRunsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListTrainingRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateTrainingTask(ctx context.Context, in *CreateTrainingTaskRequest, opts ...grpc.CallOption) (*TrainingTaskConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrainingTaskConfig)
+	err := c.cc.Invoke(ctx, AdminService_CreateTrainingTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateTrainingTask(ctx context.Context, in *UpdateTrainingTaskRequest, opts ...grpc.CallOption) (*TrainingTaskConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrainingTaskConfig)
+	err := c.cc.Invoke(ctx, AdminService_UpdateTrainingTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetTrainingTaskActive(ctx context.Context, in *SetActiveRequest, opts ...grpc.CallOption) (*TrainingTaskConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrainingTaskConfig)
+	err := c.cc.Invoke(ctx, AdminService_SetTrainingTaskActive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListTrainingTasks(ctx context.Context, in *ListTrainingTasksRequest, opts ...grpc.CallOption) (*ListTrainingTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrainingTasksResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListTrainingTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateSprt(ctx context.Context, in *CreateSprtRequest, opts ...grpc.CallOption) (*SprtTest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SprtTest)
+	err := c.cc.Invoke(ctx, AdminService_CreateSprt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetSprt(ctx context.Context, in *GetSprtRequest, opts ...grpc.CallOption) (*SprtTest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SprtTest)
+	err := c.cc.Invoke(ctx, AdminService_GetSprt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListSprts(ctx context.Context, in *ListSprtsRequest, opts ...grpc.CallOption) (*ListSprtsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSprtsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListSprts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CancelSprt(ctx context.Context, in *CancelSprtRequest, opts ...grpc.CallOption) (*SprtTest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SprtTest)
+	err := c.cc.Invoke(ctx, AdminService_CancelSprt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateTuning(ctx context.Context, in *CreateTuningRequest, opts ...grpc.CallOption) (*TuningJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TuningJob)
+	err := c.cc.Invoke(ctx, AdminService_CreateTuning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetTuning(ctx context.Context, in *GetTuningRequest, opts ...grpc.CallOption) (*TuningJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TuningJob)
+	err := c.cc.Invoke(ctx, AdminService_GetTuning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListTunings(ctx context.Context, in *ListTuningsRequest, opts ...grpc.CallOption) (*ListTuningsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTuningsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListTunings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CancelTuning(ctx context.Context, in *CancelTuningRequest, opts ...grpc.CallOption) (*TuningJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TuningJob)
+	err := c.cc.Invoke(ctx, AdminService_CancelTuning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetTuningOptimum(ctx context.Context, in *GetTuningRequest, opts ...grpc.CallOption) (*TuningOptimum, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TuningOptimum)
+	err := c.cc.Invoke(ctx, AdminService_GetTuningOptimum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetClientVersionReport(ctx context.Context, in *ClientVersionReportRequest, opts ...grpc.CallOption) (*ClientVersionReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClientVersionReport)
+	err := c.cc.Invoke(ctx, AdminService_GetClientVersionReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetTaskWeight(ctx context.Context, in *SetTaskWeightRequest, opts ...grpc.CallOption) (*TaskShare, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskShare)
+	err := c.cc.Invoke(ctx, AdminService_SetTaskWeight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListTaskShares(ctx context.Context, in *ListTaskSharesRequest, opts ...grpc.CallOption) (*ListTaskSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskSharesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListTaskShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService manages training runs and tasks. Every call needs an
+// admin-scoped token.
+type AdminServiceServer interface {
+	// Creates an inactive training run.
+	CreateTrainingRun(context.Context, *CreateTrainingRunRequest) (*TrainingRun, error)
+	// Changes the fields that are set in the request.
+	UpdateTrainingRun(context.Context, *UpdateTrainingRunRequest) (*TrainingRun, error)
+	// Activates or deactivates a training run.
+	SetTrainingRunActive(context.Context, *SetActiveRequest) (*TrainingRun, error)
+	// Lists all training runs.
+	ListTrainingRuns(context.Context, *ListTrainingRunsRequest) (*ListTrainingRunsResponse, error)
+	// Creates an inactive training task for a training run.
+	CreateTrainingTask(context.Context, *CreateTrainingTaskRequest) (*TrainingTaskConfig, error)
+	// Changes the fields that are set in the request.
+	UpdateTrainingTask(context.Context, *UpdateTrainingTaskRequest) (*TrainingTaskConfig, error)
+	// Activates or deactivates a training task. Active training tasks share
+	// clients with the other active tasks by the weight set with SetTaskWeight.
+	SetTrainingTaskActive(context.Context, *SetActiveRequest) (*TrainingTaskConfig, error)
+	// Lists the training tasks, optionally of one training run.
+	ListTrainingTasks(context.Context, *ListTrainingTasksRequest) (*ListTrainingTasksResponse, error)
+	// Submits an SPRT test. It starts handing out games right away.
+	CreateSprt(context.Context, *CreateSprtRequest) (*SprtTest, error)
+	// Returns an SPRT test with its current results.
+	GetSprt(context.Context, *GetSprtRequest) (*SprtTest, error)
+	// Lists SPRT tests, newest first.
+	ListSprts(context.Context, *ListSprtsRequest) (*ListSprtsResponse, error)
+	// Stops an SPRT test. Clients working on it are told to stop.
+	CancelSprt(context.Context, *CancelSprtRequest) (*SprtTest, error)
+	// Submits a tuning job, generating its parameter sets from a parameter
+	// space. It starts handing out games right away.
+	CreateTuning(context.Context, *CreateTuningRequest) (*TuningJob, error)
+	// Returns a tuning job with its parameter sets, best first.
+	GetTuning(context.Context, *GetTuningRequest) (*TuningJob, error)
+	// Lists tuning jobs, newest first, without their parameter sets.
+	ListTunings(context.Context, *ListTuningsRequest) (*ListTuningsResponse, error)
+	// Stops a tuning job. Clients working on it are told to stop.
+	CancelTuning(context.Context, *CancelTuningRequest) (*TuningJob, error)
+	// Returns the best parameters of a Bayesian tuning job according to its
+	// surrogate model, and the results the model was fitted to.
+	GetTuningOptimum(context.Context, *GetTuningRequest) (*TuningOptimum, error)
+	// Counts recently active clients per client and engine version, against
+	// the configured minimum and next versions.
+	GetClientVersionReport(context.Context, *ClientVersionReportRequest) (*ClientVersionReport, error)
+	// Sets the share of client throughput a task gets relative to the other
+	// active tasks.
+	SetTaskWeight(context.Context, *SetTaskWeightRequest) (*TaskShare, error)
+	// Lists the tasks taking clients with their configured and observed shares.
+	ListTaskShares(context.Context, *ListTaskSharesRequest) (*ListTaskSharesResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) CreateTrainingRun(context.Context, *CreateTrainingRunRequest) (*TrainingRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTrainingRun not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateTrainingRun(context.Context, *UpdateTrainingRunRequest) (*TrainingRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTrainingRun not implemented")
+}
+func (UnimplementedAdminServiceServer) SetTrainingRunActive(context.Context, *SetActiveRequest) (*TrainingRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTrainingRunActive not implemented")
+}
+func (UnimplementedAdminServiceServer) ListTrainingRuns(context.Context, *ListTrainingRunsRequest) (*ListTrainingRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrainingRuns not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateTrainingTask(context.Context, *CreateTrainingTaskRequest) (*TrainingTaskConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTrainingTask not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateTrainingTask(context.Context, *UpdateTrainingTaskRequest) (*TrainingTaskConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTrainingTask not implemented")
+}
+func (UnimplementedAdminServiceServer) SetTrainingTaskActive(context.Context, *SetActiveRequest) (*TrainingTaskConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTrainingTaskActive not implemented")
+}
+func (UnimplementedAdminServiceServer) ListTrainingTasks(context.Context, *ListTrainingTasksRequest) (*ListTrainingTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrainingTasks not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateSprt(context.Context, *CreateSprtRequest) (*SprtTest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSprt not implemented")
+}
+func (UnimplementedAdminServiceServer) GetSprt(context.Context, *GetSprtRequest) (*SprtTest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSprt not implemented")
+}
+func (UnimplementedAdminServiceServer) ListSprts(context.Context, *ListSprtsRequest) (*ListSprtsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSprts not implemented")
+}
+func (UnimplementedAdminServiceServer) CancelSprt(context.Context, *CancelSprtRequest) (*SprtTest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSprt not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateTuning(context.Context, *CreateTuningRequest) (*TuningJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTuning not implemented")
+}
+func (UnimplementedAdminServiceServer) GetTuning(context.Context, *GetTuningRequest) (*TuningJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTuning not implemented")
+}
+func (UnimplementedAdminServiceServer) ListTunings(context.Context, *ListTuningsRequest) (*ListTuningsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTunings not implemented")
+}
+func (UnimplementedAdminServiceServer) CancelTuning(context.Context, *CancelTuningRequest) (*TuningJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTuning not implemented")
+}
+func (UnimplementedAdminServiceServer) GetTuningOptimum(context.Context, *GetTuningRequest) (*TuningOptimum, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTuningOptimum not implemented")
+}
+func (UnimplementedAdminServiceServer) GetClientVersionReport(context.Context, *ClientVersionReportRequest) (*ClientVersionReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientVersionReport not implemented")
+}
+func (UnimplementedAdminServiceServer) SetTaskWeight(context.Context, *SetTaskWeightRequest) (*TaskShare, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTaskWeight not implemented")
+}
+func (UnimplementedAdminServiceServer) ListTaskShares(context.Context, *ListTaskSharesRequest) (*ListTaskSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskShares not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_CreateTrainingRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTrainingRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateTrainingRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateTrainingRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateTrainingRun(ctx, req.(*CreateTrainingRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateTrainingRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTrainingRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateTrainingRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateTrainingRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateTrainingRun(ctx, req.(*UpdateTrainingRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetTrainingRunActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetTrainingRunActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetTrainingRunActive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetTrainingRunActive(ctx, req.(*SetActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListTrainingRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrainingRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListTrainingRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListTrainingRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListTrainingRuns(ctx, req.(*ListTrainingRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateTrainingTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTrainingTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateTrainingTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateTrainingTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateTrainingTask(ctx, req.(*CreateTrainingTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateTrainingTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTrainingTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateTrainingTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateTrainingTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateTrainingTask(ctx, req.(*UpdateTrainingTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetTrainingTaskActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetTrainingTaskActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetTrainingTaskActive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetTrainingTaskActive(ctx, req.(*SetActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListTrainingTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrainingTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListTrainingTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListTrainingTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListTrainingTasks(ctx, req.(*ListTrainingTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateSprt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSprtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateSprt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateSprt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateSprt(ctx, req.(*CreateSprtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetSprt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSprtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetSprt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetSprt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetSprt(ctx, req.(*GetSprtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListSprts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSprtsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListSprts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListSprts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListSprts(ctx, req.(*ListSprtsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CancelSprt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSprtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CancelSprt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CancelSprt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CancelSprt(ctx, req.(*CancelSprtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateTuning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTuningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateTuning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateTuning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateTuning(ctx, req.(*CreateTuningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetTuning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTuningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetTuning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetTuning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetTuning(ctx, req.(*GetTuningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListTunings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTuningsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListTunings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListTunings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListTunings(ctx, req.(*ListTuningsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CancelTuning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTuningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CancelTuning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CancelTuning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CancelTuning(ctx, req.(*CancelTuningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetTuningOptimum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTuningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetTuningOptimum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetTuningOptimum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetTuningOptimum(ctx, req.(*GetTuningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetClientVersionReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientVersionReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetClientVersionReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetClientVersionReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetClientVersionReport(ctx, req.(*ClientVersionReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetTaskWeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTaskWeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetTaskWeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetTaskWeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetTaskWeight(ctx, req.(*SetTaskWeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListTaskShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListTaskShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListTaskShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListTaskShares(ctx, req.(*ListTaskSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lczero.api.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTrainingRun",
+			Handler:    _AdminService_CreateTrainingRun_Handler,
+		},
+		{
+			MethodName: "UpdateTrainingRun",
+			Handler:    _AdminService_UpdateTrainingRun_Handler,
+		},
+		{
+			MethodName: "SetTrainingRunActive",
+			Handler:    _AdminService_SetTrainingRunActive_Handler,
+		},
+		{
+			MethodName: "ListTrainingRuns",
+			Handler:    _AdminService_ListTrainingRuns_Handler,
+		},
+		{
+			MethodName: "CreateTrainingTask",
+			Handler:    _AdminService_CreateTrainingTask_Handler,
+		},
+		{
+			MethodName: "UpdateTrainingTask",
+			Handler:    _AdminService_UpdateTrainingTask_Handler,
+		},
+		{
+			MethodName: "SetTrainingTaskActive",
+			Handler:    _AdminService_SetTrainingTaskActive_Handler,
+		},
+		{
+			MethodName: "ListTrainingTasks",
+			Handler:    _AdminService_ListTrainingTasks_Handler,
+		},
+		{
+			MethodName: "CreateSprt",
+			Handler:    _AdminService_CreateSprt_Handler,
+		},
+		{
+			MethodName: "GetSprt",
+			Handler:    _AdminService_GetSprt_Handler,
+		},
+		{
+			MethodName: "ListSprts",
+			Handler:    _AdminService_ListSprts_Handler,
+		},
+		{
+			MethodName: "CancelSprt",
+			Handler:    _AdminService_CancelSprt_Handler,
+		},
+		{
+			MethodName: "CreateTuning",
+			Handler:    _AdminService_CreateTuning_Handler,
+		},
+		{
+			MethodName: "GetTuning",
+			Handler:    _AdminService_GetTuning_Handler,
+		},
+		{
+			MethodName: "ListTunings",
+			Handler:    _AdminService_ListTunings_Handler,
+		},
+		{
+			MethodName: "CancelTuning",
+			Handler:    _AdminService_CancelTuning_Handler,
+		},
+		{
+			MethodName: "GetTuningOptimum",
+			Handler:    _AdminService_GetTuningOptimum_Handler,
+		},
+		{
+			MethodName: "GetClientVersionReport",
+			Handler:    _AdminService_GetClientVersionReport_Handler,
+		},
+		{
+			MethodName: "SetTaskWeight",
+			Handler:    _AdminService_SetTaskWeight_Handler,
+		},
+		{
+			MethodName: "ListTaskShares",
+			Handler:    _AdminService_ListTaskShares_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/lczero.proto",
+}
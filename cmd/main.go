@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 
 	"github.com/leelachesszero/lczero-server/internal/config"
 	"github.com/leelachesszero/lczero-server/internal/db"
+	"github.com/leelachesszero/lczero-server/internal/storage"
 
 	"github.com/leelachesszero/lczero-server/internal/server"
 
@@ -28,11 +30,24 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// Validated tokens are cached and their usage written in batches
+	tokens := server.NewTokenCache(db.GetDB())
+	go tokens.Run(context.Background())
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			server.NewAuthRateLimiter().UnaryInterceptor,
+			server.NewTokenAuthenticator(tokens).UnaryInterceptor,
+		),
+	)
 
 	// Register services
-	pb.RegisterAuthServiceServer(s, server.NewAuthService(db.GetDB()))
-	pb.RegisterTaskServiceServer(s, server.NewTaskService(db.GetDB()))
+	pb.RegisterAuthServiceServer(s, server.NewAuthService(db.GetDB(), tokens))
+	pb.RegisterAdminServiceServer(s, server.NewAdminService(db.GetDB()))
+	pb.RegisterTaskServiceServer(s, server.NewTaskService(db.GetDB(), storage.NewLocalStore(config.Config.Storage.Path), tokens))
+
+	// Expire assignments whose clients went away
+	go server.NewReaper(db.GetDB()).Run(context.Background())
 
 	log.Printf("gRPC server listening at %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
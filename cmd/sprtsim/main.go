@@ -0,0 +1,53 @@
+// Command sprtsim estimates the pass rate and length of an SPRT by simulation.
+//
+// Example:
+//
+//	go run ./cmd/sprtsim -elo0 0 -elo1 5 -elo 2.5 -draw 0.6 -runs 500
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/leelachesszero/lczero-server/internal/sprt"
+)
+
+func main() {
+	var (
+		elo0       = flag.Float64("elo0", 0, "SPRT null hypothesis (normalized Elo)")
+		elo1       = flag.Float64("elo1", 5, "SPRT alternative hypothesis (normalized Elo)")
+		alpha      = flag.Float64("alpha", 0.05, "false positive rate")
+		beta       = flag.Float64("beta", 0.05, "false negative rate")
+		elo        = flag.Float64("elo", 0, "true logistic Elo of the candidate")
+		draw       = flag.Float64("draw", 0.6, "probability of a draw in a single game")
+		runs       = flag.Int("runs", 200, "number of simulated tests")
+		maxPairs   = flag.Int("max-pairs", 0, "stop undecided tests after this many pairs (0 = no limit)")
+		checkEvery = flag.Int("check-every", 1, "pairs between LLR evaluations")
+		seed       = flag.Uint64("seed", uint64(time.Now().UnixNano()), "random seed")
+	)
+	flag.Parse()
+
+	cfg := sprt.SimConfig{
+		Test:       sprt.Test{Elo0: *elo0, Elo1: *elo1, Alpha: *alpha, Beta: *beta},
+		Elo:        *elo,
+		DrawRatio:  *draw,
+		Runs:       *runs,
+		MaxPairs:   *maxPairs,
+		CheckEvery: *checkEvery,
+	}
+	res, err := sprt.Simulate(cfg, rand.New(rand.NewPCG(*seed, *seed)))
+	if err != nil {
+		log.Fatalf("simulation failed: %v", err)
+	}
+
+	lower, upper := sprt.Bounds(*alpha, *beta)
+	fmt.Printf("SPRT [%g, %g] alpha=%g beta=%g, LLR bounds (%.3f, %.3f)\n", *elo0, *elo1, *alpha, *beta, lower, upper)
+	fmt.Printf("True Elo %g, draw ratio %g, %d runs (seed %d)\n", *elo, *draw, res.Runs, *seed)
+	fmt.Printf("Passed:     %d (%.1f%%)\n", res.Passed, 100*res.PassRate())
+	fmt.Printf("Failed:     %d\n", res.Failed)
+	fmt.Printf("Unfinished: %d\n", res.Unfinished)
+	fmt.Printf("Games:      mean %.0f, 5%% %d, median %d, 95%% %d\n", res.MeanGames, res.P5Games, res.P50Games, res.P95Games)
+}
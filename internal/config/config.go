@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"os"
+	"time"
 )
 
 // Config is a Server config.
@@ -14,10 +15,13 @@ var Config struct {
 		Password string
 	}
 	Clients struct {
-		MinClientVersion  uint64
-		NextClientVersion uint64
-		MinEngineVersion  string
-		NextEngineVersion string
+		MinClientVersion      uint64
+		NextClientVersion     uint64
+		MinEngineVersion      string
+		NextEngineVersion     string
+		NextVersionRequiredBy time.Time // RFC 3339; when the next versions become the minimum
+		ClientDownloadURL     string
+		EngineDownloadURL     string
 	}
 	URLs struct {
 		OnNewNetwork    []string
@@ -31,6 +35,26 @@ var Config struct {
 	WebServer struct {
 		Address string
 	}
+	Storage struct {
+		Path string
+	}
+	Tasks struct {
+		HeartbeatTimeoutSeconds int
+		ReapIntervalSeconds     int
+		ScheduleWindowSeconds   int
+	}
+	Tokens struct {
+		CacheTTLSeconds      int
+		FlushIntervalSeconds int
+	}
+	RateLimit struct {
+		PerIPPerMinute       int
+		PerIPBurst           int
+		PerUsernamePerMinute int
+		PerUsernameBurst     int
+		MaxFailedMigrations  int
+		LockoutSeconds       int
+	}
 }
 
 func LoadConfig() {
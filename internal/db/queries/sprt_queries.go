@@ -0,0 +1,97 @@
+package queries
+
+import (
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+const selectSprtTask = `
+SELECT id, created_at, updated_at, task_id, baseline_network_id, COALESCE(baseline_params_args, ''), COALESCE(baseline_params_uci_options, ''),
+	candidate_network_id, COALESCE(candidate_params_args, ''), COALESCE(candidate_params_uci_options, ''), opening_book_id,
+	COALESCE(time_control_type, ''), COALESCE(base_time_seconds, 0), COALESCE(increment_seconds, 0), COALESCE(nodes_per_move, 0),
+	elo0, elo1, alpha, beta, ll, ld, dd, dw, ww, unpaired_losses, unpaired_draws, unpaired_wins, llr, COALESCE(verdict, ''),
+	(SELECT COALESCE(status, '') FROM tasks WHERE tasks.id = sprt_tasks.task_id),
+	(SELECT COALESCE(description, '') FROM tasks WHERE tasks.id = sprt_tasks.task_id)
+FROM sprt_tasks`
+
+// scanSprtTask scans a row selected with selectSprtTask.
+func scanSprtTask(row interface{ Scan(dest ...any) error }) (*models.SprtTask, error) {
+	var st models.SprtTask
+	err := row.Scan(
+		&st.ID, &st.CreatedAt, &st.UpdatedAt, &st.TaskID, &st.BaselineNetworkID, &st.BaselineParamsArgs, &st.BaselineParamsUciOptions,
+		&st.CandidateNetworkID, &st.CandidateParamsArgs, &st.CandidateParamsUciOptions, &st.OpeningBookID,
+		&st.TimeControlType, &st.BaseTimeSeconds, &st.IncrementSeconds, &st.NodesPerMove,
+		&st.Elo0, &st.Elo1, &st.Alpha, &st.Beta, &st.LL, &st.LD, &st.DD, &st.DW, &st.WW,
+		&st.UnpairedLosses, &st.UnpairedDraws, &st.UnpairedWins, &st.LLR, &st.Verdict,
+		&st.Task.Status, &st.Task.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+	st.Task.ID = st.TaskID
+	return &st, nil
+}
+
+// FetchSprtTaskByTaskIDForUpdate returns the SPRT task extending the given base
+// task, locking its row until the transaction ends.
+func FetchSprtTaskByTaskIDForUpdate(db Querier, taskID uint) (*models.SprtTask, error) {
+	return scanSprtTask(db.QueryRow(selectSprtTask+`
+WHERE task_id = $1
+FOR UPDATE`, taskID))
+}
+
+// FetchSprtTaskByTaskID returns the SPRT task extending the given base task.
+func FetchSprtTaskByTaskID(db Querier, taskID uint) (*models.SprtTask, error) {
+	return scanSprtTask(db.QueryRow(selectSprtTask+` WHERE task_id = $1`, taskID))
+}
+
+// FetchSprtTasks returns SPRT tasks, newest first. Unless includeFinished is
+// set only tasks that are still ACTIVE are returned.
+func FetchSprtTasks(db Querier, includeFinished bool) ([]models.SprtTask, error) {
+	rows, err := db.Query(selectSprtTask+`
+WHERE $1 OR task_id IN (SELECT id FROM tasks WHERE status = $2)
+ORDER BY id DESC`, includeFinished, models.TaskStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tasks []models.SprtTask
+	for rows.Next() {
+		st, err := scanSprtTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *st)
+	}
+	return tasks, rows.Err()
+}
+
+// InsertSprtTask inserts an SPRT task extending the base task st.TaskID and
+// returns its id.
+func InsertSprtTask(db Querier, st *models.SprtTask) (uint, error) {
+	var id uint
+	err := db.QueryRow(`INSERT INTO sprt_tasks (created_at, updated_at, task_id,
+	baseline_network_id, baseline_params_args, baseline_params_uci_options,
+	candidate_network_id, candidate_params_args, candidate_params_uci_options,
+	opening_book_id, time_control_type, base_time_seconds, increment_seconds, nodes_per_move,
+	elo0, elo1, alpha, beta)
+	VALUES (NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	RETURNING id, created_at`,
+		st.TaskID, st.BaselineNetworkID, st.BaselineParamsArgs, st.BaselineParamsUciOptions,
+		st.CandidateNetworkID, st.CandidateParamsArgs, st.CandidateParamsUciOptions,
+		nullableID(st.OpeningBookID), st.TimeControlType, st.BaseTimeSeconds, st.IncrementSeconds, st.NodesPerMove,
+		st.Elo0, st.Elo1, st.Alpha, st.Beta,
+	).Scan(&id, &st.CreatedAt)
+	return id, err
+}
+
+// UpdateSprtResults stores the game counts, LLR and verdict of an SPRT task.
+func UpdateSprtResults(db Querier, st *models.SprtTask) error {
+	var verdict any
+	if st.Verdict != "" {
+		verdict = st.Verdict
+	}
+	_, err := db.Exec(`UPDATE sprt_tasks 
+	SET ll = $1, ld = $2, dd = $3, dw = $4, ww = $5, unpaired_losses = $6, unpaired_draws = $7, unpaired_wins = $8, llr = $9, verdict = $10, updated_at = NOW() 
+	WHERE id = $11`, st.LL, st.LD, st.DD, st.DW, st.WW, st.UnpairedLosses, st.UnpairedDraws, st.UnpairedWins, st.LLR, verdict, st.ID)
+	return err
+}
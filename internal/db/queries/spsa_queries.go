@@ -0,0 +1,99 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+const selectSpsaIteration = `
+SELECT id, created_at, tune_task_id, iteration, delta, ck, games_played, score, completed_at, COALESCE(values_after, '')
+FROM spsa_iterations`
+
+// scanSpsaIteration scans a row selected with selectSpsaIteration.
+func scanSpsaIteration(row interface{ Scan(dest ...any) error }) (*models.SpsaIteration, error) {
+	var it models.SpsaIteration
+	err := row.Scan(
+		&it.ID, &it.CreatedAt, &it.TuneTaskID, &it.Iteration, &it.Delta, &it.CK,
+		&it.GamesPlayed, &it.Score, &it.CompletedAt, &it.ValuesAfter,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &it, nil
+}
+
+// InsertSpsaIteration inserts an SPSA iteration and returns its id.
+func InsertSpsaIteration(db Querier, it *models.SpsaIteration) (uint, error) {
+	var id uint
+	err := db.QueryRow(`INSERT INTO spsa_iterations (created_at, tune_task_id, iteration, delta, ck)
+	VALUES (NOW(), $1, $2, $3, $4)
+	RETURNING id, created_at`, it.TuneTaskID, it.Iteration, it.Delta, it.CK).Scan(&id, &it.CreatedAt)
+	return id, err
+}
+
+// FetchSpsaIterationForUpdate returns an iteration of an SPSA tuning task,
+// locking its row until the transaction ends.
+func FetchSpsaIterationForUpdate(db Querier, tuneTaskID, id uint) (*models.SpsaIteration, error) {
+	return scanSpsaIteration(db.QueryRow(selectSpsaIteration+`
+WHERE tune_task_id = $1 AND id = $2
+FOR UPDATE`, tuneTaskID, id))
+}
+
+// FetchOpenSpsaIterations returns the iterations of an SPSA tuning task that
+// have not completed yet, oldest first.
+func FetchOpenSpsaIterations(db Querier, tuneTaskID uint) ([]models.SpsaIteration, error) {
+	return fetchSpsaIterations(db, selectSpsaIteration+`
+WHERE tune_task_id = $1 AND completed_at IS NULL
+ORDER BY id ASC`, tuneTaskID)
+}
+
+// FetchCompletedSpsaIterations returns the completed iterations of an SPSA
+// tuning task in the order they completed.
+func FetchCompletedSpsaIterations(db Querier, tuneTaskID uint) ([]models.SpsaIteration, error) {
+	return fetchSpsaIterations(db, selectSpsaIteration+`
+WHERE tune_task_id = $1 AND completed_at IS NOT NULL
+ORDER BY completed_at ASC, id ASC`, tuneTaskID)
+}
+
+// fetchSpsaIterations runs a query selecting with selectSpsaIteration and scans every row.
+func fetchSpsaIterations(db Querier, query string, args ...any) ([]models.SpsaIteration, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var its []models.SpsaIteration
+	for rows.Next() {
+		it, err := scanSpsaIteration(rows)
+		if err != nil {
+			return nil, err
+		}
+		its = append(its, *it)
+	}
+	return its, rows.Err()
+}
+
+// UpdateSpsaIterationResults stores the game count and score of an SPSA
+// iteration, and its completion once CompletedAt is set.
+func UpdateSpsaIterationResults(db Querier, it *models.SpsaIteration) error {
+	var valuesAfter any
+	if it.ValuesAfter != "" {
+		valuesAfter = it.ValuesAfter
+	}
+	_, err := db.Exec(`UPDATE spsa_iterations
+	SET games_played = $1, score = $2, completed_at = $3, values_after = $4
+	WHERE id = $5`, it.GamesPlayed, it.Score, it.CompletedAt, valuesAfter, it.ID)
+	return err
+}
+
+// IncrementSpsaCompleted counts one more completed iteration of an SPSA tuning
+// task and returns the new count.
+func IncrementSpsaCompleted(db Querier, tuneTaskID uint, now time.Time) (int, error) {
+	var n int
+	err := db.QueryRow(`UPDATE tune_tasks
+	SET spsa_completed = spsa_completed + 1, updated_at = $1
+	WHERE id = $2
+	RETURNING spsa_completed`, now, tuneTaskID).Scan(&n)
+	return n, err
+}
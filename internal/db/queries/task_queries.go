@@ -5,29 +5,75 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/leelachesszero/lczero-server/internal/models"
 )
 
-// FetchActiveTrainingTask returns the first active training task.
-func FetchActiveTrainingTask(db *sql.DB) (*models.TrainingTask, error) {
-	row := db.QueryRow(`
-SELECT id, task_id, training_run_id, train_book_id, match_book_id, best_network_id, train_parameters, match_parameters
-FROM training_tasks
-WHERE active = true
-ORDER BY id ASC
-LIMIT 1`)
-	var tr models.TrainingTask
-	err := row.Scan(&tr.ID, &tr.TaskID, &tr.TrainingRunID, &tr.TrainBookID, &tr.MatchBookID, &tr.BestNetworkID, &tr.TrainParameters, &tr.MatchParameters)
-	if err != nil {
-		return nil, err
+// Querier is implemented by both *sql.DB and *sql.Tx, so helpers taking it can
+// run either standalone or inside a transaction.
+type Querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// nullableID maps a zero ID to SQL NULL.
+func nullableID(id uint) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// FetchTrainingTaskByTaskID returns the training task extending the given base task.
+func FetchTrainingTaskByTaskID(db Querier, taskID uint) (*models.TrainingTask, error) {
+	return scanTrainingTask(db.QueryRow(selectTrainingTask+` WHERE task_id = $1`, taskID))
+}
+
+// NextTrainingGameNumber bumps training_runs.last_game and returns the new value.
+func NextTrainingGameNumber(db Querier, trainingRunID uint) (uint, error) {
+	var n uint
+	err := db.QueryRow(`UPDATE training_runs 
+	SET last_game = COALESCE(last_game, 0) + 1 
+	WHERE id = $1 
+	RETURNING last_game`, trainingRunID).Scan(&n)
+	return n, err
+}
+
+// InsertTrainingGame inserts a training game and returns its ID.
+func InsertTrainingGame(db Querier, g *models.TrainingGame) (uint64, error) {
+	var id uint64
+	err := db.QueryRow(
+		`INSERT INTO training_games (created_at, user_id, client_id, training_run_id, network_id, game_number, version, compacted, engine_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`,
+		g.CreatedAt, nullableID(g.UserID), nullableID(g.ClientID), g.TrainingRunID, g.NetworkID, g.GameNumber, g.Version, g.Compacted, g.EngineVersion,
+	).Scan(&id)
+	return id, err
+}
+
+// MarkTrainingGamesUploadFailed flags training games whose blobs could not be
+// stored, so they are not mistaken for games with data.
+func MarkTrainingGamesUploadFailed(db Querier, ids []uint64) error {
+	arr := make([]int64, len(ids))
+	for i, id := range ids {
+		arr[i] = int64(id)
 	}
-	return &tr, nil
+	_, err := db.Exec(`UPDATE training_games SET upload_failed = true WHERE id = ANY($1)`, pq.Array(arr))
+	return err
+}
+
+// IncrementNetworkGamesPlayed adds n to the cached games_played counter of a network.
+func IncrementNetworkGamesPlayed(db Querier, networkID uint, n int) error {
+	_, err := db.Exec(`UPDATE networks SET games_played = COALESCE(games_played, 0) + $1 WHERE id = $2`, n, networkID)
+	return err
 }
 
 // FetchNetworkByID returns a network by its ID.
-func FetchNetworkByID(db *sql.DB, id uint) (*models.Network, error) {
+func FetchNetworkByID(db Querier, id uint) (*models.Network, error) {
 	row := db.QueryRow(`
-	SELECT id, created_at, training_run_id, network_number, sha, path, layers, filters, games_played, elo, anchor, eloset 
+	SELECT id, created_at, training_run_id, network_number, sha, path, layers, filters, games_played, elo, anchor, elo_set 
 	FROM networks
 	WHERE id = $1`, id)
 	var net models.Network
@@ -38,12 +84,19 @@ func FetchNetworkByID(db *sql.DB, id uint) (*models.Network, error) {
 	return &net, nil
 }
 
+// FetchNetworkIDBySha returns the id of the network with the given sha.
+func FetchNetworkIDBySha(db Querier, sha string) (uint, error) {
+	var id uint
+	err := db.QueryRow(`SELECT id FROM networks WHERE sha = $1 ORDER BY id ASC LIMIT 1`, sha).Scan(&id)
+	return id, err
+}
+
 // FetchPendingMatch returns the first pending match for a training run and slice.
 func FetchPendingMatch(db *sql.DB, trainingRunID uint, slice int) (*models.Match, error) {
 	row := db.QueryRow(
 		`SELECT id, created_at, training_run_id, candidate_id, current_best_id, games_created, wins, losses, draws, game_cap, done, passed, test_only, special_params, target_slice 
 		FROM matches 
-		WHERE done = false AND training_run_id = $1 AND (target_slice = 0 OR target_slice = $2) 
+		WHERE done = false AND games_created < game_cap AND training_run_id = $1 AND (target_slice = 0 OR target_slice = $2) 
 		ORDER BY id ASC LIMIT 1`,
 		trainingRunID, slice,
 	)
@@ -55,12 +108,75 @@ func FetchPendingMatch(db *sql.DB, trainingRunID uint, slice int) (*models.Match
 	return &m, nil
 }
 
+// FetchMatchForUpdate returns a match by ID, locking its row until the transaction ends.
+func FetchMatchForUpdate(db Querier, id uint) (*models.Match, error) {
+	row := db.QueryRow(
+		`SELECT id, created_at, training_run_id, candidate_id, current_best_id, games_created, wins, losses, draws, game_cap, done, passed, test_only, special_params, target_slice 
+		FROM matches 
+		WHERE id = $1 
+		FOR UPDATE`, id)
+	var m models.Match
+	err := row.Scan(&m.ID, &m.CreatedAt, &m.TrainingRunID, &m.CandidateID, &m.CurrentBestID, &m.GamesCreated, &m.Wins, &m.Losses, &m.Draws, &m.GameCap, &m.Done, &m.Passed, &m.TestOnly, &m.SpecialParams, &m.TargetSlice)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ReserveMatchGame bumps games_created for a match unless it already reached
+// game_cap. It reports whether a game slot was reserved.
+func ReserveMatchGame(db Querier, matchID uint) (bool, error) {
+	res, err := db.Exec(`UPDATE matches 
+	SET games_created = games_created + 1 
+	WHERE id = $1 AND done = false AND games_created < game_cap`, matchID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// UpdateMatchResults stores the running score and completion state of a match.
+func UpdateMatchResults(db Querier, m *models.Match) error {
+	_, err := db.Exec(`UPDATE matches 
+	SET games_created = $1, wins = $2, losses = $3, draws = $4, done = $5, passed = $6 
+	WHERE id = $7`, m.GamesCreated, m.Wins, m.Losses, m.Draws, m.Done, m.Passed, m.ID)
+	return err
+}
+
+// FetchMatchGameByID returns a match game by ID.
+func FetchMatchGameByID(db Querier, id uint64) (*models.MatchGame, error) {
+	row := db.QueryRow(`SELECT id, created_at, COALESCE(user_id, 0), match_id, COALESCE(done, false), COALESCE(flip, false) 
+	FROM match_games 
+	WHERE id = $1`, id)
+	var mg models.MatchGame
+	err := row.Scan(&mg.ID, &mg.CreatedAt, &mg.UserID, &mg.MatchID, &mg.Done, &mg.Flip)
+	if err != nil {
+		return nil, err
+	}
+	return &mg, nil
+}
+
+// UpdateMatchGameResult records the outcome of a finished match game.
+func UpdateMatchGameResult(db Querier, mg *models.MatchGame) error {
+	_, err := db.Exec(`UPDATE match_games 
+	SET pgn = $1, result = $2, flip = $3, version = $4, engine_version = $5, done = true 
+	WHERE id = $6`, mg.Pgn, mg.Result, mg.Flip, mg.Version, mg.EngineVersion, mg.ID)
+	return err
+}
+
+// UpdateTrainingTaskBestNetwork promotes a network to best network of a training task.
+func UpdateTrainingTaskBestNetwork(db Querier, trainingTaskID, networkID uint) error {
+	_, err := db.Exec(`UPDATE training_tasks SET best_network_id = $1, updated_at = NOW() WHERE id = $2`, networkID, trainingTaskID)
+	return err
+}
+
 // InsertMatchGame inserts a new match game and returns its ID.
-func InsertMatchGame(db *sql.DB, userID, matchID uint, done bool) (uint64, error) {
+func InsertMatchGame(db Querier, userID, matchID uint, done bool) (uint64, error) {
 	var id uint64
 	err := db.QueryRow(
-		`INSERT INTO match_games (user_id, match_id, done) 
-		VALUES ($1, $2, $3) 
+		`INSERT INTO match_games (created_at, user_id, match_id, done) 
+		VALUES (NOW(), $1, $2, $3) 
 		RETURNING id`,
 		userID, matchID, done,
 	).Scan(&id)
@@ -68,7 +184,7 @@ func InsertMatchGame(db *sql.DB, userID, matchID uint, done bool) (uint64, error
 }
 
 // UpdateMatchGameFlip sets the flip value for a match game.
-func UpdateMatchGameFlip(db *sql.DB, id uint64, flip bool) error {
+func UpdateMatchGameFlip(db Querier, id uint64, flip bool) error {
 	_, err := db.Exec(`UPDATE match_games 
 	SET flip = $1 
 	WHERE id = $2`, flip, id)
@@ -88,38 +204,261 @@ func FetchBookByID(db *sql.DB, id uint) (sha string, url string, size int64, err
 	return
 }
 
+// FetchBook returns a book by ID.
+func FetchBook(db Querier, id uint) (*models.Book, error) {
+	var b models.Book
+	err := db.QueryRow(`SELECT id, created_at, updated_at, sha256, COALESCE(url, ''), COALESCE(size_bytes, 0), COALESCE(format, '') 
+	FROM books 
+	WHERE id = $1`, id).Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt, &b.Sha256, &b.URL, &b.SizeBytes, &b.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// FetchBookIDBySha returns the id of the book with the given sha256.
+func FetchBookIDBySha(db Querier, sha256 string) (uint, error) {
+	var id uint
+	err := db.QueryRow(`SELECT id FROM books WHERE sha256 = $1`, sha256).Scan(&id)
+	return id, err
+}
+
 // InsertTaskAssignment inserts a new task assignment and returns its ID.
-func InsertTaskAssignment(db *sql.DB, taskID string, taskType string, assignedTokenID uint, assignedAt, lastHeartbeatAt time.Time, status string) (uint, error) {
+func InsertTaskAssignment(db Querier, t *models.TaskAssignment) (uint, error) {
 	var id uint
 	err := db.QueryRow(
-		`INSERT INTO task_assignments (task_id, task_type, assigned_token_id, assigned_at, last_heartbeat_at, status)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		`INSERT INTO task_assignments (task_id, task_type, parent_task_id, network_id, match_game_id, assigned_token_id, assigned_at, last_heartbeat_at, status, client_version, engine_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id`,
-		taskID, taskType, assignedTokenID, assignedAt, lastHeartbeatAt, status,
+		t.TaskID, t.TaskType, t.ParentTaskID, t.NetworkID, t.MatchGameID, t.AssignedTokenID, t.AssignedAt, t.LastHeartbeatAt, t.Status, t.ClientVersion, t.EngineVersion,
 	).Scan(&id)
 	return id, err
 }
 
 // FetchTaskAssignmentByTaskID returns a task assignment by task_id.
-func FetchTaskAssignmentByTaskID(db *sql.DB, taskID string) (*models.TaskAssignment, error) {
+func FetchTaskAssignmentByTaskID(db Querier, taskID string) (*models.TaskAssignment, error) {
 	row := db.QueryRow(
-		`SELECT id, created_at, updated_at, task_id, task_type, assigned_token_id, assigned_at, last_heartbeat_at, status, cancelled_at, completed_at 
+		`SELECT id, created_at, updated_at, task_id, task_type, parent_task_id, network_id, match_game_id, assigned_token_id, assigned_at, last_heartbeat_at, status, COALESCE(status_reason, ''), cancelled_at, completed_at, COALESCE(client_version, ''), COALESCE(engine_version, '') 
 		FROM task_assignments 
 		WHERE task_id = $1`, taskID)
 	var t models.TaskAssignment
-	err := row.Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt, &t.TaskID, &t.TaskType, &t.AssignedTokenID, &t.AssignedAt, &t.LastHeartbeatAt, &t.Status, &t.CancelledAt, &t.CompletedAt)
+	err := row.Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt, &t.TaskID, &t.TaskType, &t.ParentTaskID, &t.NetworkID, &t.MatchGameID, &t.AssignedTokenID, &t.AssignedAt, &t.LastHeartbeatAt, &t.Status, &t.StatusReason, &t.CancelledAt, &t.CompletedAt, &t.ClientVersion, &t.EngineVersion)
 	if err != nil {
 		return nil, err
 	}
 	return &t, nil
 }
 
+// FetchClientVersionCounts counts the tokens given tasks since a time by the
+// client and engine version of their latest assignment, most clients first.
+func FetchClientVersionCounts(db Querier, since time.Time) ([]models.ClientVersionCount, error) {
+	rows, err := db.Query(`SELECT client_version, engine_version, COUNT(*), MAX(assigned_at)
+	FROM (
+		SELECT DISTINCT ON (assigned_token_id) COALESCE(client_version, '') AS client_version, COALESCE(engine_version, '') AS engine_version, assigned_at
+		FROM task_assignments
+		WHERE assigned_token_id IS NOT NULL AND assigned_at >= $1
+		ORDER BY assigned_token_id, assigned_at DESC
+	) latest
+	GROUP BY client_version, engine_version
+	ORDER BY COUNT(*) DESC, client_version, engine_version`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var counts []models.ClientVersionCount
+	for rows.Next() {
+		var c models.ClientVersionCount
+		if err := rows.Scan(&c.ClientVersion, &c.EngineVersion, &c.Clients, &c.LastSeenAt); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// FetchWorkloads returns the tasks that can take clients, with their
+// assignments since a time and the games reported by assignments active since
+// then: active training tasks, ACTIVE SPRT tests without a verdict and ACTIVE
+// tuning tasks with work left. Assignments without results count as one game.
+func FetchWorkloads(db Querier, since time.Time) ([]models.Workload, error) {
+	rows, err := db.Query(`SELECT id, COALESCE(task_type, ''), COALESCE(description, ''), weight,
+	(SELECT COUNT(*) FROM task_assignments a WHERE a.parent_task_id = tasks.id AND a.assigned_at >= $1),
+	(SELECT COALESCE(SUM(GREATEST(a.games_reported, 1)), 0) FROM task_assignments a 
+		WHERE a.parent_task_id = tasks.id AND (a.assigned_at >= $1 OR a.last_heartbeat_at >= $1))
+	FROM tasks
+	WHERE id IN (SELECT task_id FROM training_tasks WHERE active = true)
+		OR (status = $2 AND id IN (SELECT task_id FROM sprt_tasks WHERE verdict IS NULL))
+		OR (status = $2 AND id IN (
+			SELECT task_id FROM tune_tasks
+			WHERE (mode = $3 AND spsa_completed < spsa_iterations)
+				OR EXISTS (
+					SELECT 1 FROM tune_param_sets p
+					WHERE p.tune_task_id = tune_tasks.id AND p.games_played < tune_tasks.games_per_param_set
+				)
+		))
+	ORDER BY id ASC`, since, models.TaskStatusActive, models.TuneModeSpsa)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var workloads []models.Workload
+	for rows.Next() {
+		var w models.Workload
+		if err := rows.Scan(&w.TaskID, &w.TaskType, &w.Description, &w.Weight, &w.Assigned, &w.Games); err != nil {
+			return nil, err
+		}
+		workloads = append(workloads, w)
+	}
+	return workloads, rows.Err()
+}
+
+// UpdateTaskWeight sets the weight of a base task. It reports whether the
+// task exists.
+func UpdateTaskWeight(db Querier, id uint, weight float64) (bool, error) {
+	res, err := db.Exec(`UPDATE tasks SET weight = $1, updated_at = NOW() WHERE id = $2`, weight, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// InsertTask inserts a base task and returns its id.
+func InsertTask(db Querier, taskType, status, description string) (uint, error) {
+	var id uint
+	err := db.QueryRow(`INSERT INTO tasks (created_at, updated_at, task_type, status, description)
+	VALUES (NOW(), NOW(), $1, $2, $3)
+	RETURNING id`, taskType, status, description).Scan(&id)
+	return id, err
+}
+
+// FetchTaskStatus returns the status of a base task.
+func FetchTaskStatus(db Querier, id uint) (string, error) {
+	var status string
+	err := db.QueryRow(`SELECT COALESCE(status, '') FROM tasks WHERE id = $1`, id).Scan(&status)
+	return status, err
+}
+
+// UpdateTaskStatus sets the status of a base task.
+func UpdateTaskStatus(db Querier, id uint, status string) error {
+	_, err := db.Exec(`UPDATE tasks SET status = $1, updated_at = NOW() WHERE id = $2`, status, id)
+	return err
+}
+
 // UpdateTaskAssignmentHeartbeat updates the last_heartbeat_at for a task assignment.
 func UpdateTaskAssignmentHeartbeat(db *sql.DB, id uint, now time.Time) error {
 	_, err := db.Exec(`UPDATE task_assignments SET last_heartbeat_at = $1 WHERE id = $2`, now, id)
 	return err
 }
 
+// CompleteTaskAssignment marks an ACTIVE assignment as DONE at now, so that
+// the reaper leaves it alone. It reports whether the assignment was completed.
+func CompleteTaskAssignment(db Querier, id uint, now time.Time) (bool, error) {
+	res, err := db.Exec(`UPDATE task_assignments 
+	SET status = $1, completed_at = $2, updated_at = NOW() 
+	WHERE id = $3 AND status = $4`,
+		models.TaskStatusDone, now, id, models.TaskStatusActive)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// CancelTaskAssignment marks an ACTIVE assignment as CANCELLED at now with the
+// given reason. It reports whether the assignment was cancelled.
+func CancelTaskAssignment(db Querier, id uint, reason string, now time.Time) (bool, error) {
+	res, err := db.Exec(`UPDATE task_assignments 
+	SET status = $1, status_reason = $2, cancelled_at = $3, updated_at = NOW() 
+	WHERE id = $4 AND status = $5`,
+		models.TaskStatusCancelled, reason, now, id, models.TaskStatusActive)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// AddTaskAssignmentGames counts n more games reported for an assignment.
+func AddTaskAssignmentGames(db Querier, id uint, n int) error {
+	_, err := db.Exec(`UPDATE task_assignments SET games_reported = games_reported + $1 WHERE id = $2`, n, id)
+	return err
+}
+
+// AdvanceTaskAssignmentSequence records seq as the last report counted for an
+// assignment. It reports false if a report with seq or a later one was
+// counted already.
+func AdvanceTaskAssignmentSequence(db Querier, id uint, seq uint64) (bool, error) {
+	res, err := db.Exec(`UPDATE task_assignments SET last_sequence = $1 WHERE id = $2 AND last_sequence < $1`, seq, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// RewindTaskAssignmentSequence undoes AdvanceTaskAssignmentSequence for seq,
+// so a retry of that report is counted again. It leaves assignments that have
+// counted a later report alone.
+func RewindTaskAssignmentSequence(db Querier, id uint, seq uint64) error {
+	_, err := db.Exec(`UPDATE task_assignments SET last_sequence = $1 - 1 WHERE id = $2 AND last_sequence = $1`, seq, id)
+	return err
+}
+
+// FetchHeldSprtGame returns the candidate's half points in the first game of
+// an SPRT pair an assignment reported while the second was still running, or
+// nil if no game is held.
+func FetchHeldSprtGame(db Querier, id uint) (*int, error) {
+	var held *int
+	err := db.QueryRow(`SELECT held_sprt_game FROM task_assignments WHERE id = $1`, id).Scan(&held)
+	return held, err
+}
+
+// SetHeldSprtGame stores the half points of a held SPRT game for an
+// assignment; nil clears it.
+func SetHeldSprtGame(db Querier, id uint, held *int) error {
+	_, err := db.Exec(`UPDATE task_assignments SET held_sprt_game = $1 WHERE id = $2`, held, id)
+	return err
+}
+
+// ExpireStaleTaskAssignments marks ACTIVE assignments whose last heartbeat is
+// older than cutoff as EXPIRED with the given reason. It returns the expired
+// assignments.
+func ExpireStaleTaskAssignments(db Querier, cutoff time.Time, reason string) ([]models.TaskAssignment, error) {
+	rows, err := db.Query(`UPDATE task_assignments 
+	SET status = $1, status_reason = $2, updated_at = NOW() 
+	WHERE status = $3 AND last_heartbeat_at < $4 
+	RETURNING id, task_id, task_type, match_game_id`,
+		models.TaskStatusExpired, reason, models.TaskStatusActive, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var expired []models.TaskAssignment
+	for rows.Next() {
+		t := models.TaskAssignment{Status: models.TaskStatusExpired, StatusReason: reason}
+		if err := rows.Scan(&t.ID, &t.TaskID, &t.TaskType, &t.MatchGameID); err != nil {
+			return nil, err
+		}
+		expired = append(expired, t)
+	}
+	return expired, rows.Err()
+}
+
+// ReleaseMatchGame returns the game slot of an unfinished match game to its
+// match, so that another client can play it. It reports whether a slot was released.
+func ReleaseMatchGame(db Querier, matchGameID uint64) (bool, error) {
+	res, err := db.Exec(`UPDATE matches 
+	SET games_created = games_created - 1 
+	WHERE id = (SELECT match_id FROM match_games WHERE id = $1 AND done = false) 
+		AND done = false AND games_created > 0`, matchGameID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
 // UpdateAllTokensLastUsedAt sets last_used_at to now for all tokens.
 func UpdateAllTokensLastUsedAt(db *sql.DB) error {
 	_, err := db.Exec(`UPDATE auth_tokens SET last_used_at = NOW()`)
@@ -0,0 +1,65 @@
+package queries
+
+import (
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+const selectAuthToken = `
+SELECT id, created_at, updated_at, user_id, token, last_used_at, COALESCE(issued_reason, ''),
+	COALESCE(client_version, ''), COALESCE(client_host, ''), COALESCE(gpu_type, ''), gpu_id, status, revoked_at, scope
+FROM auth_tokens`
+
+// scanAuthToken scans a row selected with selectAuthToken.
+func scanAuthToken(row interface{ Scan(dest ...any) error }) (*models.AuthToken, error) {
+	var tok models.AuthToken
+	err := row.Scan(
+		&tok.ID, &tok.CreatedAt, &tok.UpdatedAt, &tok.UserID, &tok.Token, &tok.LastUsedAt, &tok.IssuedReason,
+		&tok.ClientVersion, &tok.ClientHost, &tok.GPUType, &tok.GPUID, &tok.Status, &tok.RevokedAt, &tok.Scope,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &tok, nil
+}
+
+// FetchAuthTokenByToken returns the auth token with the given token string.
+func FetchAuthTokenByToken(db Querier, token string) (*models.AuthToken, error) {
+	return scanAuthToken(db.QueryRow(selectAuthToken+` WHERE token = $1`, token))
+}
+
+// FetchAuthTokenByIDForUpdate returns an auth token by id, locking its row
+// until the transaction ends.
+func FetchAuthTokenByIDForUpdate(db Querier, id uint) (*models.AuthToken, error) {
+	return scanAuthToken(db.QueryRow(selectAuthToken+` WHERE id = $1 FOR UPDATE`, id))
+}
+
+// FetchAuthTokensByUserID returns every token issued to a user, oldest first.
+func FetchAuthTokensByUserID(db Querier, userID uint) ([]models.AuthToken, error) {
+	rows, err := db.Query(selectAuthToken+` WHERE user_id = $1 ORDER BY id ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var toks []models.AuthToken
+	for rows.Next() {
+		tok, err := scanAuthToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		toks = append(toks, *tok)
+	}
+	return toks, rows.Err()
+}
+
+// RevokeAuthToken marks an ACTIVE token as REVOKED. It reports whether the
+// token was active.
+func RevokeAuthToken(db Querier, id uint) (bool, error) {
+	res, err := db.Exec(`UPDATE auth_tokens
+	SET status = $1, revoked_at = NOW(), updated_at = NOW()
+	WHERE id = $2 AND status = $3`, models.TokenStatusRevoked, id, models.TokenStatusActive)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
@@ -0,0 +1,148 @@
+package queries
+
+import (
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+const selectTrainingRun = `
+SELECT id, COALESCE(best_network_id, 0), COALESCE(description, ''), COALESCE(train_parameters, ''), COALESCE(match_parameters, ''),
+	COALESCE(train_book, ''), COALESCE(match_book, ''), COALESCE(active, false), COALESCE(last_network, 0), COALESCE(last_game, 0),
+	COALESCE(permission_expr, ''), COALESCE(multi_net_mode, false)
+FROM training_runs`
+
+// scanTrainingRun scans a row selected with selectTrainingRun.
+func scanTrainingRun(row interface{ Scan(dest ...any) error }) (*models.TrainingRun, error) {
+	var r models.TrainingRun
+	err := row.Scan(
+		&r.ID, &r.BestNetworkID, &r.Description, &r.TrainParameters, &r.MatchParameters,
+		&r.TrainBook, &r.MatchBook, &r.Active, &r.LastNetwork, &r.LastGame,
+		&r.PermissionExpr, &r.MultiNetMode,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// FetchTrainingRunByIDForUpdate returns a training run, locking its row until
+// the transaction ends.
+func FetchTrainingRunByIDForUpdate(db Querier, id uint) (*models.TrainingRun, error) {
+	return scanTrainingRun(db.QueryRow(selectTrainingRun+` WHERE id = $1 FOR UPDATE`, id))
+}
+
+// FetchTrainingRuns returns every training run, oldest first.
+func FetchTrainingRuns(db Querier) ([]models.TrainingRun, error) {
+	rows, err := db.Query(selectTrainingRun + ` ORDER BY id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var runs []models.TrainingRun
+	for rows.Next() {
+		r, err := scanTrainingRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *r)
+	}
+	return runs, rows.Err()
+}
+
+// InsertTrainingRun inserts a training run and returns its id.
+func InsertTrainingRun(db Querier, r *models.TrainingRun) (uint, error) {
+	var id uint
+	err := db.QueryRow(`INSERT INTO training_runs (best_network_id, description, train_parameters, match_parameters,
+	train_book, match_book, active, last_network, last_game, permission_expr, multi_net_mode)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, 0, 0, $8, $9)
+	RETURNING id`,
+		nullableID(r.BestNetworkID), r.Description, r.TrainParameters, r.MatchParameters,
+		r.TrainBook, r.MatchBook, r.Active, r.PermissionExpr, r.MultiNetMode,
+	).Scan(&id)
+	return id, err
+}
+
+// UpdateTrainingRun writes the editable fields of a training run.
+func UpdateTrainingRun(db Querier, r *models.TrainingRun) error {
+	_, err := db.Exec(`UPDATE training_runs
+	SET best_network_id = $1, description = $2, train_parameters = $3, match_parameters = $4,
+		train_book = $5, match_book = $6, active = $7, permission_expr = $8, multi_net_mode = $9
+	WHERE id = $10`,
+		nullableID(r.BestNetworkID), r.Description, r.TrainParameters, r.MatchParameters,
+		r.TrainBook, r.MatchBook, r.Active, r.PermissionExpr, r.MultiNetMode, r.ID,
+	)
+	return err
+}
+
+const selectTrainingTask = `
+SELECT id, created_at, updated_at, task_id, training_run_id, COALESCE(train_book_id, 0), COALESCE(match_book_id, 0),
+	COALESCE(best_network_id, 0), COALESCE(train_parameters, ''), COALESCE(match_parameters, ''), active,
+	(SELECT COALESCE(description, '') FROM tasks WHERE tasks.id = training_tasks.task_id)
+FROM training_tasks`
+
+// scanTrainingTask scans a row selected with selectTrainingTask.
+func scanTrainingTask(row interface{ Scan(dest ...any) error }) (*models.TrainingTask, error) {
+	var tr models.TrainingTask
+	err := row.Scan(
+		&tr.ID, &tr.CreatedAt, &tr.UpdatedAt, &tr.TaskID, &tr.TrainingRunID, &tr.TrainBookID, &tr.MatchBookID,
+		&tr.BestNetworkID, &tr.TrainParameters, &tr.MatchParameters, &tr.Active,
+		&tr.Task.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tr.Task.ID = tr.TaskID
+	return &tr, nil
+}
+
+// FetchTrainingTaskByIDForUpdate returns a training task, locking its row
+// until the transaction ends.
+func FetchTrainingTaskByIDForUpdate(db Querier, id uint) (*models.TrainingTask, error) {
+	return scanTrainingTask(db.QueryRow(selectTrainingTask+` WHERE id = $1 FOR UPDATE`, id))
+}
+
+// FetchTrainingTasks returns the training tasks of a training run, or of all
+// runs when trainingRunID is 0, oldest first.
+func FetchTrainingTasks(db Querier, trainingRunID uint) ([]models.TrainingTask, error) {
+	rows, err := db.Query(selectTrainingTask+`
+WHERE $1 = 0 OR training_run_id = $1
+ORDER BY id ASC`, trainingRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tasks []models.TrainingTask
+	for rows.Next() {
+		tr, err := scanTrainingTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *tr)
+	}
+	return tasks, rows.Err()
+}
+
+// InsertTrainingTask inserts a training task extending the base task
+// tr.TaskID and returns its id.
+func InsertTrainingTask(db Querier, tr *models.TrainingTask) (uint, error) {
+	var id uint
+	err := db.QueryRow(`INSERT INTO training_tasks (created_at, updated_at, task_id, training_run_id, train_book_id, match_book_id,
+	train_parameters, match_parameters, best_network_id, active)
+	VALUES (NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id`,
+		tr.TaskID, tr.TrainingRunID, nullableID(tr.TrainBookID), nullableID(tr.MatchBookID),
+		tr.TrainParameters, tr.MatchParameters, nullableID(tr.BestNetworkID), tr.Active,
+	).Scan(&id)
+	return id, err
+}
+
+// UpdateTrainingTask writes the editable fields of a training task.
+func UpdateTrainingTask(db Querier, tr *models.TrainingTask) error {
+	_, err := db.Exec(`UPDATE training_tasks
+	SET train_book_id = $1, match_book_id = $2, train_parameters = $3, match_parameters = $4,
+		best_network_id = $5, active = $6, updated_at = NOW()
+	WHERE id = $7`,
+		nullableID(tr.TrainBookID), nullableID(tr.MatchBookID), tr.TrainParameters, tr.MatchParameters,
+		nullableID(tr.BestNetworkID), tr.Active, tr.ID,
+	)
+	return err
+}
@@ -0,0 +1,277 @@
+package queries
+
+import (
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+const selectTuneTask = `
+SELECT id, created_at, updated_at, task_id, COALESCE(build_repo_url, ''), COALESCE(build_commit_hash, ''), COALESCE(build_params, ''),
+	tune_network_id, opening_book_id, games_per_param_set, mode, COALESCE(params_args, ''), COALESCE(params_uci_options, ''),
+	COALESCE(time_control_type, ''), COALESCE(base_time_seconds, 0), COALESCE(increment_seconds, 0), COALESCE(nodes_per_move, 0),
+	spsa_a, spsa_c, spsa_stability, spsa_alpha, spsa_gamma, spsa_iterations, spsa_completed,
+	bayes_initial_points, bayes_batch_size, bayes_max_points, bayes_acquisition, bayes_kappa,
+	(SELECT COALESCE(status, '') FROM tasks WHERE tasks.id = tune_tasks.task_id),
+	(SELECT COALESCE(description, '') FROM tasks WHERE tasks.id = tune_tasks.task_id)
+FROM tune_tasks`
+
+// scanTuneTask scans a row selected with selectTuneTask.
+func scanTuneTask(row interface{ Scan(dest ...any) error }) (*models.TuneTask, error) {
+	var tt models.TuneTask
+	err := row.Scan(
+		&tt.ID, &tt.CreatedAt, &tt.UpdatedAt, &tt.TaskID, &tt.BuildRepoURL, &tt.BuildCommitHash, &tt.BuildParams,
+		&tt.TuneNetworkID, &tt.OpeningBookID, &tt.GamesPerParamSet, &tt.Mode, &tt.ParamsArgs, &tt.ParamsUciOptions,
+		&tt.TimeControlType, &tt.BaseTimeSeconds, &tt.IncrementSeconds, &tt.NodesPerMove,
+		&tt.SpsaA, &tt.SpsaC, &tt.SpsaStability, &tt.SpsaAlpha, &tt.SpsaGamma, &tt.SpsaIterations, &tt.SpsaCompleted,
+		&tt.BayesInitialPoints, &tt.BayesBatchSize, &tt.BayesMaxPoints, &tt.BayesAcquisition, &tt.BayesKappa,
+		&tt.Task.Status, &tt.Task.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tt.Task.ID = tt.TaskID
+	return &tt, nil
+}
+
+// FetchTuneTaskByTaskID returns the tuning task extending the given base task.
+func FetchTuneTaskByTaskID(db Querier, taskID uint) (*models.TuneTask, error) {
+	return scanTuneTask(db.QueryRow(selectTuneTask+`
+WHERE task_id = $1`, taskID))
+}
+
+// FetchTuneTaskByTaskIDForUpdate returns the tuning task extending the given
+// base task, locking its row until the transaction ends.
+func FetchTuneTaskByTaskIDForUpdate(db Querier, taskID uint) (*models.TuneTask, error) {
+	return scanTuneTask(db.QueryRow(selectTuneTask+`
+WHERE task_id = $1
+FOR UPDATE`, taskID))
+}
+
+// FetchTuneTasks returns tuning tasks, newest first. Unless includeFinished is
+// set only tasks that are still ACTIVE are returned.
+func FetchTuneTasks(db Querier, includeFinished bool) ([]models.TuneTask, error) {
+	rows, err := db.Query(selectTuneTask+`
+WHERE $1 OR task_id IN (SELECT id FROM tasks WHERE status = $2)
+ORDER BY id DESC`, includeFinished, models.TaskStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tasks []models.TuneTask
+	for rows.Next() {
+		tt, err := scanTuneTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *tt)
+	}
+	return tasks, rows.Err()
+}
+
+// InsertTuneTask inserts a tuning task extending the base task tt.TaskID and
+// returns its id.
+func InsertTuneTask(db Querier, tt *models.TuneTask) (uint, error) {
+	var id uint
+	err := db.QueryRow(`INSERT INTO tune_tasks (created_at, updated_at, task_id,
+	build_repo_url, build_commit_hash, build_params, tune_network_id, opening_book_id, games_per_param_set, mode,
+	params_args, params_uci_options, time_control_type, base_time_seconds, increment_seconds, nodes_per_move,
+	spsa_a, spsa_c, spsa_stability, spsa_alpha, spsa_gamma, spsa_iterations,
+	bayes_initial_points, bayes_batch_size, bayes_max_points, bayes_acquisition, bayes_kappa)
+	VALUES (NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+		$21, $22, $23, $24, $25)
+	RETURNING id, created_at`,
+		tt.TaskID, tt.BuildRepoURL, tt.BuildCommitHash, tt.BuildParams,
+		tt.TuneNetworkID, nullableID(tt.OpeningBookID), tt.GamesPerParamSet, tt.Mode,
+		tt.ParamsArgs, tt.ParamsUciOptions, tt.TimeControlType, tt.BaseTimeSeconds, tt.IncrementSeconds, tt.NodesPerMove,
+		tt.SpsaA, tt.SpsaC, tt.SpsaStability, tt.SpsaAlpha, tt.SpsaGamma, tt.SpsaIterations,
+		tt.BayesInitialPoints, tt.BayesBatchSize, tt.BayesMaxPoints, tt.BayesAcquisition, tt.BayesKappa,
+	).Scan(&id, &tt.CreatedAt)
+	return id, err
+}
+
+// ClaimTuneParamSets hands up to limit parameter sets of a tuning task that
+// have fewer than gamesPerParamSet games and are not held by another
+// assignment to assignmentID, least played first. Sets locked by a concurrent
+// claim are skipped, so no two assignments get the same set.
+func ClaimTuneParamSets(db Querier, tuneTaskID, assignmentID uint, gamesPerParamSet int32, limit int) ([]models.TuneParamSet, error) {
+	return fetchTuneParamSets(db, `UPDATE tune_param_sets 
+SET assignment_id = $1 
+WHERE id IN (
+	SELECT id FROM tune_param_sets 
+	WHERE tune_task_id = $2 AND games_played < $3 AND assignment_id IS NULL 
+	ORDER BY games_played ASC, id ASC 
+	LIMIT $4 
+	FOR UPDATE SKIP LOCKED)
+RETURNING `+tuneParamSetColumns, assignmentID, tuneTaskID, gamesPerParamSet, limit)
+}
+
+// ClaimSpsaParamSets hands the plus and minus parameter sets of an SPSA
+// iteration to assignmentID, unless another assignment holds them. It returns
+// the claimed sets.
+func ClaimSpsaParamSets(db Querier, tuneTaskID, assignmentID uint, plusID, minusID string) ([]models.TuneParamSet, error) {
+	return fetchTuneParamSets(db, `UPDATE tune_param_sets 
+SET assignment_id = $1 
+WHERE tune_task_id = $2 AND param_set_id IN ($3, $4) AND assignment_id IS NULL 
+RETURNING `+tuneParamSetColumns, assignmentID, tuneTaskID, plusID, minusID)
+}
+
+// ReleaseTuneParamSets returns the parameter sets held by an assignment, so
+// that they can be claimed again. It returns how many sets were released.
+func ReleaseTuneParamSets(db Querier, assignmentID uint) (int, error) {
+	res, err := db.Exec(`UPDATE tune_param_sets SET assignment_id = NULL WHERE assignment_id = $1`, assignmentID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// FetchRankedTuneParamSets returns all parameter sets of a tuning task, best
+// estimated Elo first.
+func FetchRankedTuneParamSets(db Querier, tuneTaskID uint) ([]models.TuneParamSet, error) {
+	return fetchTuneParamSets(db, selectTuneParamSet+`
+WHERE tune_task_id = $1
+ORDER BY elo DESC, id ASC`, tuneTaskID)
+}
+
+// FetchTuneParamSetForUpdate returns a parameter set of a tuning task by its
+// param_set_id, locking its row until the transaction ends.
+func FetchTuneParamSetForUpdate(db Querier, tuneTaskID uint, paramSetID string) (*models.TuneParamSet, error) {
+	return scanTuneParamSet(db.QueryRow(selectTuneParamSet+`
+WHERE tune_task_id = $1 AND param_set_id = $2
+FOR UPDATE`, tuneTaskID, paramSetID))
+}
+
+// UpdateTuneParamSetResults stores the game count, pair counts and Elo estimate of a parameter set.
+func UpdateTuneParamSetResults(db Querier, ps *models.TuneParamSet) error {
+	_, err := db.Exec(`UPDATE tune_param_sets 
+	SET games_played = $1, ll = $2, ld = $3, dd = $4, dw = $5, ww = $6, elo = $7, elo_lower = $8, elo_upper = $9 
+	WHERE id = $10`, ps.GamesPlayed, ps.LL, ps.LD, ps.DD, ps.DW, ps.WW, ps.Elo, ps.EloLower, ps.EloUpper, ps.ID)
+	return err
+}
+
+// InsertTuneParamSet inserts a parameter set of a tuning task and returns its id.
+func InsertTuneParamSet(db Querier, ps *models.TuneParamSet) (uint, error) {
+	var id uint
+	err := db.QueryRow(`INSERT INTO tune_param_sets (tune_task_id, param_set_id, params_args, params_uci_options, assignment_id)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`, ps.TuneTaskID, ps.ParamSetID, ps.ParamsArgs, ps.ParamsUciOptions, ps.AssignmentID).Scan(&id)
+	return id, err
+}
+
+// CountTuneParamSets returns how many parameter sets a tuning task has.
+func CountTuneParamSets(db Querier, tuneTaskID uint) (int, error) {
+	var n int
+	err := db.QueryRow(`SELECT COUNT(1) FROM tune_param_sets WHERE tune_task_id = $1`, tuneTaskID).Scan(&n)
+	return n, err
+}
+
+// CountPendingTuneParamSets returns how many parameter sets of a tuning task
+// have fewer than gamesPerParamSet games.
+func CountPendingTuneParamSets(db Querier, tuneTaskID uint, gamesPerParamSet int32) (int, error) {
+	var n int
+	err := db.QueryRow(`SELECT COUNT(1) FROM tune_param_sets WHERE tune_task_id = $1 AND games_played < $2`, tuneTaskID, gamesPerParamSet).Scan(&n)
+	return n, err
+}
+
+// CountPendingTuneParamSetsOfAssignment returns how many parameter sets held
+// by an assignment have fewer than gamesPerParamSet games.
+func CountPendingTuneParamSetsOfAssignment(db Querier, assignmentID uint, gamesPerParamSet int32) (int, error) {
+	var n int
+	err := db.QueryRow(`SELECT COUNT(1) FROM tune_param_sets WHERE assignment_id = $1 AND games_played < $2`, assignmentID, gamesPerParamSet).Scan(&n)
+	return n, err
+}
+
+const tuneParamSetColumns = `id, tune_task_id, param_set_id, COALESCE(params_args, ''), COALESCE(params_uci_options, ''), games_played,
+	ll, ld, dd, dw, ww, elo, elo_lower, elo_upper, assignment_id`
+
+const selectTuneParamSet = `
+SELECT ` + tuneParamSetColumns + `
+FROM tune_param_sets`
+
+// scanTuneParamSet scans a row selected with tuneParamSetColumns.
+func scanTuneParamSet(row interface{ Scan(dest ...any) error }) (*models.TuneParamSet, error) {
+	var ps models.TuneParamSet
+	err := row.Scan(
+		&ps.ID, &ps.TuneTaskID, &ps.ParamSetID, &ps.ParamsArgs, &ps.ParamsUciOptions, &ps.GamesPlayed,
+		&ps.LL, &ps.LD, &ps.DD, &ps.DW, &ps.WW, &ps.Elo, &ps.EloLower, &ps.EloUpper, &ps.AssignmentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ps, nil
+}
+
+// fetchTuneParamSets runs a query returning tuneParamSetColumns and scans every row.
+func fetchTuneParamSets(db Querier, query string, args ...any) ([]models.TuneParamSet, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var sets []models.TuneParamSet
+	for rows.Next() {
+		ps, err := scanTuneParamSet(rows)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, *ps)
+	}
+	return sets, rows.Err()
+}
+
+const selectTuneParam = `
+SELECT id, tune_task_id, name, min_value, max_value, step, value
+FROM tune_params`
+
+// scanTuneParam scans a row selected with selectTuneParam.
+func scanTuneParam(row interface{ Scan(dest ...any) error }) (*models.TuneParam, error) {
+	var p models.TuneParam
+	if err := row.Scan(&p.ID, &p.TuneTaskID, &p.Name, &p.Min, &p.Max, &p.Step, &p.Value); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// fetchTuneParams runs a query selecting with selectTuneParam and scans every row.
+func fetchTuneParams(db Querier, query string, args ...any) ([]models.TuneParam, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var params []models.TuneParam
+	for rows.Next() {
+		p, err := scanTuneParam(rows)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, *p)
+	}
+	return params, rows.Err()
+}
+
+// FetchTuneParams returns the parameters of a tuning task in creation order.
+func FetchTuneParams(db Querier, tuneTaskID uint) ([]models.TuneParam, error) {
+	return fetchTuneParams(db, selectTuneParam+` WHERE tune_task_id = $1 ORDER BY id ASC`, tuneTaskID)
+}
+
+// FetchTuneParamsForUpdate returns the parameters of a tuning task in creation
+// order, locking their rows until the transaction ends.
+func FetchTuneParamsForUpdate(db Querier, tuneTaskID uint) ([]models.TuneParam, error) {
+	return fetchTuneParams(db, selectTuneParam+` WHERE tune_task_id = $1 ORDER BY id ASC FOR UPDATE`, tuneTaskID)
+}
+
+// InsertTuneParam inserts a parameter of a tuning task and returns its id.
+func InsertTuneParam(db Querier, p *models.TuneParam) (uint, error) {
+	var id uint
+	err := db.QueryRow(`INSERT INTO tune_params (tune_task_id, name, min_value, max_value, step, value)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id`, p.TuneTaskID, p.Name, p.Min, p.Max, p.Step, p.Value).Scan(&id)
+	return id, err
+}
+
+// UpdateTuneParamValue stores the current value of a parameter of an SPSA task.
+func UpdateTuneParamValue(db Querier, id uint, value float64) error {
+	_, err := db.Exec(`UPDATE tune_params SET value = $1 WHERE id = $2`, value, id)
+	return err
+}
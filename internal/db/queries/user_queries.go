@@ -8,8 +8,14 @@ FROM users
 WHERE username = $1
 `
 	InsertAuthToken = `
-INSERT INTO auth_tokens (token, issued_reason, created_at, user_id)
-VALUES ($1, $2, $3, $4)
+INSERT INTO auth_tokens (token, issued_reason, created_at, updated_at, user_id)
+VALUES ($1, $2, $3, $3, $4)
+RETURNING id
+`
+	// InsertScopedAuthToken is InsertAuthToken with an explicit scope ($5).
+	InsertScopedAuthToken = `
+INSERT INTO auth_tokens (token, issued_reason, created_at, updated_at, user_id, scope)
+VALUES ($1, $2, $3, $3, $4, $5)
 RETURNING id
 `
 )
@@ -118,6 +118,14 @@ type MatchGame struct {
 	EngineVersion string
 }
 
+// Match game results, stored from white's point of view as in the legacy server.
+// MatchGame.Flip is set when the candidate played black.
+const (
+	MatchResultBlackWin = -1
+	MatchResultDraw     = 0
+	MatchResultWhiteWin = 1
+)
+
 type TrainingGame struct {
 	ID        uint64
 	CreatedAt time.Time
@@ -140,6 +148,9 @@ type TrainingGame struct {
 	EngineVersion string
 
 	ResignFPThreshold float64
+
+	// Set when the game's blobs could not be stored; the game has no data.
+	UploadFailed bool
 }
 
 type ServerData struct {
@@ -160,6 +171,20 @@ const (
 	TokenReasonAnonymous = "anonymous"
 	TokenReasonMigrated  = "migrated_credentials"
 	TokenReasonManual    = "manual"
+	TokenReasonRotated   = "rotated"
+)
+
+// Token status; only ACTIVE tokens are accepted.
+const (
+	TokenStatusActive    = "ACTIVE"
+	TokenStatusRevoked   = "REVOKED"   // Disabled by its owner
+	TokenStatusSuspended = "SUSPENDED" // Disabled by the server operators
+)
+
+// Token scope; only ADMIN tokens may call AdminService.
+const (
+	TokenScopeClient = "CLIENT"
+	TokenScopeAdmin  = "ADMIN"
 )
 
 // Task types aligned with proto TaskType
@@ -177,6 +202,21 @@ const (
 	TaskStatusCancelled = "CANCELLED"
 	TaskStatusPending   = "PENDING"
 	TaskStatusDone      = "DONE"
+	TaskStatusExpired   = "EXPIRED" // Assignment stopped sending heartbeats
+)
+
+// How the parameter sets of a tuning task were generated
+const (
+	TuneModeGrid     = "GRID"
+	TuneModeRandom   = "RANDOM"
+	TuneModeSpsa     = "SPSA"
+	TuneModeBayesian = "BAYESIAN"
+)
+
+// Acquisition functions of Bayesian tuning tasks
+const (
+	BayesAcquisitionEI  = "EI"  // Expected improvement
+	BayesAcquisitionUCB = "UCB" // Upper confidence bound
 )
 
 // AuthToken stores bearer tokens for both migrated and anonymous users.
@@ -200,6 +240,10 @@ type AuthToken struct {
 	ClientHost    string
 	GPUType       string
 	GPUID         *int32
+
+	Status    string // one of TokenStatus*
+	RevokedAt *time.Time
+	Scope     string // one of TokenScope*
 }
 
 // TaskAssignment represents the assignment of a user (via AuthToken) to a specific task instance (TRAINING, MATCH, SPRT, TUNING, etc.).
@@ -215,14 +259,48 @@ type TaskAssignment struct {
 	// Type of task (TRAINING, MATCH, SPRT, TUNING)
 	TaskType string
 
+	// High-level task (tasks.id) this assignment works on
+	ParentTaskID *uint
+
+	// Network handed out with the assignment, if any
+	NetworkID *uint
+
+	// Match game reserved for a MATCH assignment
+	MatchGameID *uint64
+
 	// Assignment
 	AssignedToken   AuthToken
 	AssignedTokenID *uint // nullable until assigned
 	AssignedAt      *time.Time
 	LastHeartbeatAt *time.Time
-	Status          string // ACTIVE, CANCELLED, PENDING, DONE
+	Status          string // ACTIVE, CANCELLED, PENDING, DONE, EXPIRED
+	StatusReason    string
 	CancelledAt     *time.Time
 	CompletedAt     *time.Time
+
+	// Client info reported when the task was assigned
+	ClientVersion string
+	EngineVersion string
+}
+
+// Workload is an active task competing for clients, with how many
+// assignments it got and games its clients played recently.
+type Workload struct {
+	TaskID      uint
+	TaskType    string
+	Description string
+	Weight      float64
+	Assigned    int
+	Games       int // Assignments without results yet count as one game
+}
+
+// ClientVersionCount is how many clients last got a task with a client and
+// engine version.
+type ClientVersionCount struct {
+	ClientVersion string
+	EngineVersion string
+	Clients       int
+	LastSeenAt    time.Time
 }
 
 // ============================================================================
@@ -255,6 +333,9 @@ type Task struct {
 	// Optional: Human-readable description
 	Description string
 
+	// Share of client throughput relative to other active tasks
+	Weight float64
+
 	// Optional: extensibility fields should be added explicitly as needed.
 }
 
@@ -287,6 +368,9 @@ type TrainingTask struct {
 
 	TrainParameters string // Maybe add UCI options here?
 	MatchParameters string
+
+	// Active training tasks share clients by their task weight
+	Active bool
 }
 
 // MatchTask represents a match process (promotion, evaluation, etc.)
@@ -321,6 +405,12 @@ type MatchTask struct {
 	// NOTE: Missing target slice from original MatchTask
 }
 
+// Time control types used by SPRT and tuning tasks
+const (
+	TimeControlTimeBased    = "time_based"
+	TimeControlNodesPerMove = "nodes_per_move"
+)
+
 // SprtTask represents a sequential probability ratio test task
 type SprtTask struct {
 	ID        uint
@@ -353,8 +443,35 @@ type SprtTask struct {
 	BaseTimeSeconds  float64 // Only if time_based
 	IncrementSeconds float64 // Only if time_based
 	NodesPerMove     int64   // Only if nodes_per_move
+
+	// SPRT hypotheses (normalized Elo) and error rates
+	Elo0  float64
+	Elo1  float64
+	Alpha float64
+	Beta  float64
+
+	// Pentanomial pair counts from the candidate's side (DD includes WL)
+	LL int
+	LD int
+	DD int
+	DW int
+	WW int
+
+	// Games whose pair partner never finished
+	UnpairedLosses int
+	UnpairedDraws  int
+	UnpairedWins   int
+
+	LLR     float64
+	Verdict string // empty while running, else one of SprtVerdict*
 }
 
+// SPRT verdicts
+const (
+	SprtVerdictAccepted = "ACCEPTED"
+	SprtVerdictRejected = "REJECTED"
+)
+
 // TuneTask represents a tuning task (hyperparameter search, etc.)
 type TuneTask struct {
 	ID        uint
@@ -377,6 +494,11 @@ type TuneTask struct {
 	OpeningBookID uint
 
 	GamesPerParamSet int32
+	Mode             string // one of TuneMode*
+
+	// Engine parameters shared by every parameter set
+	ParamsArgs       string // JSON-encoded []string
+	ParamsUciOptions string // JSON-encoded map[string]string
 
 	// TODO: Should this be a separate table? or JSON-encoded?
 	TuneParamSets []TuneParamSet
@@ -386,6 +508,22 @@ type TuneTask struct {
 	BaseTimeSeconds  float64 // Only if time_based
 	IncrementSeconds float64 // Only if time_based
 	NodesPerMove     int64   // Only if nodes_per_move
+
+	// SPSA gains and progress, only in SPSA mode
+	SpsaA          float64
+	SpsaC          float64
+	SpsaStability  float64
+	SpsaAlpha      float64
+	SpsaGamma      float64
+	SpsaIterations int
+	SpsaCompleted  int
+
+	// Bayesian optimization settings, only in BAYESIAN mode
+	BayesInitialPoints int
+	BayesBatchSize     int
+	BayesMaxPoints     int
+	BayesAcquisition   string // one of BayesAcquisition*
+	BayesKappa         float64
 }
 
 type TuneParamSet struct {
@@ -394,6 +532,47 @@ type TuneParamSet struct {
 	ParamSetID       string // Unique ID for this parameter configuration
 	ParamsArgs       string // JSON-encoded []string or separate table if needed
 	ParamsUciOptions string // JSON-encoded map[string]string or separate table if needed
+	GamesPlayed      int
+
+	// Pentanomial pair counts from this parameter set's side
+	LL int
+	LD int
+	DD int
+	DW int
+	WW int
+
+	// Logistic Elo estimate with 95% confidence interval
+	Elo      float64
+	EloLower float64
+	EloUpper float64
+
+	// ACTIVE assignment the set was handed to, nil while it can be claimed
+	AssignmentID *uint
+}
+
+// TuneParam is one parameter of an SPSA or Bayesian tuning task.
+type TuneParam struct {
+	ID         uint
+	TuneTaskID uint
+	Name       string // UCI option name
+	Min        float64
+	Max        float64
+	Step       float64
+	Value      float64 // Current estimate of an SPSA task
+}
+
+// SpsaIteration is one perturbation pair handed out by an SPSA tuning task.
+type SpsaIteration struct {
+	ID          uint
+	CreatedAt   time.Time
+	TuneTaskID  uint
+	Iteration   int     // SpsaCompleted of the task when the pair was drawn
+	Delta       string  // JSON-encoded map[string]float64 of +1 or -1 per parameter
+	CK          float64 // Perturbation size, in units of each parameter's range
+	GamesPlayed int
+	Score       int // Wins minus losses of the plus set
+	CompletedAt *time.Time
+	ValuesAfter string // JSON-encoded map[string]float64, once completed
 }
 
 /* Should we add a table for build versions, could be automatically build during releases,
@@ -0,0 +1,160 @@
+// Package ratelimit provides in-memory, keyed rate limiting and lockouts used
+// to protect token issuance from abuse.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter decides whether an event for a key may happen at the given time.
+type Limiter interface {
+	Allow(key string, now time.Time) bool
+}
+
+// TokenBucket is a Limiter that gives every key its own bucket holding up to
+// Burst tokens and refilling at Rate tokens per second.
+type TokenBucket struct {
+	Rate  float64
+	Burst float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a TokenBucket allowing perMinute events per minute
+// per key, with bursts of up to burst events.
+func NewTokenBucket(perMinute, burst int) *TokenBucket {
+	return &TokenBucket{
+		Rate:    float64(perMinute) / 60,
+		Burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from key's bucket and reports whether one was available.
+func (tb *TokenBucket) Allow(key string, now time.Time) bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.sweep(now)
+
+	b, ok := tb.buckets[key]
+	if !ok {
+		b = &bucket{tokens: tb.Burst, last: now}
+		tb.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(tb.Burst, b.tokens+elapsed*tb.Rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep drops buckets that have refilled completely, so idle keys do not
+// accumulate. It runs at most once a minute.
+func (tb *TokenBucket) sweep(now time.Time) {
+	if now.Sub(tb.swept) < time.Minute {
+		return
+	}
+	tb.swept = now
+	for key, b := range tb.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*tb.Rate >= tb.Burst {
+			delete(tb.buckets, key)
+		}
+	}
+}
+
+// Lockout locks keys out after repeated failures.
+type Lockout interface {
+	// Locked reports whether key is locked out at now.
+	Locked(key string, now time.Time) bool
+	// Fail records a failure for key at now.
+	Fail(key string, now time.Time)
+	// Succeed records a success for key.
+	Succeed(key string)
+}
+
+// FailureLockout is a Lockout that locks a key out for Duration once it has
+// MaxFailures failures in a row. A success clears the failures of a key.
+type FailureLockout struct {
+	MaxFailures int
+	Duration    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*lockoutEntry
+	swept   time.Time
+}
+
+type lockoutEntry struct {
+	failures    int
+	lockedUntil time.Time
+	lastFailure time.Time
+}
+
+// NewFailureLockout creates a FailureLockout for maxFailures failures and the
+// given duration.
+func NewFailureLockout(maxFailures int, duration time.Duration) *FailureLockout {
+	return &FailureLockout{
+		MaxFailures: maxFailures,
+		Duration:    duration,
+		entries:     make(map[string]*lockoutEntry),
+	}
+}
+
+// Locked reports whether key is locked out at now.
+func (l *FailureLockout) Locked(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	return ok && now.Before(e.lockedUntil)
+}
+
+// Fail records a failure for key, locking it once MaxFailures is reached.
+// Failures older than Duration are forgotten.
+func (l *FailureLockout) Fail(key string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+	e, ok := l.entries[key]
+	if !ok || now.Sub(e.lastFailure) > l.Duration {
+		e = &lockoutEntry{}
+		l.entries[key] = e
+	}
+	e.failures++
+	e.lastFailure = now
+	if e.failures >= l.MaxFailures {
+		e.failures = 0
+		e.lockedUntil = now.Add(l.Duration)
+	}
+}
+
+// Succeed clears the failures recorded for key.
+func (l *FailureLockout) Succeed(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.entries, key)
+}
+
+// sweep drops keys that are neither locked nor have recent failures. It runs
+// at most once a minute.
+func (l *FailureLockout) sweep(now time.Time) {
+	if now.Sub(l.swept) < time.Minute {
+		return
+	}
+	l.swept = now
+	for key, e := range l.entries {
+		if !now.Before(e.lockedUntil) && now.Sub(e.lastFailure) > l.Duration {
+			delete(l.entries, key)
+		}
+	}
+}
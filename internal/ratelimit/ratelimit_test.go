@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	tb := NewTokenBucket(60, 3) // One token per second, bursts of three
+	start := time.Unix(1_700_000_000, 0)
+
+	for i := 0; i < 3; i++ {
+		if !tb.Allow("a", start) {
+			t.Fatalf("Allow #%d within burst = false, want true", i+1)
+		}
+	}
+	if tb.Allow("a", start) {
+		t.Fatal("Allow past burst = true, want false")
+	}
+	if !tb.Allow("b", start) {
+		t.Fatal("Allow for another key = false, want true")
+	}
+	if tb.Allow("a", start.Add(500*time.Millisecond)) {
+		t.Fatal("Allow after half a token refilled = true, want false")
+	}
+	if !tb.Allow("a", start.Add(time.Second)) {
+		t.Fatal("Allow after a token refilled = false, want true")
+	}
+	// A long pause refills no more than the burst
+	later := start.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		if !tb.Allow("a", later) {
+			t.Fatalf("Allow #%d after refill = false, want true", i+1)
+		}
+	}
+	if tb.Allow("a", later) {
+		t.Fatal("Allow past refilled burst = true, want false")
+	}
+}
+
+func TestTokenBucketSweep(t *testing.T) {
+	tb := NewTokenBucket(60, 1)
+	start := time.Unix(1_700_000_000, 0)
+	tb.Allow("idle", start)
+	tb.Allow("busy", start.Add(2*time.Minute))
+	if _, ok := tb.buckets["idle"]; ok {
+		t.Error("refilled bucket was not swept")
+	}
+	if _, ok := tb.buckets["busy"]; !ok {
+		t.Error("bucket in use was swept")
+	}
+}
+
+func TestFailureLockout(t *testing.T) {
+	l := NewFailureLockout(3, time.Minute)
+	start := time.Unix(1_700_000_000, 0)
+
+	l.Fail("alice", start)
+	l.Fail("alice", start)
+	if l.Locked("alice", start) {
+		t.Fatal("Locked after 2 of 3 failures = true, want false")
+	}
+	l.Fail("alice", start)
+	if !l.Locked("alice", start) {
+		t.Fatal("Locked after 3 failures = false, want true")
+	}
+	if l.Locked("bob", start) {
+		t.Fatal("Locked for another key = true, want false")
+	}
+	if l.Locked("alice", start.Add(time.Minute)) {
+		t.Fatal("Locked after the lockout ran out = true, want false")
+	}
+
+	// Successes and old failures do not count towards a lockout
+	l.Fail("carol", start)
+	l.Fail("carol", start)
+	l.Succeed("carol")
+	l.Fail("carol", start)
+	l.Fail("carol", start.Add(2*time.Minute))
+	l.Fail("carol", start.Add(2*time.Minute))
+	if l.Locked("carol", start.Add(2*time.Minute)) {
+		t.Fatal("Locked with failures cleared or expired = true, want false")
+	}
+}
@@ -0,0 +1,58 @@
+// Package schedule shares clients among active tasks by weight.
+package schedule
+
+import "sort"
+
+// Share is a task competing for clients.
+type Share struct {
+	ID       uint
+	Weight   float64 // Relative share of throughput; tasks without weight get no clients
+	Assigned int     // Work done or handed out in the current window, e.g. games
+}
+
+// pass is the virtual time at which a share would finish its next unit of
+// work: the further it is behind its weight, the earlier.
+func (s Share) pass() float64 {
+	return float64(s.Assigned+1) / s.Weight
+}
+
+// Order returns the indices of the shares with a weight, the one to hand the
+// next client to first. Always handing the client to the first share makes
+// every share's Assigned converge to its fraction of the total weight, like
+// stride scheduling: the share that is furthest behind goes first, ties go to
+// the lowest ID. Later entries are fallbacks for when a share turns out to
+// have no work for the client.
+func Order(shares []Share) []int {
+	order := make([]int, 0, len(shares))
+	for i, s := range shares {
+		if s.Weight > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		sa, sb := shares[order[a]], shares[order[b]]
+		if pa, pb := sa.pass(), sb.pass(); pa != pb {
+			return pa < pb
+		}
+		return sa.ID < sb.ID
+	})
+	return order
+}
+
+// Fractions returns each share's fraction of the total weight, 0 for shares
+// without weight.
+func Fractions(shares []Share) []float64 {
+	var total float64
+	for _, s := range shares {
+		if s.Weight > 0 {
+			total += s.Weight
+		}
+	}
+	fractions := make([]float64, len(shares))
+	for i, s := range shares {
+		if s.Weight > 0 {
+			fractions[i] = s.Weight / total
+		}
+	}
+	return fractions
+}
@@ -0,0 +1,68 @@
+package schedule
+
+import "testing"
+
+// allocate hands n clients out one at a time to the first share in Order.
+func allocate(shares []Share, n int) []Share {
+	shares = append([]Share(nil), shares...)
+	for i := 0; i < n; i++ {
+		order := Order(shares)
+		if len(order) == 0 {
+			break
+		}
+		shares[order[0]].Assigned++
+	}
+	return shares
+}
+
+func TestOrderConvergesToWeights(t *testing.T) {
+	got := allocate([]Share{{ID: 1, Weight: 70}, {ID: 2, Weight: 20}, {ID: 3, Weight: 10}}, 1000)
+	for i, want := range []int{700, 200, 100} {
+		if got[i].Assigned != want {
+			t.Errorf("share %d assigned %d of 1000, want %d", got[i].ID, got[i].Assigned, want)
+		}
+	}
+}
+
+func TestOrderStaysCloseToWeights(t *testing.T) {
+	shares := []Share{{ID: 1, Weight: 3}, {ID: 2, Weight: 1}}
+	for n := 1; n <= 100; n++ {
+		shares = allocate(shares, 1)
+		// Never more than one unit ahead of the exact share
+		if exact := float64(n) * 3 / 4; float64(shares[0].Assigned) > exact+1 || float64(shares[0].Assigned) < exact-1 {
+			t.Fatalf("after %d clients share 1 has %d, want about %.2f", n, shares[0].Assigned, exact)
+		}
+	}
+}
+
+func TestOrderCatchesUp(t *testing.T) {
+	// A task that just started gets clients until it has caught up
+	got := allocate([]Share{{ID: 1, Weight: 1, Assigned: 100}, {ID: 2, Weight: 1}}, 120)
+	if got[0].Assigned != 110 || got[1].Assigned != 110 {
+		t.Errorf("assigned %d and %d, want 110 each", got[0].Assigned, got[1].Assigned)
+	}
+}
+
+func TestOrderDeterministic(t *testing.T) {
+	shares := []Share{{ID: 5, Weight: 1}, {ID: 2, Weight: 1}, {ID: 9, Weight: 0}, {ID: 3, Weight: 2, Assigned: 1}}
+	order := Order(shares)
+	// Passes: ID 5 and 2 at 1, ID 3 at 1; ties by ID. ID 9 has no weight.
+	want := []int{1, 3, 0}
+	if len(order) != len(want) {
+		t.Fatalf("Order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestFractions(t *testing.T) {
+	got := Fractions([]Share{{Weight: 7}, {Weight: 2}, {Weight: 1}, {Weight: 0}})
+	for i, want := range []float64{0.7, 0.2, 0.1, 0} {
+		if d := got[i] - want; d > 1e-12 || d < -1e-12 {
+			t.Errorf("Fractions()[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+}
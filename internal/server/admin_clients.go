@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+// defaultReportDays is how far back a client version report looks by default.
+const defaultReportDays = 7
+
+// clientVersionReport tallies version counts against the version policy in
+// force.
+func clientVersionReport(p VersionPolicy, counts []models.ClientVersionCount) *pb.ClientVersionReport {
+	report := &pb.ClientVersionReport{
+		MinClientVersion:  clientVersionString(p.MinClient),
+		NextClientVersion: clientVersionString(p.NextClient),
+		MinEngineVersion:  p.MinEngine,
+		NextEngineVersion: p.NextEngine,
+	}
+	if !p.RequiredBy.IsZero() {
+		report.RequiredBy = timestamppb.New(p.RequiredBy)
+	}
+	for _, c := range counts {
+		atNext := p.atNext(c.ClientVersion, c.EngineVersion)
+		report.Versions = append(report.Versions, &pb.ClientVersionCount{
+			ClientVersion: c.ClientVersion,
+			EngineVersion: c.EngineVersion,
+			Clients:       int64(c.Clients),
+			LastSeen:      timestamppb.New(c.LastSeenAt),
+			BelowMin:      !p.atMin(c.ClientVersion, c.EngineVersion),
+			BelowNext:     !atNext,
+		})
+		report.TotalClients += int64(c.Clients)
+		if atNext {
+			report.ClientsAtNext += int64(c.Clients)
+		}
+	}
+	return report
+}
+
+// GetClientVersionReport counts the clients given tasks recently by version,
+// so operators can see when raising the minimum versions would cut few off.
+func (s *AdminServiceImpl) GetClientVersionReport(ctx context.Context, req *pb.ClientVersionReportRequest) (*pb.ClientVersionReport, error) {
+	if req.GetDays() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Days must not be negative")
+	}
+	days := orDefault(int(req.GetDays()), defaultReportDays)
+	counts, err := queries.FetchClientVersionCounts(s.DB, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to count client versions")
+	}
+	return clientVersionReport(s.Versions.at(time.Now()), counts), nil
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/schedule"
+)
+
+// taskShares converts workloads to proto messages with their target and
+// observed shares.
+func taskShares(workloads []models.Workload) []*pb.TaskShare {
+	shares := make([]schedule.Share, len(workloads))
+	var games int
+	for i, w := range workloads {
+		shares[i] = schedule.Share{ID: w.TaskID, Weight: w.Weight, Assigned: w.Games}
+		games += w.Games
+	}
+	fractions := schedule.Fractions(shares)
+	out := make([]*pb.TaskShare, len(workloads))
+	for i, w := range workloads {
+		out[i] = &pb.TaskShare{
+			Id:          uint64(w.TaskID),
+			Type:        pb.TaskType(pb.TaskType_value[w.TaskType]),
+			Description: w.Description,
+			Weight:      w.Weight,
+			TargetShare: fractions[i],
+			Assignments: int64(w.Assigned),
+			Games:       int64(w.Games),
+		}
+		if games > 0 {
+			out[i].ObservedShare = float64(w.Games) / float64(games)
+		}
+	}
+	return out
+}
+
+// SetTaskWeight sets the weight of a task. Tasks that cannot take clients,
+// e.g. inactive training tasks, keep it for when they can.
+func (s *AdminServiceImpl) SetTaskWeight(ctx context.Context, req *pb.SetTaskWeightRequest) (*pb.TaskShare, error) {
+	if !(req.GetWeight() >= 0) || math.IsInf(req.GetWeight(), 0) {
+		return nil, status.Error(codes.InvalidArgument, "Weight must be finite and not negative")
+	}
+	found, err := queries.UpdateTaskWeight(s.DB, uint(req.GetId()), req.GetWeight())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to update task weight")
+	}
+	if !found {
+		return nil, status.Error(codes.NotFound, "Task not found")
+	}
+	workloads, err := queries.FetchWorkloads(s.DB, time.Now().Add(-s.ScheduleWindow))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load active tasks")
+	}
+	for i, share := range taskShares(workloads) {
+		if workloads[i].TaskID == uint(req.GetId()) {
+			return share, nil
+		}
+	}
+	return &pb.TaskShare{Id: req.GetId(), Weight: req.GetWeight()}, nil
+}
+
+// ListTaskShares lists the tasks taking clients with their configured and
+// observed shares over the schedule window.
+func (s *AdminServiceImpl) ListTaskShares(ctx context.Context, req *pb.ListTaskSharesRequest) (*pb.ListTaskSharesResponse, error) {
+	workloads, err := queries.FetchWorkloads(s.DB, time.Now().Add(-s.ScheduleWindow))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load active tasks")
+	}
+	return &pb.ListTaskSharesResponse{
+		Shares:        taskShares(workloads),
+		WindowSeconds: int64(s.ScheduleWindow / time.Second),
+	}, nil
+}
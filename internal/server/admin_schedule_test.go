@@ -0,0 +1,25 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+func TestTaskSharesCountGames(t *testing.T) {
+	// One long tuning assignment playing many games against many short
+	// training assignments: the shares follow the games.
+	shares := taskShares([]models.Workload{
+		{TaskID: 1, TaskType: models.TaskTypeTraining, Weight: 1, Assigned: 30, Games: 30},
+		{TaskID: 2, TaskType: models.TaskTypeTuning, Weight: 1, Assigned: 1, Games: 90},
+	})
+	if got := shares[1].GetObservedShare(); got != 0.75 {
+		t.Errorf("tuning observed share = %v, want 0.75", got)
+	}
+	if got := shares[1].GetGames(); got != 90 {
+		t.Errorf("tuning games = %d, want 90", got)
+	}
+	if got := shares[1].GetAssignments(); got != 1 {
+		t.Errorf("tuning assignments = %d, want 1", got)
+	}
+}
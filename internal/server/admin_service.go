@@ -0,0 +1,290 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+// AdminServiceImpl manages training runs and tasks. The auth interceptor only
+// lets admin-scoped tokens through to it.
+type AdminServiceImpl struct {
+	pb.UnimplementedAdminServiceServer
+	DB *sql.DB
+
+	// Versions is what client version reports are measured against.
+	Versions VersionPolicy
+
+	// ScheduleWindow is how far back task shares count the games of assignments.
+	ScheduleWindow time.Duration
+}
+
+// NewAdminService creates a new AdminServiceImpl, with the versions of
+// config.Config.Clients and the schedule window of config.Config.Tasks.
+func NewAdminService(dbConn *sql.DB) *AdminServiceImpl {
+	return &AdminServiceImpl{DB: dbConn, Versions: NewVersionPolicy(), ScheduleWindow: scheduleWindow()}
+}
+
+// adminDBError converts a database error to a gRPC status. Rows referencing a
+// missing network, book or run are the caller's mistake.
+func adminDBError(err error, notFound, msg string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, notFound)
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+		return status.Error(codes.InvalidArgument, "Referenced network, book or training run does not exist")
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+// trainingRunToProto converts a training run to its proto message.
+func trainingRunToProto(r *models.TrainingRun) *pb.TrainingRun {
+	return &pb.TrainingRun{
+		Id:              uint64(r.ID),
+		Description:     r.Description,
+		TrainParameters: r.TrainParameters,
+		MatchParameters: r.MatchParameters,
+		TrainBook:       r.TrainBook,
+		MatchBook:       r.MatchBook,
+		Active:          r.Active,
+		BestNetworkId:   uint64(r.BestNetworkID),
+		LastNetwork:     uint64(r.LastNetwork),
+		LastGame:        uint64(r.LastGame),
+		PermissionExpr:  r.PermissionExpr,
+		MultiNetMode:    r.MultiNetMode,
+	}
+}
+
+// trainingTaskToProto converts a training task to its proto message.
+func trainingTaskToProto(tr *models.TrainingTask) *pb.TrainingTaskConfig {
+	cfg := &pb.TrainingTaskConfig{
+		Id:              uint64(tr.ID),
+		TaskId:          uint64(tr.TaskID),
+		TrainBookId:     uint64(tr.TrainBookID),
+		MatchBookId:     uint64(tr.MatchBookID),
+		TrainParameters: tr.TrainParameters,
+		MatchParameters: tr.MatchParameters,
+		BestNetworkId:   uint64(tr.BestNetworkID),
+		Active:          tr.Active,
+		Description:     tr.Task.Description,
+	}
+	if tr.TrainingRunID != nil {
+		cfg.TrainingRunId = uint64(*tr.TrainingRunID)
+	}
+	return cfg
+}
+
+// CreateTrainingRun creates an inactive training run.
+func (s *AdminServiceImpl) CreateTrainingRun(ctx context.Context, req *pb.CreateTrainingRunRequest) (*pb.TrainingRun, error) {
+	r := &models.TrainingRun{
+		BestNetworkID:   uint(req.GetBestNetworkId()),
+		Description:     req.GetDescription(),
+		TrainParameters: req.GetTrainParameters(),
+		MatchParameters: req.GetMatchParameters(),
+		TrainBook:       req.GetTrainBook(),
+		MatchBook:       req.GetMatchBook(),
+		PermissionExpr:  req.GetPermissionExpr(),
+		MultiNetMode:    req.GetMultiNetMode(),
+	}
+	id, err := queries.InsertTrainingRun(s.DB, r)
+	if err != nil {
+		return nil, adminDBError(err, "", "Failed to insert training run")
+	}
+	r.ID = id
+	return trainingRunToProto(r), nil
+}
+
+// updateTrainingRun applies change to a training run inside a transaction.
+func (s *AdminServiceImpl) updateTrainingRun(ctx context.Context, id uint64, change func(*models.TrainingRun)) (*pb.TrainingRun, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	r, err := queries.FetchTrainingRunByIDForUpdate(tx, uint(id))
+	if err != nil {
+		return nil, adminDBError(err, "Training run not found", "Failed to load training run")
+	}
+	change(r)
+	if err := queries.UpdateTrainingRun(tx, r); err != nil {
+		return nil, adminDBError(err, "", "Failed to update training run")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	return trainingRunToProto(r), nil
+}
+
+// UpdateTrainingRun changes the fields set in the request.
+func (s *AdminServiceImpl) UpdateTrainingRun(ctx context.Context, req *pb.UpdateTrainingRunRequest) (*pb.TrainingRun, error) {
+	return s.updateTrainingRun(ctx, req.GetId(), func(r *models.TrainingRun) {
+		if req.Description != nil {
+			r.Description = req.GetDescription()
+		}
+		if req.TrainParameters != nil {
+			r.TrainParameters = req.GetTrainParameters()
+		}
+		if req.MatchParameters != nil {
+			r.MatchParameters = req.GetMatchParameters()
+		}
+		if req.TrainBook != nil {
+			r.TrainBook = req.GetTrainBook()
+		}
+		if req.MatchBook != nil {
+			r.MatchBook = req.GetMatchBook()
+		}
+		if req.BestNetworkId != nil {
+			r.BestNetworkID = uint(req.GetBestNetworkId())
+		}
+		if req.PermissionExpr != nil {
+			r.PermissionExpr = req.GetPermissionExpr()
+		}
+		if req.MultiNetMode != nil {
+			r.MultiNetMode = req.GetMultiNetMode()
+		}
+	})
+}
+
+// SetTrainingRunActive activates or deactivates a training run.
+func (s *AdminServiceImpl) SetTrainingRunActive(ctx context.Context, req *pb.SetActiveRequest) (*pb.TrainingRun, error) {
+	return s.updateTrainingRun(ctx, req.GetId(), func(r *models.TrainingRun) {
+		r.Active = req.GetActive()
+	})
+}
+
+// ListTrainingRuns lists all training runs.
+func (s *AdminServiceImpl) ListTrainingRuns(ctx context.Context, req *pb.ListTrainingRunsRequest) (*pb.ListTrainingRunsResponse, error) {
+	runs, err := queries.FetchTrainingRuns(s.DB)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load training runs")
+	}
+	resp := &pb.ListTrainingRunsResponse{}
+	for i := range runs {
+		resp.TrainingRuns = append(resp.TrainingRuns, trainingRunToProto(&runs[i]))
+	}
+	return resp, nil
+}
+
+// CreateTrainingTask creates an inactive training task, with a PENDING base
+// task, for a training run.
+func (s *AdminServiceImpl) CreateTrainingTask(ctx context.Context, req *pb.CreateTrainingTaskRequest) (*pb.TrainingTaskConfig, error) {
+	if req.GetTrainingRunId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No training run supplied")
+	}
+	runID := uint(req.GetTrainingRunId())
+	tr := &models.TrainingTask{
+		TrainingRunID:   &runID,
+		TrainBookID:     uint(req.GetTrainBookId()),
+		MatchBookID:     uint(req.GetMatchBookId()),
+		TrainParameters: req.GetTrainParameters(),
+		MatchParameters: req.GetMatchParameters(),
+		BestNetworkID:   uint(req.GetBestNetworkId()),
+	}
+	tr.Task.Description = req.GetDescription()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	tr.TaskID, err = queries.InsertTask(tx, models.TaskTypeTraining, models.TaskStatusPending, tr.Task.Description)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to insert task")
+	}
+	tr.ID, err = queries.InsertTrainingTask(tx, tr)
+	if err != nil {
+		return nil, adminDBError(err, "", "Failed to insert training task")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	return trainingTaskToProto(tr), nil
+}
+
+// updateTrainingTask applies change to a training task inside a transaction.
+// The base task follows the active flag: ACTIVE while active, PENDING otherwise.
+func (s *AdminServiceImpl) updateTrainingTask(ctx context.Context, id uint64, change func(*models.TrainingTask)) (*pb.TrainingTaskConfig, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	tr, err := queries.FetchTrainingTaskByIDForUpdate(tx, uint(id))
+	if err != nil {
+		return nil, adminDBError(err, "Training task not found", "Failed to load training task")
+	}
+	wasActive := tr.Active
+	change(tr)
+	if err := queries.UpdateTrainingTask(tx, tr); err != nil {
+		return nil, adminDBError(err, "", "Failed to update training task")
+	}
+	if tr.Active != wasActive {
+		taskStatus := models.TaskStatusPending
+		if tr.Active {
+			taskStatus = models.TaskStatusActive
+		}
+		if err := queries.UpdateTaskStatus(tx, tr.TaskID, taskStatus); err != nil {
+			return nil, status.Error(codes.Internal, "Failed to update task status")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	return trainingTaskToProto(tr), nil
+}
+
+// UpdateTrainingTask changes the fields set in the request.
+func (s *AdminServiceImpl) UpdateTrainingTask(ctx context.Context, req *pb.UpdateTrainingTaskRequest) (*pb.TrainingTaskConfig, error) {
+	return s.updateTrainingTask(ctx, req.GetId(), func(tr *models.TrainingTask) {
+		if req.TrainBookId != nil {
+			tr.TrainBookID = uint(req.GetTrainBookId())
+		}
+		if req.MatchBookId != nil {
+			tr.MatchBookID = uint(req.GetMatchBookId())
+		}
+		if req.TrainParameters != nil {
+			tr.TrainParameters = req.GetTrainParameters()
+		}
+		if req.MatchParameters != nil {
+			tr.MatchParameters = req.GetMatchParameters()
+		}
+		if req.BestNetworkId != nil {
+			tr.BestNetworkID = uint(req.GetBestNetworkId())
+		}
+	})
+}
+
+// SetTrainingTaskActive activates or deactivates a training task.
+func (s *AdminServiceImpl) SetTrainingTaskActive(ctx context.Context, req *pb.SetActiveRequest) (*pb.TrainingTaskConfig, error) {
+	return s.updateTrainingTask(ctx, req.GetId(), func(tr *models.TrainingTask) {
+		tr.Active = req.GetActive()
+	})
+}
+
+// ListTrainingTasks lists the training tasks of one run, or of all runs.
+func (s *AdminServiceImpl) ListTrainingTasks(ctx context.Context, req *pb.ListTrainingTasksRequest) (*pb.ListTrainingTasksResponse, error) {
+	tasks, err := queries.FetchTrainingTasks(s.DB, uint(req.GetTrainingRunId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load training tasks")
+	}
+	resp := &pb.ListTrainingTasksResponse{}
+	for i := range tasks {
+		resp.TrainingTasks = append(resp.TrainingTasks, trainingTaskToProto(&tasks[i]))
+	}
+	return resp, nil
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+func TestAdminDBError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"no rows", sql.ErrNoRows, codes.NotFound},
+		{"wrapped no rows", fmt.Errorf("load: %w", sql.ErrNoRows), codes.NotFound},
+		{"foreign key violation", &pq.Error{Code: "23503"}, codes.InvalidArgument},
+		{"other constraint", &pq.Error{Code: "23505"}, codes.Internal},
+		{"connection lost", errors.New("connection reset"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(adminDBError(tt.err, "Not found", "Failed")); got != tt.want {
+				t.Errorf("adminDBError(%v) code = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTrainingTask(t *testing.T) {
+	tests := []struct {
+		name      string
+		runID     uint64
+		insertErr error
+		wantCode  codes.Code
+	}{
+		{"created", 4, nil, codes.OK},
+		{"no training run", 0, nil, codes.InvalidArgument},
+		{"unknown book", 4, &pq.Error{Code: "23503"}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			if tt.runID != 0 {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO tasks").WithArgs(models.TaskTypeTraining, models.TaskStatusPending, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				insert := mock.ExpectQuery("INSERT INTO training_tasks")
+				if tt.insertErr != nil {
+					insert.WillReturnError(tt.insertErr)
+					mock.ExpectRollback()
+				} else {
+					insert.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+					mock.ExpectCommit()
+				}
+			}
+
+			cfg, err := NewAdminService(db).CreateTrainingTask(context.Background(), &pb.CreateTrainingTaskRequest{TrainingRunId: tt.runID, TrainBookId: 7})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("CreateTrainingTask code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && (cfg.GetId() != 20 || cfg.GetActive()) {
+				t.Errorf("CreateTrainingTask = id %d active %v, want id 20 inactive", cfg.GetId(), cfg.GetActive())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestSetTrainingTaskActive(t *testing.T) {
+	tests := []struct {
+		name       string
+		found      bool
+		wasActive  bool
+		active     bool
+		wantStatus string // base task status written, "" for none
+		wantCode   codes.Code
+	}{
+		{"activate", true, false, true, models.TaskStatusActive, codes.OK},
+		{"deactivate", true, true, false, models.TaskStatusPending, codes.OK},
+		{"already active", true, true, true, "", codes.OK},
+		{"not found", false, false, true, "", codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			query := mock.ExpectQuery("FROM training_tasks").WithArgs(2)
+			if !tt.found {
+				query.WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			} else {
+				query.WillReturnRows(sqlmock.NewRows(trainingTaskColumns).AddRow(2, now, now, 10, 4, 0, 0, 6, "", "", tt.wasActive, ""))
+				mock.ExpectExec("UPDATE training_tasks").WithArgs(nil, nil, "", "", 6, tt.active, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				if tt.wantStatus != "" {
+					mock.ExpectExec("UPDATE tasks").WithArgs(tt.wantStatus, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectCommit()
+			}
+
+			cfg, err := NewAdminService(db).SetTrainingTaskActive(context.Background(), &pb.SetActiveRequest{Id: 2, Active: tt.active})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("SetTrainingTaskActive code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && cfg.GetActive() != tt.active {
+				t.Errorf("SetTrainingTaskActive active = %v, want %v", cfg.GetActive(), tt.active)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/sprt"
+)
+
+// defaultSprtErrorRate is used for alpha and beta when a request leaves them at 0.
+const defaultSprtErrorRate = 0.05
+
+// resolveNetworkSha returns the id of the network a ResourceSpec refers to.
+func resolveNetworkSha(db queries.Querier, network *pb.ResourceSpec) (uint, error) {
+	if network.GetSha256() == "" {
+		return 0, status.Error(codes.InvalidArgument, "No network sha256 supplied")
+	}
+	id, err := queries.FetchNetworkIDBySha(db, network.GetSha256())
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, status.Errorf(codes.InvalidArgument, "Network %s not found", network.GetSha256())
+	}
+	if err != nil {
+		return 0, status.Error(codes.Internal, "Failed to load network")
+	}
+	return id, nil
+}
+
+// resolveBookSha returns the id of the book a ResourceSpec refers to.
+func resolveBookSha(db queries.Querier, book *pb.ResourceSpec) (uint, error) {
+	if book.GetSha256() == "" {
+		return 0, status.Error(codes.InvalidArgument, "No opening book sha256 supplied")
+	}
+	id, err := queries.FetchBookIDBySha(db, book.GetSha256())
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, status.Errorf(codes.InvalidArgument, "Opening book %s not found", book.GetSha256())
+	}
+	if err != nil {
+		return 0, status.Error(codes.Internal, "Failed to load opening book")
+	}
+	return id, nil
+}
+
+// resolveSprtEngine returns the stored form of one side of an SPRT test.
+func resolveSprtEngine(db queries.Querier, side string, cfg *pb.EngineConfiguration) (networkID uint, argsJSON, uciOptionsJSON string, err error) {
+	if cfg == nil {
+		return 0, "", "", status.Errorf(codes.InvalidArgument, "No %s engine supplied", side)
+	}
+	if cfg.GetBuild().GetRepoUrl() != "" || cfg.GetBuild().GetCommitHash() != "" {
+		return 0, "", "", status.Errorf(codes.InvalidArgument, "Custom %s builds are not supported for SPRT tests", side)
+	}
+	networkID, err = resolveNetworkSha(db, cfg.GetNetwork())
+	if err != nil {
+		return 0, "", "", err
+	}
+	argsJSON, uciOptionsJSON, err = encodeEngineParams(cfg.GetParams())
+	if err != nil {
+		return 0, "", "", status.Errorf(codes.InvalidArgument, "Invalid %s engine parameters", side)
+	}
+	return networkID, argsJSON, uciOptionsJSON, nil
+}
+
+// sprtTestToProto converts an SPRT task to its proto message.
+func sprtTestToProto(st *models.SprtTask) *pb.SprtTest {
+	lower, upper := sprt.Bounds(st.Alpha, st.Beta)
+	return &pb.SprtTest{
+		Id:          uint64(st.TaskID),
+		Status:      st.Task.Status,
+		Description: st.Task.Description,
+		Elo0:        st.Elo0,
+		Elo1:        st.Elo1,
+		Alpha:       st.Alpha,
+		Beta:        st.Beta,
+		Pentanomial: []int32{int32(st.LL), int32(st.LD), int32(st.DD), int32(st.DW), int32(st.WW)},
+		Unpaired:    []int32{int32(st.UnpairedLosses), int32(st.UnpairedDraws), int32(st.UnpairedWins)},
+		Llr:         st.LLR,
+		LowerBound:  lower,
+		UpperBound:  upper,
+		Verdict:     st.Verdict,
+		CreatedAt:   timestamppb.New(st.CreatedAt),
+	}
+}
+
+// CreateSprt validates and stores an SPRT test together with its ACTIVE base
+// task, so clients start playing it right away.
+func (s *AdminServiceImpl) CreateSprt(ctx context.Context, req *pb.CreateSprtRequest) (*pb.SprtTest, error) {
+	st := &models.SprtTask{
+		Elo0:  req.GetElo0(),
+		Elo1:  req.GetElo1(),
+		Alpha: req.GetAlpha(),
+		Beta:  req.GetBeta(),
+	}
+	if st.Alpha == 0 {
+		st.Alpha = defaultSprtErrorRate
+	}
+	if st.Beta == 0 {
+		st.Beta = defaultSprtErrorRate
+	}
+	test := sprt.Test{Elo0: st.Elo0, Elo1: st.Elo1, Alpha: st.Alpha, Beta: st.Beta}
+	if err := test.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid SPRT parameters: %v", err)
+	}
+	var err error
+	st.TimeControlType, st.BaseTimeSeconds, st.IncrementSeconds, st.NodesPerMove, err = timeControlColumns(req.GetTimeControl())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid time control: %v", err)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	st.BaselineNetworkID, st.BaselineParamsArgs, st.BaselineParamsUciOptions, err = resolveSprtEngine(tx, "baseline", req.GetBaseline())
+	if err != nil {
+		return nil, err
+	}
+	st.CandidateNetworkID, st.CandidateParamsArgs, st.CandidateParamsUciOptions, err = resolveSprtEngine(tx, "candidate", req.GetCandidate())
+	if err != nil {
+		return nil, err
+	}
+	st.OpeningBookID, err = resolveBookSha(tx, req.GetOpeningBook())
+	if err != nil {
+		return nil, err
+	}
+
+	st.Task = models.Task{TaskType: models.TaskTypeSprt, Status: models.TaskStatusActive, Description: req.GetDescription()}
+	st.TaskID, err = queries.InsertTask(tx, st.Task.TaskType, st.Task.Status, st.Task.Description)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to insert task")
+	}
+	st.Task.ID = st.TaskID
+	st.ID, err = queries.InsertSprtTask(tx, st)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to insert SPRT task")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	return sprtTestToProto(st), nil
+}
+
+// GetSprt returns an SPRT test with its current results.
+func (s *AdminServiceImpl) GetSprt(ctx context.Context, req *pb.GetSprtRequest) (*pb.SprtTest, error) {
+	st, err := queries.FetchSprtTaskByTaskID(s.DB, uint(req.GetId()))
+	if err != nil {
+		return nil, adminDBError(err, "SPRT test not found", "Failed to load SPRT test")
+	}
+	return sprtTestToProto(st), nil
+}
+
+// ListSprts lists running SPRT tests, or all of them, newest first.
+func (s *AdminServiceImpl) ListSprts(ctx context.Context, req *pb.ListSprtsRequest) (*pb.ListSprtsResponse, error) {
+	tasks, err := queries.FetchSprtTasks(s.DB, req.GetIncludeFinished())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load SPRT tests")
+	}
+	resp := &pb.ListSprtsResponse{}
+	for i := range tasks {
+		resp.Sprts = append(resp.Sprts, sprtTestToProto(&tasks[i]))
+	}
+	return resp, nil
+}
+
+// CancelSprt stops a running SPRT test. Its results are kept, and clients
+// still playing it are told to stop on their next heartbeat.
+func (s *AdminServiceImpl) CancelSprt(ctx context.Context, req *pb.CancelSprtRequest) (*pb.SprtTest, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	st, err := queries.FetchSprtTaskByTaskIDForUpdate(tx, uint(req.GetId()))
+	if err != nil {
+		return nil, adminDBError(err, "SPRT test not found", "Failed to load SPRT test")
+	}
+	if st.Task.Status == models.TaskStatusDone || st.Task.Status == models.TaskStatusCancelled {
+		return nil, status.Errorf(codes.FailedPrecondition, "SPRT test is already %s", st.Task.Status)
+	}
+	if err := queries.UpdateTaskStatus(tx, st.TaskID, models.TaskStatusCancelled); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to cancel SPRT test")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	st.Task.Status = models.TaskStatusCancelled
+	return sprtTestToProto(st), nil
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+// sprtRequest returns a valid request testing one network against another.
+func sprtRequest() *pb.CreateSprtRequest {
+	return &pb.CreateSprtRequest{
+		Baseline:    &pb.EngineConfiguration{Network: &pb.ResourceSpec{Sha256: "base"}},
+		Candidate:   &pb.EngineConfiguration{Network: &pb.ResourceSpec{Sha256: "cand"}},
+		OpeningBook: &pb.ResourceSpec{Sha256: "book"},
+		TimeControl: &pb.TimeControl{Control: &pb.TimeControl_NodesPerMove{NodesPerMove: 100}},
+		Elo0:        0,
+		Elo1:        5,
+	}
+}
+
+func TestCreateSprtValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*pb.CreateSprtRequest)
+		inTx    bool // rejected while resolving engines
+		lookups int  // networks found before the rejection
+	}{
+		{"elo0 not below elo1", func(r *pb.CreateSprtRequest) { r.Elo0 = 5 }, false, 0},
+		{"alpha out of range", func(r *pb.CreateSprtRequest) { r.Alpha = 1 }, false, 0},
+		{"no time control", func(r *pb.CreateSprtRequest) { r.TimeControl = nil }, false, 0},
+		{"custom build", func(r *pb.CreateSprtRequest) {
+			r.Baseline.Build = &pb.BuildSpec{RepoUrl: "https://example.com/lc0.git"}
+		}, true, 0},
+		{"no candidate", func(r *pb.CreateSprtRequest) { r.Candidate = nil }, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			if tt.inTx {
+				mock.ExpectBegin()
+				for i := 0; i < tt.lookups; i++ {
+					mock.ExpectQuery("FROM networks").WithArgs("base").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				}
+				mock.ExpectRollback()
+			}
+
+			req := sprtRequest()
+			tt.change(req)
+			_, err = NewAdminService(db).CreateSprt(context.Background(), req)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Fatalf("CreateSprt code = %v, want InvalidArgument (err %v)", got, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateSprtUnknownNetwork(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM networks").WithArgs("base").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = NewAdminService(db).CreateSprt(context.Background(), sprtRequest())
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("CreateSprt code = %v, want InvalidArgument (err %v)", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet database expectations: %v", err)
+	}
+}
+
+func TestCancelSprt(t *testing.T) {
+	tests := []struct {
+		name     string
+		found    bool
+		status   string
+		wantCode codes.Code
+	}{
+		{"running", true, models.TaskStatusActive, codes.OK},
+		{"already done", true, models.TaskStatusDone, codes.FailedPrecondition},
+		{"already cancelled", true, models.TaskStatusCancelled, codes.FailedPrecondition},
+		{"not found", false, "", codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			query := mock.ExpectQuery("FROM sprt_tasks").WithArgs(3)
+			if !tt.found {
+				query.WillReturnError(sql.ErrNoRows)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows(sprtTaskColumns).AddRow(
+					4, now, now, 3, 1, "", "", 2, "", "", 5, "nodes", 0.0, 0.0, 100,
+					0.0, 5.0, 0.05, 0.05, 1, 2, 3, 2, 1, 0, 0, 0, 0.0, "", tt.status, ""))
+			}
+			if tt.wantCode == codes.OK {
+				mock.ExpectExec("UPDATE tasks").WithArgs(models.TaskStatusCancelled, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			test, err := NewAdminService(db).CancelSprt(context.Background(), &pb.CancelSprtRequest{Id: 3})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("CancelSprt code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && test.GetStatus() != models.TaskStatusCancelled {
+				t.Errorf("CancelSprt status = %q, want %q", test.GetStatus(), models.TaskStatusCancelled)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,511 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/tuning"
+)
+
+// maxGeneratedParamSets caps how many parameter sets one tuning job may have.
+const maxGeneratedParamSets = 1000
+
+// Defaults of Bayesian tuning jobs, those of chess-tuning-tools where it has one.
+const (
+	defaultBayesInitialPoints = 16
+	defaultBayesBatchSize     = 4
+	defaultBayesKappa         = 1.96
+)
+
+// tuneModes maps the proto tuning modes to their stored form.
+var tuneModes = map[pb.TuningMode]string{
+	pb.TuningMode_TUNING_GRID:     models.TuneModeGrid,
+	pb.TuningMode_TUNING_RANDOM:   models.TuneModeRandom,
+	pb.TuningMode_TUNING_SPSA:     models.TuneModeSpsa,
+	pb.TuningMode_TUNING_BAYESIAN: models.TuneModeBayesian,
+}
+
+// tuningModeToProto is the inverse of tuneModes.
+func tuningModeToProto(mode string) pb.TuningMode {
+	for m, s := range tuneModes {
+		if s == mode {
+			return m
+		}
+	}
+	return pb.TuningMode_TUNING_MODE_UNSPECIFIED
+}
+
+// requestSpace returns the parameter space of a request.
+func requestSpace(req *pb.CreateTuningRequest) []tuning.Param {
+	space := make([]tuning.Param, 0, len(req.GetParameters()))
+	for _, p := range req.GetParameters() {
+		space = append(space, tuning.Param{
+			Name:   p.GetName(),
+			Min:    p.GetMin(),
+			Max:    p.GetMax(),
+			Step:   p.GetStep(),
+			Values: p.GetValues(),
+		})
+	}
+	return space
+}
+
+// requestRand returns a random source seeded by a request, or at random if
+// it has no seed.
+func requestRand(req *pb.CreateTuningRequest) *rand.Rand {
+	seed := req.GetSeed()
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return rand.New(rand.NewPCG(seed, 0))
+}
+
+// generateParamPoints generates the points of a request's parameter space.
+func generateParamPoints(req *pb.CreateTuningRequest) ([]tuning.Point, error) {
+	space := requestSpace(req)
+	if req.GetMode() == pb.TuningMode_TUNING_RANDOM {
+		if req.GetNumSamples() > maxGeneratedParamSets {
+			return nil, status.Errorf(codes.InvalidArgument, "At most %d samples are allowed", maxGeneratedParamSets)
+		}
+		points, err := tuning.Random(space, int(req.GetNumSamples()), requestRand(req))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid parameter space: %v", err)
+		}
+		return points, nil
+	}
+	points, err := tuning.Grid(space, maxGeneratedParamSets)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid parameter space: %v", err)
+	}
+	return points, nil
+}
+
+// spsaParamsFromRequest validates the SPSA configuration and parameters of a
+// request, stores the gains in tt with defaults applied, and returns the
+// parameters at their start values.
+func spsaParamsFromRequest(req *pb.CreateTuningRequest, tt *models.TuneTask) ([]models.TuneParam, error) {
+	cfg := req.GetSpsa()
+	if cfg.GetIterations() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "SPSA iterations must be positive")
+	}
+	gains := tuning.SPSA{A: cfg.GetA(), C: cfg.GetC(), Stability: cfg.GetStability(), Alpha: cfg.GetAlpha(), Gamma: cfg.GetGamma()}
+	if gains.Stability == 0 {
+		gains.Stability = float64(cfg.GetIterations()) / 10
+	}
+	if gains.Alpha == 0 {
+		gains.Alpha = tuning.DefaultAlpha
+	}
+	if gains.Gamma == 0 {
+		gains.Gamma = tuning.DefaultGamma
+	}
+
+	space := requestSpace(req)
+	start := make([]float64, 0, len(req.GetParameters()))
+	for _, p := range req.GetParameters() {
+		if p.Start != nil {
+			start = append(start, p.GetStart())
+		} else {
+			start = append(start, (p.GetMin()+p.GetMax())/2)
+		}
+	}
+	if err := gains.Validate(space, start); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid SPSA configuration: %v", err)
+	}
+
+	tt.SpsaA, tt.SpsaC, tt.SpsaStability, tt.SpsaAlpha, tt.SpsaGamma = gains.A, gains.C, gains.Stability, gains.Alpha, gains.Gamma
+	tt.SpsaIterations = int(cfg.GetIterations())
+	params := make([]models.TuneParam, len(space))
+	for i, p := range space {
+		params[i] = models.TuneParam{Name: p.Name, Min: p.Min, Max: p.Max, Step: p.Step, Value: start[i]}
+	}
+	return params, nil
+}
+
+// bayesianFromRequest validates the Bayesian optimization settings and
+// parameters of a request, stores the settings in tt with defaults applied,
+// and returns the parameters with the random points measured first.
+func bayesianFromRequest(req *pb.CreateTuningRequest, tt *models.TuneTask) ([]models.TuneParam, []tuning.Point, error) {
+	cfg := req.GetBayesian()
+	if cfg.GetMaxPoints() <= 0 || cfg.GetMaxPoints() > maxGeneratedParamSets {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Max points must be between 1 and %d", maxGeneratedParamSets)
+	}
+	if cfg.GetInitialPoints() < 0 || cfg.GetBatchSize() < 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "Initial points and batch size must not be negative")
+	}
+	tt.BayesMaxPoints = int(cfg.GetMaxPoints())
+	tt.BayesInitialPoints = min(orDefault(int(cfg.GetInitialPoints()), defaultBayesInitialPoints), tt.BayesMaxPoints)
+	tt.BayesBatchSize = orDefault(int(cfg.GetBatchSize()), defaultBayesBatchSize)
+	tt.BayesKappa = cfg.GetKappa()
+	if tt.BayesKappa == 0 {
+		tt.BayesKappa = defaultBayesKappa
+	}
+	switch cfg.GetAcquisition() {
+	case pb.Acquisition_ACQUISITION_UNSPECIFIED, pb.Acquisition_ACQUISITION_EI:
+		tt.BayesAcquisition = models.BayesAcquisitionEI
+	case pb.Acquisition_ACQUISITION_UCB:
+		tt.BayesAcquisition = models.BayesAcquisitionUCB
+	default:
+		return nil, nil, status.Error(codes.InvalidArgument, "Unknown acquisition function")
+	}
+
+	space := requestSpace(req)
+	if err := bayesOptimizer(tt).Validate(space); err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid Bayesian configuration: %v", err)
+	}
+	points, err := tuning.Random(space, tt.BayesInitialPoints, requestRand(req))
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid parameter space: %v", err)
+	}
+	params := make([]models.TuneParam, len(space))
+	for i, p := range space {
+		params[i] = models.TuneParam{Name: p.Name, Min: p.Min, Max: p.Max, Step: p.Step}
+	}
+	return params, points, nil
+}
+
+// bayesianConfigToProto returns the Bayesian optimization settings of a tuning
+// task as a proto message.
+func bayesianConfigToProto(tt *models.TuneTask) *pb.BayesianConfig {
+	cfg := &pb.BayesianConfig{
+		InitialPoints: int32(tt.BayesInitialPoints),
+		BatchSize:     int32(tt.BayesBatchSize),
+		MaxPoints:     int32(tt.BayesMaxPoints),
+		Acquisition:   pb.Acquisition_ACQUISITION_EI,
+		Kappa:         tt.BayesKappa,
+	}
+	if tt.BayesAcquisition == models.BayesAcquisitionUCB {
+		cfg.Acquisition = pb.Acquisition_ACQUISITION_UCB
+	}
+	return cfg
+}
+
+// spsaConfigToProto returns the SPSA gains of a tuning task as a proto message.
+func spsaConfigToProto(tt *models.TuneTask) *pb.SpsaConfig {
+	return &pb.SpsaConfig{
+		A:          tt.SpsaA,
+		C:          tt.SpsaC,
+		Stability:  tt.SpsaStability,
+		Alpha:      tt.SpsaAlpha,
+		Gamma:      tt.SpsaGamma,
+		Iterations: int32(tt.SpsaIterations),
+	}
+}
+
+// spsaHistoryToProto converts completed SPSA iterations to their proto messages.
+func spsaHistoryToProto(its []models.SpsaIteration) ([]*pb.SpsaIterationResult, error) {
+	history := make([]*pb.SpsaIterationResult, 0, len(its))
+	for i, it := range its {
+		r := &pb.SpsaIterationResult{
+			Iteration:   int32(i + 1),
+			GamesPlayed: int32(it.GamesPlayed),
+			Score:       int32(it.Score),
+		}
+		if it.ValuesAfter != "" {
+			if err := json.Unmarshal([]byte(it.ValuesAfter), &r.Values); err != nil {
+				return nil, err
+			}
+		}
+		if it.CompletedAt != nil {
+			r.CompletedAt = timestamppb.New(*it.CompletedAt)
+		}
+		history = append(history, r)
+	}
+	return history, nil
+}
+
+// tuneParamSetToProto converts a parameter set and its results to its proto message.
+func tuneParamSetToProto(ps *models.TuneParamSet) (*pb.TunedParamSet, error) {
+	params, err := engineParams(ps.ParamsArgs, ps.ParamsUciOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TunedParamSet{
+		ParamSetId:  ps.ParamSetID,
+		Params:      params,
+		GamesPlayed: int32(ps.GamesPlayed),
+		Pentanomial: []int32{int32(ps.LL), int32(ps.LD), int32(ps.DD), int32(ps.DW), int32(ps.WW)},
+		Elo:         ps.Elo,
+		EloLower:    ps.EloLower,
+		EloUpper:    ps.EloUpper,
+	}, nil
+}
+
+// tuningJobToProto converts a tuning task and its parameter sets to its proto message.
+func tuningJobToProto(tt *models.TuneTask, sets []models.TuneParamSet) (*pb.TuningJob, error) {
+	job := &pb.TuningJob{
+		Id:               uint64(tt.TaskID),
+		Status:           tt.Task.Status,
+		Description:      tt.Task.Description,
+		Mode:             tuningModeToProto(tt.Mode),
+		GamesPerParamSet: tt.GamesPerParamSet,
+		CreatedAt:        timestamppb.New(tt.CreatedAt),
+	}
+	switch tt.Mode {
+	case models.TuneModeSpsa:
+		job.Spsa = &pb.SpsaState{Config: spsaConfigToProto(tt), CompletedIterations: int32(tt.SpsaCompleted)}
+	case models.TuneModeBayesian:
+		job.Bayesian = bayesianConfigToProto(tt)
+	}
+	for i := range sets {
+		ps, err := tuneParamSetToProto(&sets[i])
+		if err != nil {
+			return nil, err
+		}
+		job.ParamSets = append(job.ParamSets, ps)
+	}
+	return job, nil
+}
+
+// CreateTuning generates the parameter sets of a tuning job and stores them
+// together with an ACTIVE base task, so clients start playing right away. SPSA
+// jobs store their parameters instead and draw parameter sets as clients ask;
+// Bayesian jobs store them with the random sets measured first.
+func (s *AdminServiceImpl) CreateTuning(ctx context.Context, req *pb.CreateTuningRequest) (*pb.TuningJob, error) {
+	mode, ok := tuneModes[req.GetMode()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "No tuning mode supplied")
+	}
+	if req.GetGamesPerParamSet() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Games per parameter set must be positive")
+	}
+	for _, p := range req.GetParameters() {
+		if _, ok := req.GetBaseParams().GetUciOptions()[p.GetName()]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Parameter %s is also a base UCI option", p.GetName())
+		}
+	}
+	tt := &models.TuneTask{
+		BuildRepoURL:     req.GetBuild().GetRepoUrl(),
+		BuildCommitHash:  req.GetBuild().GetCommitHash(),
+		GamesPerParamSet: req.GetGamesPerParamSet(),
+		Mode:             mode,
+	}
+	if len(req.GetBuild().GetBuildParams()) > 0 {
+		b, err := json.Marshal(req.GetBuild().GetBuildParams())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid build parameters")
+		}
+		tt.BuildParams = string(b)
+	}
+	var err error
+	tt.TimeControlType, tt.BaseTimeSeconds, tt.IncrementSeconds, tt.NodesPerMove, err = timeControlColumns(req.GetTimeControl())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid time control: %v", err)
+	}
+	tt.ParamsArgs, tt.ParamsUciOptions, err = encodeEngineParams(req.GetBaseParams())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid engine parameters")
+	}
+
+	var points []tuning.Point
+	var params []models.TuneParam
+	switch mode {
+	case models.TuneModeSpsa:
+		params, err = spsaParamsFromRequest(req, tt)
+	case models.TuneModeBayesian:
+		params, points, err = bayesianFromRequest(req, tt)
+	default:
+		points, err = generateParamPoints(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Every set shares the base args and UCI options, plus its own point
+	sets := make([]models.TuneParamSet, 0, len(points))
+	for _, pt := range points {
+		ps, err := tuneParamSet(tt, pt.ID(), pt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid engine parameters")
+		}
+		sets = append(sets, ps)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	tt.TuneNetworkID, err = resolveNetworkSha(tx, req.GetNetwork())
+	if err != nil {
+		return nil, err
+	}
+	tt.OpeningBookID, err = resolveBookSha(tx, req.GetOpeningBook())
+	if err != nil {
+		return nil, err
+	}
+	tt.Task = models.Task{TaskType: models.TaskTypeTuning, Status: models.TaskStatusActive, Description: req.GetDescription()}
+	tt.TaskID, err = queries.InsertTask(tx, tt.Task.TaskType, tt.Task.Status, tt.Task.Description)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to insert task")
+	}
+	tt.Task.ID = tt.TaskID
+	tt.ID, err = queries.InsertTuneTask(tx, tt)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to insert tuning task")
+	}
+	for i := range sets {
+		sets[i].TuneTaskID = tt.ID
+		sets[i].ID, err = queries.InsertTuneParamSet(tx, &sets[i])
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to insert parameter set")
+		}
+	}
+	for i := range params {
+		params[i].TuneTaskID = tt.ID
+		params[i].ID, err = queries.InsertTuneParam(tx, &params[i])
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to insert tuning parameter")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	job, err := tuningJobToProto(tt, sets)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to decode parameter sets")
+	}
+	if job.Spsa != nil {
+		job.Spsa.Values = spsaValues(params)
+	}
+	return job, nil
+}
+
+// GetTuning returns a tuning job with its parameter sets, best estimated Elo
+// first, or with the current values and history of an SPSA job.
+func (s *AdminServiceImpl) GetTuning(ctx context.Context, req *pb.GetTuningRequest) (*pb.TuningJob, error) {
+	tt, err := queries.FetchTuneTaskByTaskID(s.DB, uint(req.GetId()))
+	if err != nil {
+		return nil, adminDBError(err, "Tuning job not found", "Failed to load tuning job")
+	}
+	if tt.Mode == models.TuneModeSpsa {
+		return s.getSpsaTuning(tt)
+	}
+	sets, err := queries.FetchRankedTuneParamSets(s.DB, tt.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load parameter sets")
+	}
+	job, err := tuningJobToProto(tt, sets)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Tuning task %d: %v", tt.ID, err)
+	}
+	return job, nil
+}
+
+// getSpsaTuning returns an SPSA tuning job with its current values and history.
+func (s *AdminServiceImpl) getSpsaTuning(tt *models.TuneTask) (*pb.TuningJob, error) {
+	params, err := queries.FetchTuneParams(s.DB, tt.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load SPSA parameters")
+	}
+	its, err := queries.FetchCompletedSpsaIterations(s.DB, tt.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load SPSA history")
+	}
+	job, _ := tuningJobToProto(tt, nil)
+	job.Spsa.Values = spsaValues(params)
+	job.Spsa.History, err = spsaHistoryToProto(its)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Tuning task %d history: %v", tt.ID, err)
+	}
+	return job, nil
+}
+
+// ListTunings lists running tuning jobs, or all of them, newest first.
+func (s *AdminServiceImpl) ListTunings(ctx context.Context, req *pb.ListTuningsRequest) (*pb.ListTuningsResponse, error) {
+	tasks, err := queries.FetchTuneTasks(s.DB, req.GetIncludeFinished())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load tuning jobs")
+	}
+	resp := &pb.ListTuningsResponse{}
+	for i := range tasks {
+		job, _ := tuningJobToProto(&tasks[i], nil)
+		resp.Tunings = append(resp.Tunings, job)
+	}
+	return resp, nil
+}
+
+// CancelTuning stops a running tuning job. Its results are kept, and clients
+// still playing it are told to stop on their next heartbeat.
+func (s *AdminServiceImpl) CancelTuning(ctx context.Context, req *pb.CancelTuningRequest) (*pb.TuningJob, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	tt, err := queries.FetchTuneTaskByTaskIDForUpdate(tx, uint(req.GetId()))
+	if err != nil {
+		return nil, adminDBError(err, "Tuning job not found", "Failed to load tuning job")
+	}
+	if tt.Task.Status == models.TaskStatusDone || tt.Task.Status == models.TaskStatusCancelled {
+		return nil, status.Errorf(codes.FailedPrecondition, "Tuning job is already %s", tt.Task.Status)
+	}
+	if err := queries.UpdateTaskStatus(tx, tt.TaskID, models.TaskStatusCancelled); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to cancel tuning job")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	tt.Task.Status = models.TaskStatusCancelled
+	job, _ := tuningJobToProto(tt, nil)
+	return job, nil
+}
+
+// GetTuningOptimum fits the surrogate of a Bayesian tuning job to its
+// finished parameter sets and returns the values it predicts to be best.
+func (s *AdminServiceImpl) GetTuningOptimum(ctx context.Context, req *pb.GetTuningRequest) (*pb.TuningOptimum, error) {
+	tt, err := queries.FetchTuneTaskByTaskID(s.DB, uint(req.GetId()))
+	if err != nil {
+		return nil, adminDBError(err, "Tuning job not found", "Failed to load tuning job")
+	}
+	if tt.Mode != models.TuneModeBayesian {
+		return nil, status.Error(codes.FailedPrecondition, "Tuning job is not a Bayesian one")
+	}
+	params, err := queries.FetchTuneParams(s.DB, tt.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load tuning parameters")
+	}
+	sets, err := queries.FetchRankedTuneParamSets(s.DB, tt.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load parameter sets")
+	}
+	space, _ := tuneSpace(params)
+	obs, from := tuneObservations(space, sets, tt.GamesPerParamSet)
+	if len(obs) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "No parameter set has finished yet")
+	}
+	rng := rand.New(rand.NewPCG(uint64(tt.ID), uint64(len(sets))))
+	values, elo, stddev, err := bayesOptimizer(tt).Optimum(space, obs, rng)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Tuning task %d: %v", tt.ID, err)
+	}
+
+	resp := &pb.TuningOptimum{
+		Id:         uint64(tt.TaskID),
+		UciOptions: tuning.PointAt(space, values),
+		Elo:        elo,
+		EloStddev:  stddev,
+	}
+	for i, o := range obs {
+		ob := &pb.TuningObservation{
+			ParamSetId: from[i].ParamSetID,
+			Values:     make(map[string]float64, len(space)),
+			Elo:        o.Elo,
+			EloStddev:  o.EloStdDev,
+		}
+		for j, p := range space {
+			ob.Values[p.Name] = o.Values[j]
+		}
+		resp.Observations = append(resp.Observations, ob)
+	}
+	return resp, nil
+}
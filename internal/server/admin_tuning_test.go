@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+// tuningRequest returns a valid request over one parameter with three values.
+func tuningRequest(mode pb.TuningMode) *pb.CreateTuningRequest {
+	return &pb.CreateTuningRequest{
+		Network:          &pb.ResourceSpec{Sha256: "net"},
+		OpeningBook:      &pb.ResourceSpec{Sha256: "book"},
+		TimeControl:      &pb.TimeControl{Control: &pb.TimeControl_NodesPerMove{NodesPerMove: 100}},
+		GamesPerParamSet: 50,
+		Parameters:       []*pb.TuningParameter{{Name: "CPuct", Min: 1, Max: 3, Step: 1}},
+		Mode:             mode,
+		NumSamples:       2,
+		Seed:             1,
+		Spsa:             &pb.SpsaConfig{A: 1, C: 0.5, Iterations: 10},
+		Bayesian:         &pb.BayesianConfig{InitialPoints: 2, MaxPoints: 3},
+	}
+}
+
+func TestCreateTuning(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     pb.TuningMode
+		wantMode string
+		sets     int
+		params   int
+		wantCode codes.Code
+	}{
+		{"grid", pb.TuningMode_TUNING_GRID, models.TuneModeGrid, 3, 0, codes.OK},
+		{"random", pb.TuningMode_TUNING_RANDOM, models.TuneModeRandom, 2, 0, codes.OK},
+		{"spsa", pb.TuningMode_TUNING_SPSA, models.TuneModeSpsa, 0, 1, codes.OK},
+		{"bayesian", pb.TuningMode_TUNING_BAYESIAN, models.TuneModeBayesian, 2, 1, codes.OK},
+		{"unspecified", pb.TuningMode_TUNING_MODE_UNSPECIFIED, "", 0, 0, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			if tt.wantCode == codes.OK {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM networks").WithArgs("net").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectQuery("FROM books").WithArgs("book").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectQuery("INSERT INTO tasks").WithArgs(models.TaskTypeTuning, models.TaskStatusActive, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				mock.ExpectQuery("INSERT INTO tune_tasks").
+					WithArgs(10, "", "", "", 3, 4, 50, tt.wantMode, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(20, time.Now()))
+				for i := 0; i < tt.sets; i++ {
+					mock.ExpectQuery("INSERT INTO tune_param_sets").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30 + i))
+				}
+				for i := 0; i < tt.params; i++ {
+					mock.ExpectQuery("INSERT INTO tune_params").WithArgs(20, "CPuct", 1.0, 3.0, 1.0, sqlmock.AnyArg()).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(40 + i))
+				}
+				mock.ExpectCommit()
+			}
+
+			job, err := NewAdminService(db).CreateTuning(context.Background(), tuningRequest(tt.mode))
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("CreateTuning code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK {
+				if job.GetMode() != tt.mode || len(job.GetParamSets()) != tt.sets {
+					t.Errorf("CreateTuning = mode %v with %d sets, want %v with %d", job.GetMode(), len(job.GetParamSets()), tt.mode, tt.sets)
+				}
+				if tt.mode == pb.TuningMode_TUNING_BAYESIAN && job.GetBayesian().GetBatchSize() != defaultBayesBatchSize {
+					t.Errorf("Bayesian batch size = %d, want default %d", job.GetBayesian().GetBatchSize(), defaultBayesBatchSize)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestTuningModeToProto(t *testing.T) {
+	for m, stored := range tuneModes {
+		if got := tuningModeToProto(stored); got != m {
+			t.Errorf("tuningModeToProto(%q) = %v, want %v", stored, got, m)
+		}
+	}
+	if len(tuneModes) != len(pb.TuningMode_name)-1 {
+		t.Errorf("tuneModes maps %d modes, want every mode but UNSPECIFIED", len(tuneModes))
+	}
+}
+
+func TestCreateTuningValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   pb.TuningMode
+		change func(*pb.CreateTuningRequest)
+	}{
+		{"no games", pb.TuningMode_TUNING_GRID, func(r *pb.CreateTuningRequest) { r.GamesPerParamSet = 0 }},
+		{"parameter is a base option", pb.TuningMode_TUNING_GRID, func(r *pb.CreateTuningRequest) {
+			r.BaseParams = &pb.EngineParams{UciOptions: map[string]string{"CPuct": "2"}}
+		}},
+		{"no time control", pb.TuningMode_TUNING_GRID, func(r *pb.CreateTuningRequest) { r.TimeControl = nil }},
+		{"empty parameter range", pb.TuningMode_TUNING_GRID, func(r *pb.CreateTuningRequest) { r.Parameters[0].Max = 0 }},
+		{"too many random steps", pb.TuningMode_TUNING_RANDOM, func(r *pb.CreateTuningRequest) {
+			r.Parameters[0].Max, r.Parameters[0].Step = 1e18, 1e-9
+		}},
+		{"too many Bayesian steps", pb.TuningMode_TUNING_BAYESIAN, func(r *pb.CreateTuningRequest) {
+			r.Parameters[0].Max, r.Parameters[0].Step = 1e18, 1e-9
+		}},
+		{"no SPSA iterations", pb.TuningMode_TUNING_SPSA, func(r *pb.CreateTuningRequest) { r.Spsa.Iterations = 0 }},
+		{"no Bayesian points", pb.TuningMode_TUNING_BAYESIAN, func(r *pb.CreateTuningRequest) { r.Bayesian.MaxPoints = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			req := tuningRequest(tt.mode)
+			tt.change(req)
+			_, err = NewAdminService(db).CreateTuning(context.Background(), req)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Fatalf("CreateTuning code = %v, want InvalidArgument (err %v)", got, err)
+			}
+			// Requests are rejected before touching the database
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetTuningNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("FROM tune_tasks").WithArgs(3).WillReturnError(sql.ErrNoRows)
+	_, err = NewAdminService(db).GetTuning(context.Background(), &pb.GetTuningRequest{Id: 3})
+	if got := status.Code(err); got != codes.NotFound {
+		t.Errorf("GetTuning code = %v, want NotFound (err %v)", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet database expectations: %v", err)
+	}
+}
+
+func TestCancelTuning(t *testing.T) {
+	tests := []struct {
+		name     string
+		found    bool
+		status   string
+		wantCode codes.Code
+	}{
+		{"running", true, models.TaskStatusActive, codes.OK},
+		{"already done", true, models.TaskStatusDone, codes.FailedPrecondition},
+		{"already cancelled", true, models.TaskStatusCancelled, codes.FailedPrecondition},
+		{"not found", false, "", codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			query := mock.ExpectQuery("FROM tune_tasks").WithArgs(3)
+			if !tt.found {
+				query.WillReturnError(sql.ErrNoRows)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows(tuneTaskColumns).AddRow(
+					5, now, now, 3, "", "", "", 1, 2, 10, models.TuneModeGrid, "", "", "nodes", 0.0, 0.0, 100,
+					0.0, 0.0, 0.0, 0.0, 0.0, 0, 0, 0, 0, 0, "", 0.0, tt.status, ""))
+			}
+			if tt.wantCode == codes.OK {
+				mock.ExpectExec("UPDATE tasks").WithArgs(models.TaskStatusCancelled, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			job, err := NewAdminService(db).CancelTuning(context.Background(), &pb.CancelTuningRequest{Id: 3})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("CancelTuning code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && job.GetStatus() != models.TaskStatusCancelled {
+				t.Errorf("CancelTuning status = %q, want %q", job.GetStatus(), models.TaskStatusCancelled)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
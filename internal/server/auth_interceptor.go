@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+// ErrInvalidTokenFormat is returned when a token does not start with "lc0-".
+var ErrInvalidTokenFormat = status.Error(codes.Unauthenticated, "invalid token format")
+
+// ErrTokenRevoked is returned for tokens revoked by their owner.
+var ErrTokenRevoked = status.Error(codes.PermissionDenied, "Token has been revoked")
+
+// ErrTokenSuspended is returned for tokens suspended by the server operators.
+var ErrTokenSuspended = status.Error(codes.PermissionDenied, "Token has been suspended")
+
+// ErrMissingToken is returned when a call needing a token carries none.
+var ErrMissingToken = status.Error(codes.Unauthenticated, "No token supplied")
+
+// publicMethods can be called without a token, as they are how clients get one.
+var publicMethods = map[string]bool{
+	pb.AuthService_MigrateCredentials_FullMethodName: true,
+	pb.AuthService_GetAnonymousToken_FullMethodName:  true,
+}
+
+// adminMethodPrefix starts the full method name of every AdminService RPC.
+var adminMethodPrefix = "/" + pb.AdminService_ServiceDesc.ServiceName + "/"
+
+// ErrNotAdmin is returned when a token without admin scope calls AdminService.
+var ErrNotAdmin = status.Error(codes.PermissionDenied, "Admin token required")
+
+type tokenContextKey struct{}
+
+// ContextWithToken returns a copy of ctx carrying the authenticated token.
+func ContextWithToken(ctx context.Context, tok *models.AuthToken) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, tok)
+}
+
+// TokenFromContext returns the token the auth interceptor validated for this
+// call.
+func TokenFromContext(ctx context.Context) (*models.AuthToken, error) {
+	tok, ok := ctx.Value(tokenContextKey{}).(*models.AuthToken)
+	if !ok || tok == nil {
+		return nil, ErrMissingToken
+	}
+	return tok, nil
+}
+
+// TokenAuthenticator validates the token of every non-public call and makes
+// it available to handlers through TokenFromContext. AdminService calls also
+// need an admin-scoped token.
+type TokenAuthenticator struct {
+	Tokens *TokenCache
+}
+
+// NewTokenAuthenticator creates a TokenAuthenticator validating through tokens.
+func NewTokenAuthenticator(tokens *TokenCache) *TokenAuthenticator {
+	return &TokenAuthenticator{Tokens: tokens}
+}
+
+// UnaryInterceptor is a grpc.UnaryServerInterceptor authenticating calls.
+func (a *TokenAuthenticator) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	token, err := requestToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	tok, err := a.Tokens.Validate(token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(info.FullMethod, adminMethodPrefix) && tok.Scope != models.TokenScopeAdmin {
+		return nil, ErrNotAdmin
+	}
+	return handler(ContextWithToken(ctx, tok), req)
+}
+
+// requestToken reads the token from "authorization: Bearer" metadata, falling
+// back to the deprecated token field of the request.
+func requestToken(ctx context.Context, req any) (string, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			scheme, token, found := strings.Cut(values[0], " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") {
+				return "", status.Error(codes.Unauthenticated, "Authorization must be a Bearer token")
+			}
+			return strings.TrimSpace(token), nil
+		}
+	}
+	if legacy, ok := req.(interface{ GetToken() string }); ok && legacy.GetToken() != "" {
+		return legacy.GetToken(), nil
+	}
+	return "", ErrMissingToken
+}
+
+// checkTokenStatus rejects tokens that are not ACTIVE.
+func checkTokenStatus(tok *models.AuthToken) error {
+	switch tok.Status {
+	case models.TokenStatusActive:
+		return nil
+	case models.TokenStatusRevoked:
+		return ErrTokenRevoked
+	}
+	return ErrTokenSuspended
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+)
+
+const testToken = "lc0-0123456789abcdef"
+
+// tokenRows returns an empty result set with the columns of an auth_tokens select.
+func tokenRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "created_at", "updated_at", "user_id", "token", "last_used_at", "issued_reason",
+		"client_version", "client_host", "gpu_type", "gpu_id", "status", "revoked_at", "scope"})
+}
+
+func TestRequestToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		req      any
+		want     string
+		wantCode codes.Code
+	}{
+		{"bearer metadata", metadata.Pairs("authorization", "Bearer "+testToken), &pb.TaskRequest{}, testToken, codes.OK},
+		{"metadata wins over field", metadata.Pairs("authorization", "bearer "+testToken), &pb.TaskRequest{Token: "lc0-old"}, testToken, codes.OK},
+		{"legacy field", nil, &pb.ProgressReport{Token: testToken}, testToken, codes.OK},
+		{"other scheme", metadata.Pairs("authorization", "Basic "+testToken), &pb.TaskRequest{}, "", codes.Unauthenticated},
+		{"missing", nil, &pb.TaskRequest{}, "", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			got, err := requestToken(ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("requestToken code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("requestToken = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	defer db.Close()
+	a := NewTokenAuthenticator(NewTokenCache(db))
+
+	var seen bool
+	handler := func(ctx context.Context, req any) (any, error) {
+		_, err := TokenFromContext(ctx)
+		seen = err == nil
+		return nil, nil
+	}
+
+	// Public methods need no token
+	info := &grpc.UnaryServerInfo{FullMethod: pb.AuthService_GetAnonymousToken_FullMethodName}
+	if _, err := a.UnaryInterceptor(context.Background(), &pb.AnonymousTokenRequest{}, info, handler); err != nil {
+		t.Fatalf("public method: %v", err)
+	}
+	if seen {
+		t.Error("public method got a token in its context")
+	}
+
+	info = &grpc.UnaryServerInfo{FullMethod: pb.TaskService_GetNextTask_FullMethodName}
+	if _, err := a.UnaryInterceptor(context.Background(), &pb.TaskRequest{}, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("call without token code = %v, want Unauthenticated", status.Code(err))
+	}
+
+	now := time.Now()
+	mock.ExpectQuery("FROM auth_tokens").WithArgs(testToken).WillReturnRows(
+		tokenRows().AddRow(3, now, now, nil, testToken, nil, "anonymous", "", "", "", nil, "ACTIVE", nil, "CLIENT"))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+testToken))
+	if _, err := a.UnaryInterceptor(ctx, &pb.TaskRequest{}, info, handler); err != nil {
+		t.Fatalf("call with token: %v", err)
+	}
+	if !seen {
+		t.Error("handler did not get the token from its context")
+	}
+
+	// The token is cached now, so this call does not query the database
+	if _, err := a.UnaryInterceptor(ctx, &pb.TaskRequest{}, info, handler); err != nil {
+		t.Fatalf("second call with token: %v", err)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer lc0-unknown"))
+	mock.ExpectQuery("FROM auth_tokens").WithArgs("lc0-unknown").WillReturnRows(sqlmock.NewRows(nil))
+	if _, err := a.UnaryInterceptor(ctx, &pb.TaskRequest{}, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("call with unknown token code = %v, want Unauthenticated", status.Code(err))
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer lc0-revoked"))
+	mock.ExpectQuery("FROM auth_tokens").WithArgs("lc0-revoked").WillReturnRows(
+		tokenRows().AddRow(4, now, now, nil, "lc0-revoked", nil, "anonymous", "", "", "", nil, "REVOKED", now, "CLIENT"))
+	if _, err := a.UnaryInterceptor(ctx, &pb.TaskRequest{}, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("call with revoked token code = %v, want PermissionDenied", status.Code(err))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet database expectations: %v", err)
+	}
+}
+
+func TestTokenAuthenticatorAdminScope(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	defer db.Close()
+	a := NewTokenAuthenticator(NewTokenCache(db))
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: pb.AdminService_ListTrainingRuns_FullMethodName}
+
+	now := time.Now()
+	for _, tc := range []struct {
+		token, scope string
+		want         codes.Code
+	}{
+		{"lc0-client", "CLIENT", codes.PermissionDenied},
+		{"lc0-admin", "ADMIN", codes.OK},
+	} {
+		mock.ExpectQuery("FROM auth_tokens").WithArgs(tc.token).WillReturnRows(
+			tokenRows().AddRow(5, now, now, nil, tc.token, nil, "manual", "", "", "", nil, "ACTIVE", nil, tc.scope))
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+tc.token))
+		_, err := a.UnaryInterceptor(ctx, &pb.ListTrainingRunsRequest{}, info, handler)
+		if status.Code(err) != tc.want {
+			t.Errorf("%s token calling AdminService code = %v, want %v", tc.scope, status.Code(err), tc.want)
+		}
+	}
+}
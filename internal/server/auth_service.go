@@ -11,6 +11,7 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	model "github.com/leelachesszero/lczero-server/internal/models"
 
@@ -27,17 +28,23 @@ import (
 type AuthServiceServer interface {
 	MigrateCredentials(ctx context.Context, req *pb.MigrateCredentialsRequest) (*pb.AuthResponse, error)
 	GetAnonymousToken(ctx context.Context, req *pb.AnonymousTokenRequest) (*pb.AuthResponse, error)
+	RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error)
+	ListTokens(ctx context.Context, req *pb.ListTokensRequest) (*pb.ListTokensResponse, error)
+	RotateToken(ctx context.Context, req *pb.RotateTokenRequest) (*pb.AuthResponse, error)
 }
 
 // AuthServiceImpl is the concrete implementation backed by Gorm and our models.
 type AuthServiceImpl struct {
 	pb.UnimplementedAuthServiceServer
 	DB *sql.DB
+
+	// Tokens is invalidated when a token is revoked.
+	Tokens *TokenCache
 }
 
 // generateUniqueToken generates a unique token with the prefix "lc0-" and 64 random characters.
 // It checks the DB to ensure the token does not already exist.
-func generateUniqueToken(db *sql.DB) (string, error) {
+func generateUniqueToken(db queries.Querier) (string, error) {
 	const (
 		prefix      = "lc0-"
 		tokenLen    = 64
@@ -64,9 +71,12 @@ func generateUniqueToken(db *sql.DB) (string, error) {
 	return "", errors.New("could not generate unique token after several attempts")
 }
 
+// ErrInvalidCredentials is returned when a username/password pair does not match.
+var ErrInvalidCredentials = status.Error(codes.Unauthenticated, "Invalid username or password")
+
 // NewAuthService creates a new AuthServiceImpl.
-func NewAuthService(dbConn *sql.DB) *AuthServiceImpl {
-	return &AuthServiceImpl{DB: dbConn}
+func NewAuthService(dbConn *sql.DB, tokens *TokenCache) *AuthServiceImpl {
+	return &AuthServiceImpl{DB: dbConn, Tokens: tokens}
 }
 
 // MigrateCredentials exchanges a legacy username/password for a token.
@@ -83,19 +93,21 @@ func (s *AuthServiceImpl) MigrateCredentials(ctx context.Context, req *pb.Migrat
 		return nil, status.Error(codes.InvalidArgument, "No password supplied")
 	}
 
-	// Look up user
-	user := &model.User{
-		Username: req.Username,
-		Password: req.Password,
-	}
+	// Look up user. Unknown users, deleted users and wrong passwords get the
+	// same answer after the same work, so usernames cannot be probed.
+	user := &model.User{}
 	row := s.DB.QueryRow(queries.SelectUserByUsername, req.Username)
 	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.AssignedTrainingRunID, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "User not found")
+			verifyLegacyPassword(dummyPassword, req.Password)
+			return nil, ErrInvalidCredentials
 		}
 		return nil, status.Error(codes.Internal, "Database error")
 	}
+	if !verifyLegacyPassword(user.Password, req.Password) || user.DeletedAt != nil {
+		return nil, ErrInvalidCredentials
+	}
 
 	tokenStr, err := generateUniqueToken(s.DB)
 	if err != nil {
@@ -148,3 +160,162 @@ func (s *AuthServiceImpl) GetAnonymousToken(ctx context.Context, req *pb.Anonymo
 	token.ID = tokenID
 	return &pb.AuthResponse{Token: token.Token}, nil
 }
+
+// tokenPrefixLen is how much of a token ListTokens reveals: "lc0-" and 8 hex digits.
+const tokenPrefixLen = 12
+
+// tokenStatusToProto converts a TokenStatus* value to its proto enum.
+func tokenStatusToProto(s string) pb.TokenStatus {
+	switch s {
+	case model.TokenStatusActive:
+		return pb.TokenStatus_TOKEN_ACTIVE
+	case model.TokenStatusRevoked:
+		return pb.TokenStatus_TOKEN_REVOKED
+	case model.TokenStatusSuspended:
+		return pb.TokenStatus_TOKEN_SUSPENDED
+	}
+	return pb.TokenStatus_TOKEN_STATUS_UNSPECIFIED
+}
+
+// tokenInfo describes a token without revealing the full token string.
+func tokenInfo(tok *model.AuthToken) *pb.TokenInfo {
+	info := &pb.TokenInfo{
+		Id:            uint64(tok.ID),
+		TokenPrefix:   tok.Token[:min(len(tok.Token), tokenPrefixLen)],
+		Status:        tokenStatusToProto(tok.Status),
+		IssuedReason:  tok.IssuedReason,
+		CreatedAt:     timestamppb.New(tok.CreatedAt),
+		ClientHost:    tok.ClientHost,
+		ClientVersion: tok.ClientVersion,
+	}
+	if tok.LastUsedAt != nil {
+		info.LastUsedAt = timestamppb.New(*tok.LastUsedAt)
+	}
+	return info
+}
+
+// ownsToken reports whether caller may manage tok: its own token, or any token
+// of the same migrated user.
+func ownsToken(caller, tok *model.AuthToken) bool {
+	if caller.ID == tok.ID {
+		return true
+	}
+	return caller.UserID != nil && tok.UserID != nil && *caller.UserID == *tok.UserID
+}
+
+// RevokeToken permanently disables a token owned by the caller.
+func (s *AuthServiceImpl) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error) {
+	caller, err := TokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := uint(req.GetTokenId())
+	if id == 0 {
+		id = caller.ID
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	tok, err := queries.FetchAuthTokenByIDForUpdate(tx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "Token not found")
+		}
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	if !ownsToken(caller, tok) {
+		return nil, status.Error(codes.PermissionDenied, "Token belongs to another user")
+	}
+	revoked, err := queries.RevokeAuthToken(tx, tok.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to revoke token")
+	}
+	if !revoked {
+		return nil, status.Error(codes.FailedPrecondition, "Token is not active")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	s.Tokens.Invalidate(tok.Token)
+	return &pb.RevokeTokenResponse{}, nil
+}
+
+// ListTokens lists the tokens of the caller's user. Anonymous callers only see
+// their own token.
+func (s *AuthServiceImpl) ListTokens(ctx context.Context, req *pb.ListTokensRequest) (*pb.ListTokensResponse, error) {
+	caller, err := TokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if caller.UserID == nil {
+		return &pb.ListTokensResponse{Tokens: []*pb.TokenInfo{tokenInfo(caller)}}, nil
+	}
+	toks, err := queries.FetchAuthTokensByUserID(s.DB, *caller.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	resp := &pb.ListTokensResponse{}
+	for i := range toks {
+		resp.Tokens = append(resp.Tokens, tokenInfo(&toks[i]))
+	}
+	return resp, nil
+}
+
+// RotateToken issues a new token with the caller's user and scope and revokes
+// the token used for the call, in one transaction.
+func (s *AuthServiceImpl) RotateToken(ctx context.Context, req *pb.RotateTokenRequest) (*pb.AuthResponse, error) {
+	caller, err := TokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	// Revoke first, so two concurrent rotations cannot both succeed
+	revoked, err := queries.RevokeAuthToken(tx, caller.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to revoke token")
+	}
+	if !revoked {
+		return nil, status.Error(codes.FailedPrecondition, "Token is not active")
+	}
+	tokenStr, err := generateUniqueToken(tx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to generate token")
+	}
+	var userID any
+	if caller.UserID != nil {
+		userID = *caller.UserID
+	}
+	var tokenID uint
+	// The new token keeps the scope of the old one, so rotating an admin token
+	// does not demote it
+	scope := caller.Scope
+	if scope == "" {
+		scope = model.TokenScopeClient
+	}
+	err = tx.QueryRow(
+		queries.InsertScopedAuthToken,
+		tokenStr,
+		model.TokenReasonRotated,
+		time.Now(),
+		userID,
+		scope,
+	).Scan(&tokenID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to insert token")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	s.Tokens.Invalidate(caller.Token)
+	return &pb.AuthResponse{Token: tokenStr}, nil
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	model "github.com/leelachesszero/lczero-server/internal/models"
+)
+
+var userColumns = []string{"id", "username", "password", "assigned_training_run_id", "created_at", "updated_at", "deleted_at"}
+
+func TestMigrateCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		found    bool
+		deleted  bool
+		wantCode codes.Code
+	}{
+		{"correct password", "hunter2", true, false, codes.OK},
+		{"wrong password", "hunter3", true, false, codes.Unauthenticated},
+		{"unknown user", "hunter2", false, false, codes.Unauthenticated},
+		{"deleted user", "hunter2", true, true, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows(userColumns)
+			if tt.found {
+				var deletedAt any
+				if tt.deleted {
+					deletedAt = time.Now()
+				}
+				rows.AddRow(7, "alice", "hunter2", 1, time.Now(), time.Now(), deletedAt)
+			}
+			mock.ExpectQuery("FROM users").WithArgs("alice").WillReturnRows(rows)
+			if tt.wantCode == codes.OK {
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery("INSERT INTO auth_tokens").
+					WithArgs(sqlmock.AnyArg(), "migrated_credentials", sqlmock.AnyArg(), 7).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			s := NewAuthService(db, NewTokenCache(db))
+			resp, err := s.MigrateCredentials(context.Background(), &pb.MigrateCredentialsRequest{
+				Username: "alice",
+				Password: tt.password,
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("MigrateCredentials code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && !strings.HasPrefix(resp.GetToken(), "lc0-") {
+				t.Errorf("MigrateCredentials token = %q, want lc0- prefix", resp.GetToken())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	alice, bob := uint(7), uint(8)
+	tests := []struct {
+		name     string
+		owner    *uint
+		active   bool
+		wantCode codes.Code
+	}{
+		{"same user", &alice, true, codes.OK},
+		{"other user", &bob, true, codes.PermissionDenied},
+		{"anonymous token", nil, true, codes.PermissionDenied},
+		{"already revoked", &alice, false, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			mock.ExpectQuery("FROM auth_tokens").WithArgs(2).WillReturnRows(
+				tokenRows().AddRow(2, now, now, tt.owner, "lc0-target", nil, "", "", "", "", nil, "ACTIVE", nil, "CLIENT"))
+			if tt.wantCode != codes.PermissionDenied {
+				affected := int64(0)
+				if tt.active {
+					affected = 1
+				}
+				mock.ExpectExec("UPDATE auth_tokens").WithArgs("REVOKED", 2, "ACTIVE").WillReturnResult(sqlmock.NewResult(0, affected))
+			}
+			if tt.wantCode == codes.OK {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			caller := &model.AuthToken{ID: 1, UserID: &alice, Token: testToken}
+			s := NewAuthService(db, NewTokenCache(db))
+			_, err = s.RevokeToken(ContextWithToken(context.Background(), caller), &pb.RevokeTokenRequest{TokenId: 2})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("RevokeToken code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRotateToken(t *testing.T) {
+	alice := uint(7)
+	tests := []struct {
+		name      string
+		scope     string
+		active    bool
+		wantScope string
+		wantCode  codes.Code
+	}{
+		{"client token", model.TokenScopeClient, true, model.TokenScopeClient, codes.OK},
+		{"admin token", model.TokenScopeAdmin, true, model.TokenScopeAdmin, codes.OK},
+		{"unscoped token", "", true, model.TokenScopeClient, codes.OK},
+		{"already revoked", model.TokenScopeAdmin, false, "", codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			affected := int64(0)
+			if tt.active {
+				affected = 1
+			}
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE auth_tokens").WithArgs("REVOKED", 1, "ACTIVE").WillReturnResult(sqlmock.NewResult(0, affected))
+			if tt.wantCode == codes.OK {
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery("INSERT INTO auth_tokens").
+					WithArgs(sqlmock.AnyArg(), model.TokenReasonRotated, sqlmock.AnyArg(), alice, tt.wantScope).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			caller := &model.AuthToken{ID: 1, UserID: &alice, Token: testToken, Scope: tt.scope}
+			s := NewAuthService(db, NewTokenCache(db))
+			resp, err := s.RotateToken(ContextWithToken(context.Background(), caller), &pb.RotateTokenRequest{})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("RotateToken code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if err == nil && (resp.GetToken() == "" || resp.GetToken() == testToken) {
+				t.Errorf("RotateToken returned token %q", resp.GetToken())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
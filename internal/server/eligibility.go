@@ -0,0 +1,199 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/leelachesszero/lczero-server/internal/config"
+)
+
+// parseVersion parses a dotted version such as "v0.31.0-rc1" or "25" into its
+// numeric components, ignoring a leading "v" and any pre-release or build suffix.
+func parseVersion(v string) ([]uint64, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return nil, false
+	}
+	parts := strings.Split(v, ".")
+	nums := make([]uint64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		nums[i] = n
+	}
+	return nums, true
+}
+
+// compareVersions returns -1, 0 or 1 as a is older than, the same as or newer
+// than b. Missing components count as 0, so "25" equals "25.0.0".
+func compareVersions(a, b []uint64) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionAtLeast reports whether version is min or newer. Unparsable versions
+// are never new enough; an empty min accepts everything.
+func versionAtLeast(version, min string) bool {
+	m, ok := parseVersion(min)
+	if !ok {
+		return true
+	}
+	v, ok := parseVersion(version)
+	return ok && compareVersions(v, m) >= 0
+}
+
+// VersionPolicy holds the client and engine versions clients must run. Below
+// the minimum versions they get no tasks; below the next versions they are
+// asked to upgrade by RequiredBy, after which the next versions are the
+// minimum. Client versions are major versions, so any 25.x client meets a
+// minimum of 25. Zero values disable a check.
+type VersionPolicy struct {
+	MinClient  uint64
+	NextClient uint64
+	MinEngine  string
+	NextEngine string
+
+	RequiredBy        time.Time
+	ClientDownloadURL string
+	EngineDownloadURL string
+}
+
+// NewVersionPolicy returns the versions of config.Config.Clients.
+func NewVersionPolicy() VersionPolicy {
+	c := config.Config.Clients
+	return VersionPolicy{
+		MinClient:         c.MinClientVersion,
+		NextClient:        c.NextClientVersion,
+		MinEngine:         c.MinEngineVersion,
+		NextEngine:        c.NextEngineVersion,
+		RequiredBy:        c.NextVersionRequiredBy,
+		ClientDownloadURL: c.ClientDownloadURL,
+		EngineDownloadURL: c.EngineDownloadURL,
+	}
+}
+
+// clientVersionString formats a client major version, or "" if it is not set.
+func clientVersionString(v uint64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatUint(v, 10)
+}
+
+// withURL appends where to download an upgrade from, if known.
+func withURL(msg, url string) string {
+	if url == "" {
+		return msg
+	}
+	return msg + " from " + url
+}
+
+// at returns the policy in force at now: once RequiredBy has passed, the next
+// versions are also the minimum versions.
+func (p VersionPolicy) at(now time.Time) VersionPolicy {
+	if p.RequiredBy.IsZero() || now.Before(p.RequiredBy) {
+		return p
+	}
+	p.MinClient = max(p.MinClient, p.NextClient)
+	if !versionAtLeast(p.MinEngine, p.NextEngine) {
+		p.MinEngine = p.NextEngine
+	}
+	return p
+}
+
+// check rejects clients older than the minimum client or engine version in
+// force at now, telling them what to upgrade to.
+func (p VersionPolicy) check(info *pb.ClientInfo, now time.Time) error {
+	p = p.at(now)
+	if !versionAtLeast(info.GetVersion(), clientVersionString(p.MinClient)) {
+		return status.Error(codes.FailedPrecondition, withURL(fmt.Sprintf(
+			"Client version %q is no longer supported, please upgrade the client to version %d or later", info.GetVersion(), p.MinClient), p.ClientDownloadURL))
+	}
+	if !versionAtLeast(info.GetEngineVersion(), p.MinEngine) {
+		return status.Error(codes.FailedPrecondition, withURL(fmt.Sprintf(
+			"Engine version %q is no longer supported, please upgrade lc0 to %s or later", info.GetEngineVersion(), p.MinEngine), p.EngineDownloadURL))
+	}
+	return nil
+}
+
+// atNext reports whether a client already runs the next versions or newer.
+func (p VersionPolicy) atNext(clientVersion, engineVersion string) bool {
+	return versionAtLeast(clientVersion, clientVersionString(p.NextClient)) && versionAtLeast(engineVersion, p.NextEngine)
+}
+
+// atMin reports whether a client runs the minimum versions or newer.
+func (p VersionPolicy) atMin(clientVersion, engineVersion string) bool {
+	return versionAtLeast(clientVersion, clientVersionString(p.MinClient)) && versionAtLeast(engineVersion, p.MinEngine)
+}
+
+// advisory returns the upgrade advisory for a client older than the next
+// versions, or nil if it is recent enough.
+func (p VersionPolicy) advisory(clientVersion, engineVersion string, now time.Time) *pb.UpgradeAdvisory {
+	a := &pb.UpgradeAdvisory{}
+	var upgrades []string
+	if !versionAtLeast(clientVersion, clientVersionString(p.NextClient)) {
+		a.ClientVersion = clientVersionString(p.NextClient)
+		a.ClientDownloadUrl = p.ClientDownloadURL
+		upgrades = append(upgrades, withURL("the client to version "+a.ClientVersion, p.ClientDownloadURL))
+	}
+	if !versionAtLeast(engineVersion, p.NextEngine) {
+		a.EngineVersion = p.NextEngine
+		a.EngineDownloadUrl = p.EngineDownloadURL
+		upgrades = append(upgrades, withURL("lc0 to "+p.NextEngine, p.EngineDownloadURL))
+	}
+	if len(upgrades) == 0 {
+		return nil
+	}
+	a.Message = "Please upgrade " + strings.Join(upgrades, " and ")
+	if !p.RequiredBy.IsZero() {
+		a.RequiredBy = timestamppb.New(p.RequiredBy)
+		if now.Before(p.RequiredBy) {
+			a.Message += ", older versions will stop getting tasks on " + p.RequiredBy.UTC().Format("2006-01-02")
+		} else {
+			a.Message += ", older versions no longer get tasks since " + p.RequiredBy.UTC().Format("2006-01-02")
+		}
+	}
+	return a
+}
+
+// supportsTaskType reports whether a client lists taskType in its supported
+// task types. Clients that list none predate the list and only run training
+// games and matches.
+func supportsTaskType(info *pb.ClientInfo, taskType pb.TaskType) bool {
+	if len(info.GetSupportedTaskTypes()) == 0 {
+		return taskType == pb.TaskType_TRAINING || taskType == pb.TaskType_MATCH
+	}
+	for _, t := range info.GetSupportedTaskTypes() {
+		if t == taskType {
+			return true
+		}
+	}
+	return false
+}
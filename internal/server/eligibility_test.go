@@ -0,0 +1,139 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+func TestVersionAtLeast(t *testing.T) {
+	for _, tc := range []struct {
+		version, min string
+		want         bool
+	}{
+		{"v0.31.0", "v0.24.0", true},
+		{"v0.24.0", "v0.24.0", true},
+		{"v0.23.3", "v0.24.0", false},
+		{"v0.31.0-rc1", "v0.31.0", true},
+		{"v0.9.0", "v0.24.0", false},
+		{"26", "25", true},
+		{"v25.2.1", "25", true},
+		{"v2.1.0", "25", false},
+		{"", "v0.24.0", false},
+		{"dev", "v0.24.0", false},
+		{"dev", "", true},
+	} {
+		if got := versionAtLeast(tc.version, tc.min); got != tc.want {
+			t.Errorf("versionAtLeast(%q, %q) = %v, want %v", tc.version, tc.min, got, tc.want)
+		}
+	}
+}
+
+func TestVersionPolicyCheck(t *testing.T) {
+	p := VersionPolicy{MinClient: 25, MinEngine: "v0.24.0"}
+	if err := p.check(&pb.ClientInfo{Version: "v25.0.0", EngineVersion: "v0.31.0"}, time.Now()); err != nil {
+		t.Errorf("up to date client rejected: %v", err)
+	}
+	for _, info := range []*pb.ClientInfo{
+		{Version: "v24.9.0", EngineVersion: "v0.31.0"},
+		{Version: "v25.0.0", EngineVersion: "v0.23.0"},
+		{EngineVersion: "v0.31.0"},
+	} {
+		if err := p.check(info, time.Now()); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("check(%v) = %v, want FailedPrecondition", info, err)
+		}
+	}
+	if err := (VersionPolicy{}).check(&pb.ClientInfo{}, time.Now()); err != nil {
+		t.Errorf("client rejected without minimum versions: %v", err)
+	}
+}
+
+func TestVersionPolicyAdvisory(t *testing.T) {
+	p := VersionPolicy{
+		MinClient: 25, NextClient: 26, MinEngine: "v0.30.0", NextEngine: "v0.31.0",
+		RequiredBy:        time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+		ClientDownloadURL: "https://example.org/client",
+	}
+	before, after := p.RequiredBy.Add(-time.Hour), p.RequiredBy.Add(time.Hour)
+	if a := p.advisory("v26.1.0", "v0.31.0", before); a != nil {
+		t.Errorf("advisory for an up to date client = %v, want nil", a)
+	}
+	a := p.advisory("v25.0.0", "v0.31.0", before)
+	if a == nil || a.ClientVersion != "26" || a.EngineVersion != "" || a.ClientDownloadUrl != "https://example.org/client" || !a.RequiredBy.AsTime().Equal(p.RequiredBy) {
+		t.Fatalf("advisory for an old client = %v, want client version 26 by 2026-12-01", a)
+	}
+	if a = p.advisory("v26.0.0", "v0.30.2", before); a == nil || a.EngineVersion != "v0.31.0" || a.ClientVersion != "" {
+		t.Errorf("advisory for an old engine = %v, want engine version v0.31.0", a)
+	}
+	if a = (VersionPolicy{MinClient: 25}).advisory("v25.0.0", "", before); a != nil {
+		t.Errorf("advisory without next versions = %v, want nil", a)
+	}
+	if a = p.advisory("v25.0.0", "v0.31.0", after); !strings.Contains(a.GetMessage(), "no longer get tasks since 2026-12-01") {
+		t.Errorf("advisory after the deadline = %q, want it to say older versions no longer get tasks", a.GetMessage())
+	}
+}
+
+func TestVersionPolicyRequiredBy(t *testing.T) {
+	p := VersionPolicy{
+		MinClient: 25, NextClient: 26, MinEngine: "v0.30.0", NextEngine: "v0.31.0",
+		RequiredBy: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name string
+		info *pb.ClientInfo
+		now  time.Time
+		want codes.Code
+	}{
+		{"old client before the deadline", &pb.ClientInfo{Version: "v25.0.0", EngineVersion: "v0.31.0"}, p.RequiredBy.Add(-time.Hour), codes.OK},
+		{"old client after the deadline", &pb.ClientInfo{Version: "v25.0.0", EngineVersion: "v0.31.0"}, p.RequiredBy, codes.FailedPrecondition},
+		{"old engine after the deadline", &pb.ClientInfo{Version: "v26.0.0", EngineVersion: "v0.30.0"}, p.RequiredBy, codes.FailedPrecondition},
+		{"next versions after the deadline", &pb.ClientInfo{Version: "v26.0.0", EngineVersion: "v0.31.0"}, p.RequiredBy, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(p.check(tt.info, tt.now)); got != tt.want {
+				t.Errorf("check = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientVersionReport(t *testing.T) {
+	p := VersionPolicy{MinClient: 25, NextClient: 26, MinEngine: "v0.30.0", NextEngine: "v0.31.0"}
+	report := clientVersionReport(p, []models.ClientVersionCount{
+		{ClientVersion: "v26.0.0", EngineVersion: "v0.31.0", Clients: 6},
+		{ClientVersion: "v25.3.0", EngineVersion: "v0.31.0", Clients: 3},
+		{ClientVersion: "v24.0.0", EngineVersion: "v0.29.0", Clients: 1},
+	})
+	if report.TotalClients != 10 || report.ClientsAtNext != 6 {
+		t.Errorf("report counts %d clients, %d at next, want 10 and 6", report.TotalClients, report.ClientsAtNext)
+	}
+	for i, want := range []struct{ belowMin, belowNext bool }{{false, false}, {false, true}, {true, true}} {
+		if v := report.Versions[i]; v.BelowMin != want.belowMin || v.BelowNext != want.belowNext {
+			t.Errorf("%s/%s: below min %v, below next %v, want %v, %v", v.ClientVersion, v.EngineVersion, v.BelowMin, v.BelowNext, want.belowMin, want.belowNext)
+		}
+	}
+}
+
+func TestSupportsTaskType(t *testing.T) {
+	sprtOnly := &pb.ClientInfo{SupportedTaskTypes: []pb.TaskType{pb.TaskType_SPRT}}
+	if !supportsTaskType(sprtOnly, pb.TaskType_SPRT) {
+		t.Error("SPRT client does not support SPRT")
+	}
+	for _, tt := range []pb.TaskType{pb.TaskType_TRAINING, pb.TaskType_MATCH, pb.TaskType_TUNING} {
+		if supportsTaskType(sprtOnly, tt) {
+			t.Errorf("SPRT client supports %v", tt)
+		}
+	}
+	legacy := &pb.ClientInfo{}
+	if !supportsTaskType(legacy, pb.TaskType_TRAINING) || !supportsTaskType(legacy, pb.TaskType_MATCH) || supportsTaskType(legacy, pb.TaskType_SPRT) {
+		t.Error("client without task types should support training and matches only")
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/config"
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/sprt"
+)
+
+// whiteResult converts a reported outcome to a match_games result.
+func whiteResult(outcome pb.ShortOutcome) (int, bool) {
+	switch outcome {
+	case pb.ShortOutcome_WHITE_WIN:
+		return models.MatchResultWhiteWin, true
+	case pb.ShortOutcome_BLACK_WIN:
+		return models.MatchResultBlackWin, true
+	case pb.ShortOutcome_DRAW:
+		return models.MatchResultDraw, true
+	}
+	return 0, false
+}
+
+// candidateResult returns the result of a game from the candidate's point of
+// view: 1 for a candidate win, -1 for a loss and 0 for a draw.
+func candidateResult(result int, flip bool) int {
+	if flip {
+		return -result
+	}
+	return result
+}
+
+// matchPassed decides whether a finished match promotes its candidate.
+func matchPassed(m *models.Match) bool {
+	_, elo, _, err := sprt.EloTrinomial([]int{m.Losses, m.Draws, m.Wins})
+	return err == nil && elo > config.Config.Matches.Threshold
+}
+
+// handleMatchProgress records the game of a match assignment in the
+// match_games row reserved in getNextMatchTask, scored with the colours stored
+// there. An assignment carries exactly one game; reports for a game or match
+// that already has its result are rejected. Recording the game completes the
+// assignment. Once every game up to game_cap
+// has a result the match is closed and, unless it is test-only, a passing
+// candidate becomes the best network of the training task of its run.
+func (s *TaskServiceImpl) handleMatchProgress(
+	ctx context.Context,
+	task *models.TaskAssignment,
+	progress *pb.MatchProgress,
+	now time.Time,
+) error {
+	games := progress.GetGames()
+	if len(games) == 0 {
+		return nil
+	}
+	if len(games) > 1 {
+		return status.Error(codes.InvalidArgument, "A match assignment carries a single game")
+	}
+	if task.MatchGameID == nil || task.ParentTaskID == nil {
+		return status.Error(codes.FailedPrecondition, "Task assignment is not linked to a match")
+	}
+	game := games[0]
+	result, ok := whiteResult(game.GetShortOutcome())
+	if !ok {
+		return status.Error(codes.InvalidArgument, "Match game has no outcome")
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	reserved, err := queries.FetchMatchGameByID(tx, *task.MatchGameID)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to load match game")
+	}
+	m, err := queries.FetchMatchForUpdate(tx, reserved.MatchID)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to load match")
+	}
+	if reserved.Done || m.Done {
+		return status.Error(codes.FailedPrecondition, "Match game already has a result")
+	}
+	// Flip means the candidate plays black
+	if game.GetCandidateIsWhite() == reserved.Flip {
+		return status.Error(codes.InvalidArgument, "Match game was played with the wrong colours")
+	}
+
+	mg := &models.MatchGame{
+		ID:            reserved.ID,
+		Pgn:           game.GetPgn(),
+		Result:        result,
+		Flip:          reserved.Flip,
+		Version:       parseClientVersion(task.ClientVersion),
+		EngineVersion: task.EngineVersion,
+	}
+	if err := queries.UpdateMatchGameResult(tx, mg); err != nil {
+		return status.Error(codes.Internal, "Failed to update match game")
+	}
+	switch candidateResult(mg.Result, mg.Flip) {
+	case 1:
+		m.Wins++
+	case -1:
+		m.Losses++
+	default:
+		m.Draws++
+	}
+
+	if m.Wins+m.Losses+m.Draws >= m.GameCap {
+		m.Done = true
+		m.Passed = matchPassed(m)
+		if m.Passed && !m.TestOnly {
+			tr, err := queries.FetchTrainingTaskByTaskID(tx, *task.ParentTaskID)
+			if err != nil {
+				return status.Error(codes.Internal, "Failed to load training task")
+			}
+			// Only the run the match belongs to moves on to its candidate
+			if tr.TrainingRunID != nil && *tr.TrainingRunID == m.TrainingRunID {
+				if err := queries.UpdateTrainingTaskBestNetwork(tx, tr.ID, m.CandidateID); err != nil {
+					return status.Error(codes.Internal, "Failed to promote network")
+				}
+			}
+		}
+	}
+	if err := queries.UpdateMatchResults(tx, m); err != nil {
+		return status.Error(codes.Internal, "Failed to update match")
+	}
+	if err := queries.AddTaskAssignmentGames(tx, task.ID, 1); err != nil {
+		return status.Error(codes.Internal, "Failed to count task games")
+	}
+	if _, err := queries.CompleteTaskAssignment(tx, task.ID, now); err != nil {
+		return status.Error(codes.Internal, "Failed to complete task")
+	}
+	if err := tx.Commit(); err != nil {
+		return status.Error(codes.Internal, "Database error")
+	}
+	task.Status = models.TaskStatusDone
+	task.CompletedAt = &now
+	return nil
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+var (
+	matchGameColumns = []string{"id", "created_at", "user_id", "match_id", "done", "flip"}
+	matchColumns     = []string{"id", "created_at", "training_run_id", "candidate_id", "current_best_id", "games_created", "wins", "losses", "draws", "game_cap", "done", "passed", "test_only", "special_params", "target_slice"}
+)
+
+func TestHandleMatchProgress(t *testing.T) {
+	now := time.Now()
+	candidateWin := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_BLACK_WIN, CandidateIsWhite: false}
+	tests := []struct {
+		name      string
+		games     []*pb.MatchGame
+		gameDone  bool
+		matchDone bool
+		wantCode  codes.Code
+	}{
+		{"reserved game", []*pb.MatchGame{candidateWin}, false, false, codes.OK},
+		{"extra games", []*pb.MatchGame{candidateWin, candidateWin}, false, false, codes.InvalidArgument},
+		{"wrong colours", []*pb.MatchGame{{ShortOutcome: pb.ShortOutcome_WHITE_WIN, CandidateIsWhite: true}}, false, false, codes.InvalidArgument},
+		{"game already reported", []*pb.MatchGame{candidateWin}, true, false, codes.FailedPrecondition},
+		{"match done", []*pb.MatchGame{candidateWin}, false, true, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			if len(tt.games) == 1 {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM match_games").WithArgs(5).WillReturnRows(
+					sqlmock.NewRows(matchGameColumns).AddRow(5, time.Now(), 7, 2, tt.gameDone, true))
+				mock.ExpectQuery("FROM matches").WithArgs(2).WillReturnRows(
+					sqlmock.NewRows(matchColumns).AddRow(2, time.Now(), 1, 11, 10, 4, 1, 1, 0, 10, tt.matchDone, false, false, false, 0))
+				if tt.wantCode == codes.OK {
+					mock.ExpectExec("UPDATE match_games").WithArgs("", models.MatchResultBlackWin, true, sqlmock.AnyArg(), "", 5).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					// The candidate won with black
+					mock.ExpectExec("UPDATE matches").WithArgs(4, 2, 1, 0, false, false, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec("SET games_reported").WithArgs(1, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec("UPDATE task_assignments").WithArgs(models.TaskStatusDone, now, 9, models.TaskStatusActive).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			gameID, parent := uint64(5), uint(3)
+			task := &models.TaskAssignment{ID: 9, MatchGameID: &gameID, ParentTaskID: &parent, Status: models.TaskStatusActive}
+			s := &TaskServiceImpl{DB: db}
+			err = s.handleMatchProgress(context.Background(), task, &pb.MatchProgress{Games: tt.games}, now)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("handleMatchProgress code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if done := task.Status == models.TaskStatusDone; done != (tt.wantCode == codes.OK) {
+				t.Errorf("task status = %s after code %v", task.Status, tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestHandleMatchProgressPromotion(t *testing.T) {
+	now := time.Now()
+	candidateWin := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_BLACK_WIN, CandidateIsWhite: false}
+	tests := []struct {
+		name        string
+		taskRun     any // training_run_id of the assignment's training task
+		wantPromote bool
+	}{
+		{"match of the task's run", 1, true},
+		{"match of another run", 8, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("FROM match_games").WithArgs(5).WillReturnRows(
+				sqlmock.NewRows(matchGameColumns).AddRow(5, now, 7, 2, false, true))
+			// The last game of a match the candidate has won so far
+			mock.ExpectQuery("FROM matches").WithArgs(2).WillReturnRows(
+				sqlmock.NewRows(matchColumns).AddRow(2, now, 1, 11, 10, 10, 9, 0, 0, 10, false, false, false, false, 0))
+			mock.ExpectExec("UPDATE match_games").WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery("FROM training_tasks").WithArgs(3).WillReturnRows(
+				sqlmock.NewRows(trainingTaskColumns).AddRow(20, now, now, 3, tt.taskRun, 0, 0, 10, "", "", true, ""))
+			if tt.wantPromote {
+				mock.ExpectExec("UPDATE training_tasks").WithArgs(11, 20).WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectExec("UPDATE matches").WithArgs(10, 10, 0, 0, true, true, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("SET games_reported").WithArgs(1, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("UPDATE task_assignments").WithArgs(models.TaskStatusDone, now, 9, models.TaskStatusActive).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			gameID, parent := uint64(5), uint(3)
+			task := &models.TaskAssignment{ID: 9, MatchGameID: &gameID, ParentTaskID: &parent, Status: models.TaskStatusActive}
+			s := &TaskServiceImpl{DB: db}
+			if err := s.handleMatchProgress(context.Background(), task, &pb.MatchProgress{Games: []*pb.MatchGame{candidateWin}}, now); err != nil {
+				t.Fatalf("handleMatchProgress returned error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+// dummyPassword is verified against when a username is unknown, so that
+// unknown users take as long to reject as wrong passwords.
+const dummyPassword = "lczero-no-such-user"
+
+// verifyLegacyPassword checks a password supplied by a client against the
+// plaintext value stored in the legacy users table. Both are hashed before the
+// constant time comparison, so its duration depends on neither length.
+func verifyLegacyPassword(stored, supplied string) bool {
+	storedSum := sha256.Sum256([]byte(stored))
+	suppliedSum := sha256.Sum256([]byte(supplied))
+	match := subtle.ConstantTimeCompare(storedSum[:], suppliedSum[:]) == 1
+	return match && stored != "" && supplied != ""
+}
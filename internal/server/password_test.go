@@ -0,0 +1,28 @@
+package server
+
+import (
+	"testing"
+)
+
+func TestVerifyLegacyPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		stored   string
+		supplied string
+		want     bool
+	}{
+		{"plaintext match", "hunter2", "hunter2", true},
+		{"plaintext mismatch", "hunter2", "hunter3", false},
+		{"plaintext prefix", "hunter2", "hunter", false},
+		{"plaintext case", "hunter2", "HUNTER2", false},
+		{"empty stored", "", "", false},
+		{"empty supplied", "hunter2", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyLegacyPassword(tt.stored, tt.supplied); got != tt.want {
+				t.Errorf("verifyLegacyPassword(%q, %q) = %v, want %v", tt.stored, tt.supplied, got, tt.want)
+			}
+		})
+	}
+}
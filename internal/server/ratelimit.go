@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"net"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/config"
+	"github.com/leelachesszero/lczero-server/internal/ratelimit"
+)
+
+const (
+	defaultPerIPPerMinute       = 10
+	defaultPerIPBurst           = 20
+	defaultPerUsernamePerMinute = 5
+	defaultPerUsernameBurst     = 5
+	defaultMaxFailedMigrations  = 5
+	defaultLockout              = 15 * time.Minute
+)
+
+// ErrRateLimited is returned when a caller issues token requests too quickly.
+var ErrRateLimited = status.Error(codes.ResourceExhausted, "Too many requests, try again later")
+
+// ErrLockedOut is returned while a username is locked after failed migrations.
+var ErrLockedOut = status.Error(codes.ResourceExhausted, "Too many failed attempts, try again later")
+
+// AuthRateLimiter limits AuthService calls per peer IP and, for
+// MigrateCredentials, per username, and locks a username out after repeated
+// failed migrations. Other services pass through untouched.
+type AuthRateLimiter struct {
+	PerIP       ratelimit.Limiter
+	PerUsername ratelimit.Limiter
+	Lockout     ratelimit.Lockout
+
+	now func() time.Time
+}
+
+// NewAuthRateLimiter creates an AuthRateLimiter from config.Config.RateLimit.
+func NewAuthRateLimiter() *AuthRateLimiter {
+	c := config.Config.RateLimit
+	lockout := time.Duration(c.LockoutSeconds) * time.Second
+	if lockout <= 0 {
+		lockout = defaultLockout
+	}
+	return &AuthRateLimiter{
+		PerIP: ratelimit.NewTokenBucket(
+			orDefault(c.PerIPPerMinute, defaultPerIPPerMinute),
+			orDefault(c.PerIPBurst, defaultPerIPBurst)),
+		PerUsername: ratelimit.NewTokenBucket(
+			orDefault(c.PerUsernamePerMinute, defaultPerUsernamePerMinute),
+			orDefault(c.PerUsernameBurst, defaultPerUsernameBurst)),
+		Lockout: ratelimit.NewFailureLockout(orDefault(c.MaxFailedMigrations, defaultMaxFailedMigrations), lockout),
+		now:     time.Now,
+	}
+}
+
+// orDefault returns v, or def when v is not set.
+func orDefault(v, def int) int {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
+
+// UnaryInterceptor is a grpc.UnaryServerInterceptor applying the limits.
+func (l *AuthRateLimiter) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	switch info.FullMethod {
+	case pb.AuthService_GetAnonymousToken_FullMethodName, pb.AuthService_MigrateCredentials_FullMethodName:
+	default:
+		return handler(ctx, req)
+	}
+
+	now := l.now()
+	if !l.PerIP.Allow(peerIP(ctx), now) {
+		return nil, ErrRateLimited
+	}
+	migrate, ok := req.(*pb.MigrateCredentialsRequest)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	username := migrate.GetUsername()
+	if l.Lockout.Locked(username, now) {
+		return nil, ErrLockedOut
+	}
+	if !l.PerUsername.Allow(username, now) {
+		return nil, ErrRateLimited
+	}
+	resp, err := handler(ctx, req)
+	switch status.Code(err) {
+	case codes.OK:
+		l.Lockout.Succeed(username)
+	case codes.Unauthenticated:
+		l.Lockout.Fail(username, now)
+	}
+	return resp, err
+}
+
+// peerIP returns the IP address of the caller, or "" when it is unknown.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/ratelimit"
+)
+
+func newTestRateLimiter() *AuthRateLimiter {
+	now := time.Unix(1_700_000_000, 0)
+	return &AuthRateLimiter{
+		PerIP:       ratelimit.NewTokenBucket(1, 3),
+		PerUsername: ratelimit.NewTokenBucket(1, 10),
+		Lockout:     ratelimit.NewFailureLockout(2, time.Minute),
+		now:         func() time.Time { return now },
+	}
+}
+
+func peerContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000},
+	})
+}
+
+func TestAuthRateLimiterPerIP(t *testing.T) {
+	l := newTestRateLimiter()
+	info := &grpc.UnaryServerInfo{FullMethod: pb.AuthService_GetAnonymousToken_FullMethodName}
+	ok := func(ctx context.Context, req any) (any, error) { return &pb.AuthResponse{}, nil }
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.UnaryInterceptor(peerContext("192.0.2.1"), &pb.AnonymousTokenRequest{}, info, ok); err != nil {
+			t.Fatalf("call #%d within burst: %v", i+1, err)
+		}
+	}
+	_, err := l.UnaryInterceptor(peerContext("192.0.2.1"), &pb.AnonymousTokenRequest{}, info, ok)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call past burst code = %v, want ResourceExhausted", status.Code(err))
+	}
+	if _, err := l.UnaryInterceptor(peerContext("192.0.2.2"), &pb.AnonymousTokenRequest{}, info, ok); err != nil {
+		t.Fatalf("call from another IP: %v", err)
+	}
+
+	// Other services are not limited
+	info = &grpc.UnaryServerInfo{FullMethod: pb.TaskService_GetNextTask_FullMethodName}
+	if _, err := l.UnaryInterceptor(peerContext("192.0.2.1"), &pb.TaskRequest{}, info, ok); err != nil {
+		t.Fatalf("TaskService call: %v", err)
+	}
+}
+
+func TestAuthRateLimiterLockout(t *testing.T) {
+	l := newTestRateLimiter()
+	l.PerIP = ratelimit.NewTokenBucket(1, 100)
+	info := &grpc.UnaryServerInfo{FullMethod: pb.AuthService_MigrateCredentials_FullMethodName}
+	calls := 0
+	wrong := func(ctx context.Context, req any) (any, error) {
+		calls++
+		return nil, ErrInvalidCredentials
+	}
+	req := &pb.MigrateCredentialsRequest{Username: "alice", Password: "guess"}
+
+	for i := 0; i < 2; i++ {
+		_, err := l.UnaryInterceptor(peerContext("192.0.2.1"), req, info, wrong)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("failed attempt #%d code = %v, want Unauthenticated", i+1, status.Code(err))
+		}
+	}
+	_, err := l.UnaryInterceptor(peerContext("192.0.2.1"), req, info, wrong)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("attempt after lockout code = %v, want ResourceExhausted", status.Code(err))
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+
+	// Other usernames are unaffected
+	other := &pb.MigrateCredentialsRequest{Username: "bob", Password: "guess"}
+	if _, err := l.UnaryInterceptor(peerContext("192.0.2.1"), other, info, wrong); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("other username code = %v, want Unauthenticated", status.Code(err))
+	}
+}
+
+// recordingLockout is a ratelimit.Lockout that records the outcomes it is told.
+type recordingLockout struct {
+	locked    bool
+	failed    []string
+	succeeded []string
+}
+
+func (r *recordingLockout) Locked(key string, now time.Time) bool { return r.locked }
+func (r *recordingLockout) Fail(key string, now time.Time)        { r.failed = append(r.failed, key) }
+func (r *recordingLockout) Succeed(key string)                    { r.succeeded = append(r.succeeded, key) }
+
+func TestAuthRateLimiterLockoutOutcomes(t *testing.T) {
+	tests := []struct {
+		name          string
+		locked        bool
+		err           error
+		wantCode      codes.Code
+		wantFailed    int
+		wantSucceeded int
+	}{
+		{"success", false, nil, codes.OK, 0, 1},
+		{"wrong password", false, ErrInvalidCredentials, codes.Unauthenticated, 1, 0},
+		{"server error", false, status.Error(codes.Internal, "Database error"), codes.Internal, 0, 0},
+		{"locked out", true, nil, codes.ResourceExhausted, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockout := &recordingLockout{locked: tt.locked}
+			l := newTestRateLimiter()
+			l.Lockout = lockout
+			info := &grpc.UnaryServerInfo{FullMethod: pb.AuthService_MigrateCredentials_FullMethodName}
+			handler := func(ctx context.Context, req any) (any, error) { return nil, tt.err }
+			req := &pb.MigrateCredentialsRequest{Username: "alice", Password: "secret"}
+
+			_, err := l.UnaryInterceptor(peerContext("192.0.2.1"), req, info, handler)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("UnaryInterceptor code = %v, want %v", got, tt.wantCode)
+			}
+			if len(lockout.failed) != tt.wantFailed || len(lockout.succeeded) != tt.wantSucceeded {
+				t.Errorf("lockout got %d failures and %d successes, want %d and %d",
+					len(lockout.failed), len(lockout.succeeded), tt.wantFailed, tt.wantSucceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/leelachesszero/lczero-server/internal/config"
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+const (
+	defaultHeartbeatTimeout = 15 * time.Minute
+	defaultReapInterval     = time.Minute
+
+	reasonMissedHeartbeats = "missed heartbeats"
+)
+
+// Reaper periodically expires task assignments whose client stopped sending
+// heartbeats, so the work they reserved can be handed to someone else.
+type Reaper struct {
+	DB       *sql.DB
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+// NewReaper creates a Reaper using the timeouts in config.Config.Tasks.
+func NewReaper(dbConn *sql.DB) *Reaper {
+	r := &Reaper{
+		DB:       dbConn,
+		Timeout:  time.Duration(config.Config.Tasks.HeartbeatTimeoutSeconds) * time.Second,
+		Interval: time.Duration(config.Config.Tasks.ReapIntervalSeconds) * time.Second,
+	}
+	if r.Timeout <= 0 {
+		r.Timeout = defaultHeartbeatTimeout
+	}
+	if r.Interval <= 0 {
+		r.Interval = defaultReapInterval
+	}
+	return r
+}
+
+// Run reaps stale assignments every Interval until ctx is done.
+func (r *Reaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := r.Reap(ctx, now); err != nil {
+				log.Printf("Failed to reap stale task assignments: %v", err)
+			}
+		}
+	}
+}
+
+// Reap expires every ACTIVE assignment without a heartbeat since now-Timeout
+// and returns how many were expired. Unplayed match games reserved by an
+// expired MATCH assignment are released back to their match, and parameter
+// sets held by an expired TUNING assignment can be claimed again. Training and
+// SPRT assignments reserve nothing up front, so expiring them is enough.
+func (r *Reaper) Reap(ctx context.Context, now time.Time) (int, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	expired, err := queries.ExpireStaleTaskAssignments(tx, now.Add(-r.Timeout), reasonMissedHeartbeats)
+	if err != nil {
+		return 0, err
+	}
+	released, releasedSets := 0, 0
+	for _, t := range expired {
+		switch {
+		case t.TaskType == models.TaskTypeMatch && t.MatchGameID != nil:
+			ok, err := queries.ReleaseMatchGame(tx, *t.MatchGameID)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				released++
+			}
+		case t.TaskType == models.TaskTypeTuning:
+			n, err := queries.ReleaseTuneParamSets(tx, t.ID)
+			if err != nil {
+				return 0, err
+			}
+			releasedSets += n
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	if len(expired) > 0 {
+		log.Printf("Expired %d stale task assignments, released %d match games and %d parameter sets", len(expired), released, releasedSets)
+	}
+	return len(expired), nil
+}
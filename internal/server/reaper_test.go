@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+var expiredColumns = []string{"id", "task_id", "task_type", "match_game_id"}
+
+func TestReap(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		expired     [][]any
+		released    []int64 // rows affected per match game release, in order
+		sets        []int64 // parameter sets released per tuning assignment, in order
+		releaseErr  error
+		wantExpired int
+		wantErr     bool
+	}{
+		{"nothing stale", nil, nil, nil, nil, 0, false},
+		{
+			"match game released",
+			[][]any{{1, "a", models.TaskTypeMatch, 5}, {2, "b", models.TaskTypeTraining, nil}},
+			[]int64{1}, nil, nil, 2, false,
+		},
+		{
+			"finished match game kept",
+			[][]any{{1, "a", models.TaskTypeMatch, 5}},
+			[]int64{0}, nil, nil, 1, false,
+		},
+		{
+			"match without game",
+			[][]any{{1, "a", models.TaskTypeMatch, nil}, {2, "b", models.TaskTypeSprt, nil}},
+			nil, nil, nil, 2, false,
+		},
+		{
+			"tuning sets released",
+			[][]any{{3, "c", models.TaskTypeTuning, nil}, {4, "d", models.TaskTypeTuning, nil}},
+			nil, []int64{2, 0}, nil, 2, false,
+		},
+		{
+			"release fails",
+			[][]any{{1, "a", models.TaskTypeMatch, 5}},
+			nil, nil, errors.New("boom"), 0, true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows(expiredColumns)
+			for _, r := range tt.expired {
+				rows.AddRow(r[0], r[1], r[2], r[3])
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery("UPDATE task_assignments").
+				WithArgs(models.TaskStatusExpired, reasonMissedHeartbeats, models.TaskStatusActive, now.Add(-time.Minute)).
+				WillReturnRows(rows)
+			for _, n := range tt.released {
+				mock.ExpectExec("UPDATE matches").WithArgs(5).WillReturnResult(sqlmock.NewResult(0, n))
+			}
+			for i, n := range tt.sets {
+				mock.ExpectExec("UPDATE tune_param_sets").WithArgs(3 + i).WillReturnResult(sqlmock.NewResult(0, n))
+			}
+			if tt.releaseErr != nil {
+				mock.ExpectExec("UPDATE matches").WithArgs(5).WillReturnError(tt.releaseErr)
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			r := &Reaper{DB: db, Timeout: time.Minute, Interval: time.Minute}
+			got, err := r.Reap(context.Background(), now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reap error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantExpired {
+				t.Errorf("Reap = %d, want %d", got, tt.wantExpired)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+// networkResource describes a network download.
+func networkResource(net *models.Network) *pb.ResourceSpec {
+	return &pb.ResourceSpec{
+		Sha256:    net.Sha,
+		Url:       "",
+		SizeBytes: 0,
+		Type:      pb.ResourceType_NETWORK,
+		Format:    "",
+	}
+}
+
+// bookResource describes an opening book download.
+func bookResource(book *models.Book) *pb.ResourceSpec {
+	format := book.Format
+	if format == "" {
+		format = "pgn"
+	}
+	return &pb.ResourceSpec{
+		Sha256:    book.Sha256,
+		Url:       book.URL,
+		SizeBytes: book.SizeBytes,
+		Type:      pb.ResourceType_BOOK,
+		Format:    format,
+	}
+}
+
+// engineParams decodes engine parameters stored as a JSON-encoded []string of
+// arguments and a JSON-encoded map[string]string of UCI options. Empty columns
+// decode to no parameters.
+func engineParams(argsJSON, uciOptionsJSON string) (*pb.EngineParams, error) {
+	params := &pb.EngineParams{
+		Args:       []string{},
+		UciOptions: map[string]string{},
+	}
+	if argsJSON != "" {
+		if err := json.Unmarshal([]byte(argsJSON), &params.Args); err != nil {
+			return nil, fmt.Errorf("decoding engine args: %w", err)
+		}
+	}
+	if uciOptionsJSON != "" {
+		if err := json.Unmarshal([]byte(uciOptionsJSON), &params.UciOptions); err != nil {
+			return nil, fmt.Errorf("decoding UCI options: %w", err)
+		}
+	}
+	return params, nil
+}
+
+// timeControl builds the TimeControl oneof from the time control columns shared
+// by SPRT and tuning tasks.
+func timeControl(controlType string, baseTimeSeconds, incrementSeconds float64, nodesPerMove int64) (*pb.TimeControl, error) {
+	switch controlType {
+	case models.TimeControlTimeBased:
+		return &pb.TimeControl{
+			Control: &pb.TimeControl_TimeBased_{
+				TimeBased: &pb.TimeControl_TimeBased{
+					BaseTimeSeconds:  float32(baseTimeSeconds),
+					IncrementSeconds: float32(incrementSeconds),
+				},
+			},
+		}, nil
+	case models.TimeControlNodesPerMove:
+		return &pb.TimeControl{
+			Control: &pb.TimeControl_NodesPerMove{NodesPerMove: nodesPerMove},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown time control type %q", controlType)
+}
+
+// timeControlColumns is the inverse of timeControl: it splits a TimeControl
+// into the time control columns of SPRT and tuning tasks.
+func timeControlColumns(tc *pb.TimeControl) (controlType string, baseTimeSeconds, incrementSeconds float64, nodesPerMove int64, err error) {
+	switch c := tc.GetControl().(type) {
+	case *pb.TimeControl_TimeBased_:
+		if c.TimeBased.GetBaseTimeSeconds() <= 0 || c.TimeBased.GetIncrementSeconds() < 0 {
+			return "", 0, 0, 0, fmt.Errorf("time control needs a positive base time and a non-negative increment")
+		}
+		return models.TimeControlTimeBased, float64(c.TimeBased.GetBaseTimeSeconds()), float64(c.TimeBased.GetIncrementSeconds()), 0, nil
+	case *pb.TimeControl_NodesPerMove:
+		if c.NodesPerMove <= 0 {
+			return "", 0, 0, 0, fmt.Errorf("time control needs a positive number of nodes per move")
+		}
+		return models.TimeControlNodesPerMove, 0, 0, c.NodesPerMove, nil
+	}
+	return "", 0, 0, 0, fmt.Errorf("no time control supplied")
+}
+
+// encodeEngineParams is the inverse of engineParams: it encodes engine
+// parameters as the JSON columns they are stored in. Missing parts encode to
+// empty columns.
+func encodeEngineParams(params *pb.EngineParams) (argsJSON, uciOptionsJSON string, err error) {
+	if len(params.GetArgs()) > 0 {
+		b, err := json.Marshal(params.GetArgs())
+		if err != nil {
+			return "", "", err
+		}
+		argsJSON = string(b)
+	}
+	if len(params.GetUciOptions()) > 0 {
+		b, err := json.Marshal(params.GetUciOptions())
+		if err != nil {
+			return "", "", err
+		}
+		uciOptionsJSON = string(b)
+	}
+	return argsJSON, uciOptionsJSON, nil
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"log"
+	"math"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/sprt"
+)
+
+// candidateHalfPoints returns the candidate's score in a game in half points
+// (0 = loss, 1 = draw, 2 = win).
+func candidateHalfPoints(game *pb.MatchGame) (int, bool) {
+	result, ok := whiteResult(game.GetShortOutcome())
+	if !ok {
+		return 0, false
+	}
+	return candidateResult(result, !game.GetCandidateIsWhite()) + 1, true
+}
+
+// pairIndex returns the pentanomial bucket of a game pair, from 0 (LL) to 4
+// (WW). Pairs with a missing or undecided game cannot be classified.
+func pairIndex(game1, game2 *pb.MatchGame) (int, bool) {
+	if game1 == nil || game2 == nil {
+		return 0, false
+	}
+	p1, ok1 := candidateHalfPoints(game1)
+	p2, ok2 := candidateHalfPoints(game2)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return p1 + p2, true
+}
+
+// pentanomial returns the pair counts of an SPRT task as [LL, LD, DD, DW, WW].
+func pentanomial(st *models.SprtTask) []int {
+	return []int{st.LL, st.LD, st.DD, st.DW, st.WW}
+}
+
+// setPentanomial stores [LL, LD, DD, DW, WW] pair counts in an SPRT task.
+func setPentanomial(st *models.SprtTask, counts []int) {
+	st.LL, st.LD, st.DD, st.DW, st.WW = counts[0], counts[1], counts[2], counts[3], counts[4]
+}
+
+// unpairedTrinomial returns the unpaired game counts of an SPRT task as [L, D, W].
+func unpairedTrinomial(st *models.SprtTask) []int {
+	return []int{st.UnpairedLosses, st.UnpairedDraws, st.UnpairedWins}
+}
+
+// sprtTest returns the hypotheses and error rates of an SPRT task.
+func sprtTest(st *models.SprtTask) sprt.Test {
+	return sprt.Test{Elo0: st.Elo0, Elo1: st.Elo1, Alpha: st.Alpha, Beta: st.Beta}
+}
+
+// foldSprtPairs adds reported game pairs to the counters of an SPRT task and
+// returns how many games it counted. A pair is counted once both of its games
+// are decided. A pair whose second game never ran counts its first game as an
+// unpaired trinomial result. A pair whose second game is still running holds
+// its first game, given and returned as held, until a later report sends the
+// second game alone; a held game that is never completed that way is counted
+// unpaired when the next game is held. Undecided first games are not counted.
+func foldSprtPairs(st *models.SprtTask, pairs []*pb.SprtPairReport, held *int) (int, *int) {
+	counts := pentanomial(st)
+	games := 0
+	addUnpaired := func(points int) {
+		switch points {
+		case 0:
+			st.UnpairedLosses++
+		case 1:
+			st.UnpairedDraws++
+		case 2:
+			st.UnpairedWins++
+		}
+		games++
+	}
+	for _, pair := range pairs {
+		game1, game2 := pair.GetGame1(), pair.GetGame2()
+		if game1 == nil {
+			// The second game of the held pair.
+			if points, ok := candidateHalfPoints(game2); ok && held != nil {
+				counts[*held+points]++
+				games += 2
+				held = nil
+			}
+			continue
+		}
+		if idx, ok := pairIndex(game1, game2); ok {
+			counts[idx]++
+			games += 2
+			continue
+		}
+		points, ok := candidateHalfPoints(game1)
+		if !ok {
+			continue
+		}
+		if game2 == nil {
+			addUnpaired(points)
+			continue
+		}
+		if held != nil {
+			addUnpaired(*held)
+		}
+		held = &points
+	}
+	setPentanomial(st, counts)
+	return games, held
+}
+
+// updateSprtVerdict recomputes the LLR of an SPRT task from its counters and
+// sets its verdict once the LLR crosses a Wald bound. If the LLR cannot be
+// computed the previous one is kept and the test continues.
+func updateSprtVerdict(st *models.SprtTask) sprt.State {
+	test := sprtTest(st)
+	llr, err := test.LLR(pentanomial(st), unpairedTrinomial(st))
+	if err != nil {
+		log.Printf("sprt task %d: computing LLR for %v/%v: %v", st.ID, pentanomial(st), unpairedTrinomial(st), err)
+		return sprt.Continue
+	}
+	st.LLR = llr
+	state := test.State(llr)
+	switch state {
+	case sprt.Passed:
+		st.Verdict = models.SprtVerdictAccepted
+	case sprt.Failed:
+		st.Verdict = models.SprtVerdictRejected
+	}
+	return state
+}
+
+// handleSprtProgress folds reported game pairs into the counters of the SPRT
+// task and recomputes the LLR. Reports are numbered by seq, and a report whose
+// number was counted already for the assignment, i.e. a retry, is ignored.
+// When the LLR crosses a Wald bound the verdict is stored and the base task is
+// marked DONE, which cancels every assignment still working on it.
+func (s *TaskServiceImpl) handleSprtProgress(
+	ctx context.Context,
+	task *models.TaskAssignment,
+	progress *pb.SprtProgress,
+	seq uint64,
+) error {
+	pairs := progress.GetPairs()
+	if len(pairs) == 0 {
+		return nil
+	}
+	if task.ParentTaskID == nil {
+		return status.Error(codes.FailedPrecondition, "Task assignment is not linked to an SPRT task")
+	}
+	if seq == 0 {
+		return status.Error(codes.InvalidArgument, "SPRT progress needs a sequence number")
+	}
+	clientLLR := progress.GetCurrentLlr()
+	if math.IsNaN(clientLLR) || math.IsInf(clientLLR, 0) {
+		return status.Error(codes.InvalidArgument, "Invalid LLR")
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	st, err := queries.FetchSprtTaskByTaskIDForUpdate(tx, *task.ParentTaskID)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to load SPRT task")
+	}
+	if st.Verdict != "" {
+		// Results arriving after the verdict do not change it.
+		return nil
+	}
+	fresh, err := queries.AdvanceTaskAssignmentSequence(tx, task.ID, seq)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to record report sequence")
+	}
+	if !fresh {
+		return nil
+	}
+
+	held, err := queries.FetchHeldSprtGame(tx, task.ID)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to load held SPRT game")
+	}
+	games, stillHeld := foldSprtPairs(st, pairs, held)
+	if held != nil || stillHeld != nil {
+		if err := queries.SetHeldSprtGame(tx, task.ID, stillHeld); err != nil {
+			return status.Error(codes.Internal, "Failed to hold SPRT game")
+		}
+	}
+	if err := queries.AddTaskAssignmentGames(tx, task.ID, games); err != nil {
+		return status.Error(codes.Internal, "Failed to count task games")
+	}
+	// Keep the counts even if the LLR cannot be computed; the next report retries.
+	state := updateSprtVerdict(st)
+	test := sprtTest(st)
+	if clientState := test.State(clientLLR); clientState != sprt.Continue && clientState != state {
+		log.Printf("sprt task %d: client %s reports LLR %.3f (%v), server LLR is %.3f (%v)", st.ID, task.TaskID, clientLLR, clientState, st.LLR, state)
+	}
+	if st.Verdict != "" {
+		if err := queries.UpdateTaskStatus(tx, st.TaskID, models.TaskStatusDone); err != nil {
+			return status.Error(codes.Internal, "Failed to finish SPRT task")
+		}
+	}
+	if err := queries.UpdateSprtResults(tx, st); err != nil {
+		return status.Error(codes.Internal, "Failed to update SPRT task")
+	}
+	if err := tx.Commit(); err != nil {
+		return status.Error(codes.Internal, "Database error")
+	}
+	return nil
+}
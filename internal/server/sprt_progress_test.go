@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/sprt"
+)
+
+var sprtTaskColumns = []string{"id", "created_at", "updated_at", "task_id", "baseline_network_id", "baseline_params_args", "baseline_params_uci_options",
+	"candidate_network_id", "candidate_params_args", "candidate_params_uci_options", "opening_book_id",
+	"time_control_type", "base_time_seconds", "increment_seconds", "nodes_per_move",
+	"elo0", "elo1", "alpha", "beta", "ll", "ld", "dd", "dw", "ww", "unpaired_losses", "unpaired_draws", "unpaired_wins", "llr", "verdict",
+	"status", "description"}
+
+var (
+	sprtWin  = &pb.MatchGame{ShortOutcome: pb.ShortOutcome_WHITE_WIN, CandidateIsWhite: true}
+	sprtLoss = &pb.MatchGame{ShortOutcome: pb.ShortOutcome_BLACK_WIN, CandidateIsWhite: true}
+	sprtDraw = &pb.MatchGame{ShortOutcome: pb.ShortOutcome_DRAW}
+)
+
+func TestFoldSprtPairs(t *testing.T) {
+	st := &models.SprtTask{LL: 1, WW: 2, UnpairedWins: 1}
+	games, held := foldSprtPairs(st, []*pb.SprtPairReport{
+		{Game1: sprtWin, Game2: sprtWin},
+		{Game1: sprtWin, Game2: sprtLoss},
+		{Game1: sprtDraw, Game2: sprtLoss},
+		{Game1: sprtLoss},
+		{Game1: sprtDraw},
+		{Game1: sprtWin, Game2: &pb.MatchGame{}},
+		{Game1: &pb.MatchGame{}},
+	}, nil)
+	if games != 8 {
+		t.Errorf("foldSprtPairs counted %d games, want 8", games)
+	}
+	if held == nil || *held != 2 {
+		t.Fatalf("foldSprtPairs held %v, want the win of the running pair", held)
+	}
+	if got, want := pentanomial(st), []int{1, 1, 1, 0, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pentanomial after fold = %v, want %v", got, want)
+	}
+	if got, want := unpairedTrinomial(st), []int{1, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unpaired trinomial after fold = %v, want %v", got, want)
+	}
+
+	// A later report completes the held pair, then holds another first game.
+	games, held = foldSprtPairs(st, []*pb.SprtPairReport{
+		{Game2: &pb.MatchGame{}},
+		{Game2: sprtDraw},
+		{Game2: sprtDraw},
+		{Game1: sprtLoss, Game2: &pb.MatchGame{}},
+	}, held)
+	if games != 2 {
+		t.Errorf("foldSprtPairs counted %d games, want 2", games)
+	}
+	if held == nil || *held != 0 {
+		t.Fatalf("foldSprtPairs held %v, want the loss of the running pair", held)
+	}
+	if got, want := pentanomial(st), []int{1, 1, 1, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pentanomial after completing the pair = %v, want %v", got, want)
+	}
+
+	// Holding another game before the held one is completed counts it unpaired.
+	games, held = foldSprtPairs(st, []*pb.SprtPairReport{{Game1: sprtDraw, Game2: &pb.MatchGame{}}}, held)
+	if games != 1 {
+		t.Errorf("foldSprtPairs counted %d games, want 1", games)
+	}
+	if held == nil || *held != 1 {
+		t.Fatalf("foldSprtPairs held %v, want the draw of the running pair", held)
+	}
+	if got, want := unpairedTrinomial(st), []int{2, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unpaired trinomial after replacing the held game = %v, want %v", got, want)
+	}
+}
+
+func TestUpdateSprtVerdict(t *testing.T) {
+	tests := []struct {
+		name        string
+		counts      []int
+		wantState   sprt.State
+		wantVerdict string
+	}{
+		{"too few pairs", []int{1, 2, 3, 2, 1}, sprt.Continue, ""},
+		{"clearly stronger", []int{0, 10, 100, 200, 400}, sprt.Passed, models.SprtVerdictAccepted},
+		{"clearly weaker", []int{400, 200, 100, 10, 0}, sprt.Failed, models.SprtVerdictRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &models.SprtTask{Elo0: 0, Elo1: 5, Alpha: 0.05, Beta: 0.05}
+			setPentanomial(st, tt.counts)
+			if got := updateSprtVerdict(st); got != tt.wantState {
+				t.Errorf("updateSprtVerdict = %v, want %v (LLR %.3f)", got, tt.wantState, st.LLR)
+			}
+			if st.Verdict != tt.wantVerdict {
+				t.Errorf("verdict = %q, want %q", st.Verdict, tt.wantVerdict)
+			}
+		})
+	}
+}
+
+func TestHandleSprtProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		pair     *pb.SprtPairReport
+		heldRow  driver.Value // held_sprt_game before the report
+		setHeld  bool
+		wantHeld driver.Value // held_sprt_game written, if setHeld
+		games    int
+		seq      uint64
+		fresh    bool
+		wantCode codes.Code
+	}{
+		{"new report", &pb.SprtPairReport{Game1: sprtWin, Game2: sprtDraw}, nil, false, nil, 2, 2, true, codes.OK},
+		{"second game running", &pb.SprtPairReport{Game1: sprtWin, Game2: &pb.MatchGame{}}, nil, true, 2, 0, 2, true, codes.OK},
+		{"held pair completed", &pb.SprtPairReport{Game2: sprtDraw}, int64(2), true, nil, 2, 3, true, codes.OK},
+		{"retried report", &pb.SprtPairReport{Game1: sprtWin, Game2: sprtDraw}, nil, false, nil, 0, 1, false, codes.OK},
+		{"no sequence", &pb.SprtPairReport{Game1: sprtWin, Game2: sprtDraw}, nil, false, nil, 0, 0, false, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			if tt.seq != 0 {
+				now := time.Now()
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM sprt_tasks").WithArgs(3).WillReturnRows(sqlmock.NewRows(sprtTaskColumns).AddRow(
+					4, now, now, 3, 1, "", "", 2, "", "", 5, "nodes", 0.0, 0.0, 100,
+					0.0, 5.0, 0.05, 0.05, 1, 2, 3, 2, 1, 0, 0, 0, 0.0, "", models.TaskStatusActive, ""))
+				affected := int64(0)
+				if tt.fresh {
+					affected = 1
+				}
+				mock.ExpectExec("SET last_sequence").WithArgs(tt.seq, 9).WillReturnResult(sqlmock.NewResult(0, affected))
+				if tt.fresh {
+					// Only the fresh report reaches the counters
+					mock.ExpectQuery("SELECT held_sprt_game").WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"held_sprt_game"}).AddRow(tt.heldRow))
+					if tt.setHeld {
+						mock.ExpectExec("SET held_sprt_game").WithArgs(tt.wantHeld, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+					}
+					mock.ExpectExec("SET games_reported").WithArgs(tt.games, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec("UPDATE sprt_tasks").WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			parent := uint(3)
+			task := &models.TaskAssignment{ID: 9, ParentTaskID: &parent}
+			s := &TaskServiceImpl{DB: db}
+			progress := &pb.SprtProgress{Pairs: []*pb.SprtPairReport{tt.pair}}
+			err = s.handleSprtProgress(context.Background(), task, progress, tt.seq)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("handleSprtProgress code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
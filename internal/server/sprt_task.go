@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+// sprtEngine builds the engine configuration of one side of an SPRT test.
+func (s *TaskServiceImpl) sprtEngine(networkID uint, argsJSON, uciOptionsJSON string) (*pb.EngineConfiguration, error) {
+	net, err := queries.FetchNetworkByID(s.DB, networkID)
+	if err != nil {
+		return nil, err
+	}
+	params, err := engineParams(argsJSON, uciOptionsJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EngineConfiguration{
+		Build:   &pb.BuildSpec{},
+		Network: networkResource(net),
+		Params:  params,
+	}, nil
+}
+
+// getNextSprtTask assigns games of an SPRT test.
+func (s *TaskServiceImpl) getNextSprtTask(
+	ctx context.Context,
+	tok *models.AuthToken,
+	st *models.SprtTask,
+	now time.Time,
+	req *pb.TaskRequest,
+) (*pb.TaskResponse, error) {
+	baseline, err := s.sprtEngine(st.BaselineNetworkID, st.BaselineParamsArgs, st.BaselineParamsUciOptions)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "SPRT task %d baseline: %v", st.ID, err)
+	}
+	candidate, err := s.sprtEngine(st.CandidateNetworkID, st.CandidateParamsArgs, st.CandidateParamsUciOptions)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "SPRT task %d candidate: %v", st.ID, err)
+	}
+	book, err := queries.FetchBook(s.DB, st.OpeningBookID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "SPRT task %d book: %v", st.ID, err)
+	}
+	tc, err := timeControl(st.TimeControlType, st.BaseTimeSeconds, st.IncrementSeconds, st.NodesPerMove)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "SPRT task %d: %v", st.ID, err)
+	}
+
+	taskID := time.Now().UTC().Format("20060102T150405.000000000")
+	assignment := newTaskAssignment(taskID, models.TaskTypeSprt, tok, now, req)
+	assignment.ParentTaskID = &st.TaskID
+	if _, err := queries.InsertTaskAssignment(s.DB, assignment); err != nil {
+		return nil, err
+	}
+	return &pb.TaskResponse{
+		TaskId: taskID,
+		Task: &pb.TaskResponse_Sprt{
+			Sprt: &pb.SprtTask{
+				Baseline:    baseline,
+				Candidate:   candidate,
+				OpeningBook: bookResource(book),
+				TimeControl: tc,
+			},
+		},
+	}, nil
+}
@@ -3,45 +3,59 @@ package server
 import (
 	"context"
 	"errors"
+	"log"
 	"time"
 
 	pb "github.com/leelachesszero/lczero-server/api/v1"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/config"
 	"github.com/leelachesszero/lczero-server/internal/models"
 
 	"database/sql"
 
 	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/schedule"
+	"github.com/leelachesszero/lczero-server/internal/storage"
 )
 
-// validateToken checks if the provided token string exists and is active in the database.
-func (s *TaskServiceImpl) validateToken(token string) (*models.AuthToken, error) {
-	if len(token) < 5 || token[:4] != "lc0-" {
-		return nil, ErrInvalidTokenFormat
-	}
-	var tok models.AuthToken
-	row := s.DB.QueryRow(`SELECT id, created_at, updated_at, user_id, token, last_used_at, issued_reason, client_version, client_host, gpu_type, gpuid, status FROM auth_tokens WHERE token = $1`, token)
-	err := row.Scan(
-		&tok.ID, &tok.CreatedAt, &tok.UpdatedAt, &tok.UserID, &tok.Token, &tok.LastUsedAt, &tok.IssuedReason,
-		&tok.ClientVersion, &tok.ClientHost, &tok.GPUType, &tok.GPUID,
-	)
-	if err != nil {
-		return nil, err
-	}
+// defaultScheduleWindowSeconds is how far back the games of assignments count
+// towards the share of their task by default.
+const defaultScheduleWindowSeconds = 3600
 
-	// If the token is found, update its last used timestamp
-	now := time.Now()
-	tok.LastUsedAt = &now
-	_, err = s.DB.Exec(`UPDATE auth_tokens SET last_used_at = $1 WHERE id = $2`, now, tok.ID)
-	if err != nil {
-		return nil, err
-	}
+// reasonTaskCancelled is the status reason of assignments cancelled because
+// their task was.
+const reasonTaskCancelled = "task cancelled"
 
-	return &tok, nil
+// progressTaskType returns the task type a progress report is for, or "" for
+// a plain heartbeat without progress.
+func progressTaskType(req *pb.ProgressReport) string {
+	switch req.GetProgress().(type) {
+	case *pb.ProgressReport_Training:
+		return models.TaskTypeTraining
+	case *pb.ProgressReport_Match:
+		return models.TaskTypeMatch
+	case *pb.ProgressReport_Sprt:
+		return models.TaskTypeSprt
+	case *pb.ProgressReport_Tuning:
+		return models.TaskTypeTuning
+	}
+	return ""
 }
 
-// ErrInvalidTokenFormat is returned when a token does not start with "lc0-".
-var ErrInvalidTokenFormat = errors.New("invalid token format")
+// checkProgressAllowed makes sure tok owns the assignment and that the report
+// fits it before any of its progress is recorded.
+func checkProgressAllowed(tok *models.AuthToken, task *models.TaskAssignment, req *pb.ProgressReport) error {
+	if task.AssignedTokenID == nil || *task.AssignedTokenID != tok.ID {
+		return status.Error(codes.PermissionDenied, "Task is assigned to another client")
+	}
+	if t := progressTaskType(req); t != "" && t != task.TaskType {
+		return status.Errorf(codes.InvalidArgument, "%s progress reported for a %s task", t, task.TaskType)
+	}
+	return nil
+}
 
 // TaskServiceServer defines the server interface for TaskService.
 type TaskServiceServer interface {
@@ -53,39 +67,73 @@ type TaskServiceServer interface {
 type TaskServiceImpl struct {
 	pb.UnimplementedTaskServiceServer
 	DB *sql.DB
+
+	// Store receives uploaded game blobs.
+	Store storage.Store
+
+	// Tokens batches the client info audit writes.
+	Tokens *TokenCache
+
+	// Versions decides which clients get tasks and which are told to upgrade.
+	Versions VersionPolicy
+
+	// ScheduleWindow is how far back the games of assignments count towards
+	// the share of their task.
+	ScheduleWindow time.Duration
 }
 
-// updateClientInfo updates audit fields for the given token using client info from the request.
-func (s *TaskServiceImpl) updateClientInfo(tok *models.AuthToken, clientInfo *pb.ClientInfo) {
-	now := time.Now()
-	_, _ = s.DB.Exec(
-		`UPDATE auth_tokens SET last_used_at = $1, client_host = $2, client_version = $3, gpu_type = $4, gpuid = $5 WHERE id = $6`,
-		now,
-		clientInfo.GetHostname(),
-		clientInfo.GetVersion(),
-		clientInfo.GetGpuType(),
-		clientInfo.GetGpuId(),
-		tok.ID,
-	)
+// NewTaskService constructs the TaskServiceImpl, with the versions of
+// config.Config.Clients and the schedule window of config.Config.Tasks.
+func NewTaskService(dbConn *sql.DB, store storage.Store, tokens *TokenCache) *TaskServiceImpl {
+	return &TaskServiceImpl{
+		DB:             dbConn,
+		Store:          store,
+		Tokens:         tokens,
+		Versions:       NewVersionPolicy(),
+		ScheduleWindow: scheduleWindow(),
+	}
 }
 
-// NewTaskService constructs the TaskServiceImpl.
-func NewTaskService(dbConn *sql.DB) *TaskServiceImpl {
-	return &TaskServiceImpl{DB: dbConn}
+// scheduleWindow returns config.Config.Tasks.ScheduleWindowSeconds, or its default.
+func scheduleWindow() time.Duration {
+	return time.Duration(orDefault(config.Config.Tasks.ScheduleWindowSeconds, defaultScheduleWindowSeconds)) * time.Second
+}
+
+// newTaskAssignment builds an ACTIVE assignment of the given task to tok.
+func newTaskAssignment(taskID, taskType string, tok *models.AuthToken, now time.Time, req *pb.TaskRequest) *models.TaskAssignment {
+	return &models.TaskAssignment{
+		TaskID:          taskID,
+		TaskType:        taskType,
+		AssignedTokenID: &tok.ID,
+		AssignedAt:      &now,
+		LastHeartbeatAt: &now,
+		Status:          models.TaskStatusActive,
+		ClientVersion:   req.GetClientInfo().GetVersion(),
+		EngineVersion:   req.GetClientInfo().GetEngineVersion(),
+	}
 }
 
 /*
 GetNextTask fetches next available task for the client/token
 
+Clients older than the minimum client or engine version are rejected with
+FailedPrecondition, and only task types a client supports are handed out:
+matches and training games need MATCH and TRAINING, so a client supporting
+only SPRT never gets a training task. Clients older than the next versions get
+an upgrade advisory with their task.
+
+Among the active tasks a client can work on, it is handed to the one furthest
+behind its weight, counting the games of its assignments over the schedule
+window, so that the throughput of every task converges to its share of the
+total weight.
+
 TODO for this function:
-1. Determine what tasks the user is eligible for based on their token and client info
-  - Validate engine version
-  - Check user supported task types
+1. Determine what tasks the user is eligible for based on their token (e.g. per-user bans)
 
 2. Determine NPS on each task type (Depends on network, might be hard. Maybe use a known network)
   - Potentially store this NPS in a hardware db.
 
-3. Compute workload ratios (mostly for training runs)
+3. Weigh workload by NPS instead of counting games
 
 4. Assign user to previous task (if it exists), if it doesn't mess with ratios too much
 
@@ -96,28 +144,116 @@ TODO for this function:
 7. Correctly handle engine parameters.
 */
 func (s *TaskServiceImpl) GetNextTask(ctx context.Context, req *pb.TaskRequest) (*pb.TaskResponse, error) {
-	// 1) Validate token and update audit info
-	tok, err := s.validateToken(req.Token)
+	// 1) Token was validated by the auth interceptor, update audit info
+	tok, err := TokenFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 	now := time.Now()
-	s.updateClientInfo(tok, req.GetClientInfo())
-
-	// 2) Choose the lowest-id active TrainingRun (do NOT default to zero)
-	tr, err := queries.FetchActiveTrainingTask(s.DB)
+	s.Tokens.RecordClientInfo(tok, req.GetClientInfo(), now)
+	if err := s.Versions.check(req.GetClientInfo(), now); err != nil {
+		return nil, err
+	}
+	resp, err := s.nextTask(ctx, tok, now, req)
 	if err != nil {
 		return nil, err
 	}
+	resp.Upgrade = s.Versions.advisory(req.GetClientInfo().GetVersion(), req.GetClientInfo().GetEngineVersion(), now)
+	return resp, nil
+}
 
-	// Load best network for this run
-	net, err := queries.FetchNetworkByID(s.DB, tr.BestNetworkID)
+// nextTask hands the client to the active task furthest behind its weight
+// among those it supports, falling back to the next one while a task has no
+// work for it.
+func (s *TaskServiceImpl) nextTask(
+	ctx context.Context,
+	tok *models.AuthToken,
+	now time.Time,
+	req *pb.TaskRequest,
+) (*pb.TaskResponse, error) {
+	workloads, err := queries.FetchWorkloads(s.DB, now.Add(-s.ScheduleWindow))
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.Internal, "Failed to load active tasks")
+	}
+	var eligible []models.Workload
+	var shares []schedule.Share
+	for _, w := range workloads {
+		if canRunWorkload(req.GetClientInfo(), w.TaskType) {
+			eligible = append(eligible, w)
+			shares = append(shares, schedule.Share{ID: w.TaskID, Weight: w.Weight, Assigned: w.Games})
+		}
+	}
+	for _, i := range schedule.Order(shares) {
+		resp, err := s.assignWorkload(ctx, tok, now, req, eligible[i])
+		if err != nil {
+			// A broken task must not keep clients from the others
+			log.Printf("task %d: assigning work: %v", eligible[i].TaskID, err)
+			continue
+		}
+		if resp != nil {
+			return resp, nil
+		}
 	}
+	return nil, status.Error(codes.NotFound, "No task available")
+}
+
+// canRunWorkload reports whether a client can work on a task of taskType.
+// Training tasks hand out both matches and training games.
+func canRunWorkload(info *pb.ClientInfo, taskType string) bool {
+	switch taskType {
+	case models.TaskTypeTraining:
+		return supportsTaskType(info, pb.TaskType_TRAINING) || supportsTaskType(info, pb.TaskType_MATCH)
+	case models.TaskTypeSprt:
+		return supportsTaskType(info, pb.TaskType_SPRT)
+	case models.TaskTypeTuning:
+		return supportsTaskType(info, pb.TaskType_TUNING)
+	}
+	return false
+}
 
+// assignWorkload assigns work of one task, or returns nil if it has none for
+// the client.
+func (s *TaskServiceImpl) assignWorkload(
+	ctx context.Context,
+	tok *models.AuthToken,
+	now time.Time,
+	req *pb.TaskRequest,
+	w models.Workload,
+) (*pb.TaskResponse, error) {
+	switch w.TaskType {
+	case models.TaskTypeTraining:
+		tr, err := queries.FetchTrainingTaskByTaskID(s.DB, w.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		return s.getNextTrainingRunTask(ctx, tok, *tr, now, req)
+	case models.TaskTypeSprt:
+		st, err := queries.FetchSprtTaskByTaskID(s.DB, w.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		return s.getNextSprtTask(ctx, tok, st, now, req)
+	case models.TaskTypeTuning:
+		tt, err := queries.FetchTuneTaskByTaskID(s.DB, w.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		return s.getNextTuningTask(ctx, tok, tt, now, req)
+	}
+	return nil, nil
+}
+
+// getNextTrainingRunTask assigns a pending match of a training task, or else
+// a training game, limited to the task types the client supports.
+func (s *TaskServiceImpl) getNextTrainingRunTask(
+	ctx context.Context,
+	tok *models.AuthToken,
+	tr models.TrainingTask,
+	now time.Time,
+	req *pb.TaskRequest,
+) (*pb.TaskResponse, error) {
 	// Compute deterministic slice for match assignment
-	tokenStr := req.GetToken()
+	tokenStr := tok.Token
 	slice := 1
 	if len(tokenStr) > 0 {
 		var acc int
@@ -128,18 +264,30 @@ func (s *TaskServiceImpl) GetNextTask(ctx context.Context, req *pb.TaskRequest)
 	}
 
 	// Try match task first
-	resp, err := s.getNextMatchTask(ctx, tok, *tr, now, req, slice)
-	if err == nil && resp != nil {
-		return resp, nil
+	if supportsTaskType(req.GetClientInfo(), pb.TaskType_MATCH) {
+		resp, err := s.getNextMatchTask(ctx, tok, tr, now, req, slice)
+		if err == nil && resp != nil {
+			return resp, nil
+		}
 	}
 
 	// Fallback to training task
-	return s.getNextTrainingTask(ctx, tok, *tr, *net, now, req)
+	if !supportsTaskType(req.GetClientInfo(), pb.TaskType_TRAINING) {
+		return nil, nil
+	}
+	net, err := queries.FetchNetworkByID(s.DB, tr.BestNetworkID)
+	if err != nil {
+		return nil, err
+	}
+	return s.getNextTrainingTask(ctx, tok, tr, *net, now, req)
 }
 
 // TODO: getNextMatchTask and getNextTrainingTask are both almost direct copies from HTTP version. They should be rewritten.
 
-// getNextMatchTask tries to allocate a match task for the given training run and slice.
+// getNextMatchTask tries to allocate a match task for the given training run
+// and slice. The game slot, its match_games row with the colours and the
+// assignment are stored in one transaction, so a failure leaves no slot
+// reserved.
 func (s *TaskServiceImpl) getNextMatchTask(
 	ctx context.Context,
 	tok *models.AuthToken,
@@ -151,95 +299,104 @@ func (s *TaskServiceImpl) getNextMatchTask(
 	// TODO: I think this is wrong, look over again. It is almost an exact copy from the HTTP version.
 
 	// NOTE: target slice no longer exists in MatchTask. Rework required
-	pendingMatchPtr, err := queries.FetchPendingMatch(s.DB, tr.ID, slice)
-	if err == nil && pendingMatchPtr != nil {
-		pendingMatch := *pendingMatchPtr
-		mg := &models.MatchGame{
-			UserID: func() uint {
-				if tok.UserID != nil {
-					return *tok.UserID
-				}
-				return 0
-			}(),
-			MatchID: pendingMatch.ID,
-			Done:    false,
-		}
-		mg.ID, err = queries.InsertMatchGame(s.DB, mg.UserID, mg.MatchID, mg.Done)
-		if err != nil {
-			return nil, err
-		}
-		flip := (mg.ID & 1) == 1
-		_ = queries.UpdateMatchGameFlip(s.DB, mg.ID, flip)
-
-		// Fetch Candidate and CurrentBest networks for resource specs
-		candidateSha, _ := queries.FetchNetworkSha(s.DB, pendingMatch.CandidateID)
-		currentBestSha, _ := queries.FetchNetworkSha(s.DB, pendingMatch.CurrentBestID)
-
-		baselineNetRes := &pb.ResourceSpec{
-			Sha256:    currentBestSha,
-			Url:       "",
-			SizeBytes: 0,
-			Type:      pb.ResourceType_NETWORK,
-			Format:    "",
-		}
-		candidateNetRes := &pb.ResourceSpec{
-			Sha256:    candidateSha,
-			Url:       "",
-			SizeBytes: 0,
-			Type:      pb.ResourceType_NETWORK,
-			Format:    "",
-		}
-		// Fetch MatchBook info
-		matchBookSha, matchBookURL, matchBookSize, _ := queries.FetchBookByID(s.DB, tr.MatchBookID)
-		matchBook := &pb.ResourceSpec{
-			Sha256:    matchBookSha,
-			Url:       matchBookURL,
-			SizeBytes: matchBookSize,
-			Type:      pb.ResourceType_BOOK,
-			Format:    "pgn",
-		}
-		engineParams := &pb.EngineParams{
-			Args:       []string{tr.MatchParameters},
-			UciOptions: map[string]string{},
-		}
-		matchTask := &pb.MatchTask{
-			Baseline: &pb.EngineConfiguration{
-				Build:   &pb.BuildSpec{},
-				Network: baselineNetRes,
-				Params:  engineParams,
-			},
-			Candidate: &pb.EngineConfiguration{
-				Build:   &pb.BuildSpec{},
-				Network: candidateNetRes,
-				Params:  engineParams,
-			},
-			OpeningBook: matchBook,
-		}
+	if tr.TrainingRunID == nil {
+		return nil, nil
+	}
+	pendingMatch, err := queries.FetchPendingMatch(s.DB, *tr.TrainingRunID, slice)
+	if err != nil || pendingMatch == nil {
+		return nil, nil
+	}
 
-		taskID := time.Now().UTC().Format("20060102T150405.000000000")
-		grpcTaskID, err := queries.InsertTaskAssignment(
-			s.DB,
-			taskID,
-			models.TaskTypeMatch,
-			tok.ID,
-			now,
-			now,
-			models.TaskStatusActive,
-		)
-		if err != nil {
-			return nil, err
-		}
+	// Fetch Candidate and CurrentBest networks for resource specs
+	candidateSha, _ := queries.FetchNetworkSha(s.DB, pendingMatch.CandidateID)
+	currentBestSha, _ := queries.FetchNetworkSha(s.DB, pendingMatch.CurrentBestID)
 
-		resp := &pb.TaskResponse{
-			TaskId: taskID,
-			Task: &pb.TaskResponse_Match{
-				Match: matchTask,
-			},
-		}
-		_ = grpcTaskID // suppress unused warning
-		return resp, nil
+	baselineNetRes := &pb.ResourceSpec{
+		Sha256:    currentBestSha,
+		Url:       "",
+		SizeBytes: 0,
+		Type:      pb.ResourceType_NETWORK,
+		Format:    "",
 	}
-	return nil, nil
+	candidateNetRes := &pb.ResourceSpec{
+		Sha256:    candidateSha,
+		Url:       "",
+		SizeBytes: 0,
+		Type:      pb.ResourceType_NETWORK,
+		Format:    "",
+	}
+	// Fetch MatchBook info
+	matchBookSha, matchBookURL, matchBookSize, _ := queries.FetchBookByID(s.DB, tr.MatchBookID)
+	matchBook := &pb.ResourceSpec{
+		Sha256:    matchBookSha,
+		Url:       matchBookURL,
+		SizeBytes: matchBookSize,
+		Type:      pb.ResourceType_BOOK,
+		Format:    "pgn",
+	}
+	engineParams := &pb.EngineParams{
+		Args:       []string{tr.MatchParameters},
+		UciOptions: map[string]string{},
+	}
+
+	var userID uint
+	if tok.UserID != nil {
+		userID = *tok.UserID
+	}
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Reserve a game slot; another client may have taken the last one since the fetch.
+	reserved, err := queries.ReserveMatchGame(tx, pendingMatch.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return nil, nil
+	}
+	matchGameID, err := queries.InsertMatchGame(tx, userID, pendingMatch.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	// Colours alternate between games; flip means the candidate plays black
+	flip := (matchGameID & 1) == 1
+	if err := queries.UpdateMatchGameFlip(tx, matchGameID, flip); err != nil {
+		return nil, err
+	}
+
+	taskID := time.Now().UTC().Format("20060102T150405.000000000")
+	assignment := newTaskAssignment(taskID, models.TaskTypeMatch, tok, now, req)
+	assignment.ParentTaskID = &tr.TaskID
+	assignment.MatchGameID = &matchGameID
+	if _, err := queries.InsertTaskAssignment(tx, assignment); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &pb.TaskResponse{
+		TaskId: taskID,
+		Task: &pb.TaskResponse_Match{
+			Match: &pb.MatchTask{
+				Baseline: &pb.EngineConfiguration{
+					Build:   &pb.BuildSpec{},
+					Network: baselineNetRes,
+					Params:  engineParams,
+				},
+				Candidate: &pb.EngineConfiguration{
+					Build:   &pb.BuildSpec{},
+					Network: candidateNetRes,
+					Params:  engineParams,
+				},
+				OpeningBook:      matchBook,
+				CandidateIsWhite: !flip,
+			},
+		},
+	}, nil
 }
 
 // getNextTrainingTask allocates a training task for the given training run.
@@ -251,20 +408,12 @@ func (s *TaskServiceImpl) getNextTrainingTask(
 	now time.Time,
 	req *pb.TaskRequest,
 ) (*pb.TaskResponse, error) {
-	networkRes := &pb.ResourceSpec{
-		Sha256:    net.Sha,
-		Url:       "",
-		SizeBytes: 0,
-		Type:      pb.ResourceType_NETWORK,
-		Format:    "",
-	}
-	openingBookRes := &pb.ResourceSpec{
-		Sha256:    tr.TrainBook.Sha256,
-		Url:       tr.TrainBook.URL,
-		SizeBytes: tr.TrainBook.SizeBytes,
-		Type:      pb.ResourceType_BOOK,
-		Format:    "pgn",
+	networkRes := networkResource(&net)
+	trainBook, err := queries.FetchBook(s.DB, tr.TrainBookID)
+	if err != nil {
+		return nil, err
 	}
+	openingBookRes := bookResource(trainBook)
 	engineCfg := &pb.EngineConfiguration{
 		Build:   &pb.BuildSpec{},
 		Network: networkRes,
@@ -279,15 +428,10 @@ func (s *TaskServiceImpl) getNextTrainingTask(
 		NodesPerMove: 8000, // TODO: Get from table
 	}
 	taskID := time.Now().UTC().Format("20060102T150405.000000000")
-	grpcTaskID, err := queries.InsertTaskAssignment(
-		s.DB,
-		taskID,
-		models.TaskTypeTraining,
-		tok.ID,
-		now,
-		now,
-		models.TaskStatusActive,
-	)
+	assignment := newTaskAssignment(taskID, models.TaskTypeTraining, tok, now, req)
+	assignment.ParentTaskID = &tr.TaskID
+	assignment.NetworkID = &net.ID
+	grpcTaskID, err := queries.InsertTaskAssignment(s.DB, assignment)
 	if err != nil {
 		return nil, err
 	}
@@ -307,13 +451,13 @@ ReportProgress updates heartbeat and checks cancellation.
 TODO for this function:
 
 ## Training/Match Heartbeats
-- Save games that are uploaded
+- Training games and match results are saved as they are uploaded
 
 ## SPRT Heartbeats
-- Record Wins/Losses/Draws (Pentanomial requires LL/LD/DD/DW/WW results)
--
+- Pentanomial LL/LD/DD/DW/WW results are recorded and the test stops at a verdict
 
 ## Tuning Heartbeats
+- Results are folded per parameter set and the task finishes once every set has its games
 
 ## All of them
 1. Handle training and match cases
@@ -322,47 +466,136 @@ TODO for this function:
 
 3. Handle SPRT and tuning progress
 4. Correctly update task status based on progress
-5. Remove tasks that are never completed, missing a number of heart beats
-6. Handle cancellation logic (Stop iff task is finished, find some way to report new networks required)
-7. Handle crashes and logging
+5. Handle cancellation logic (Stop iff task is finished, find some way to report new networks required)
+6. Handle crashes and logging
+
+Only the token an assignment was handed to may report on it, and only with
+progress of the assignment's own type. An assignment is completed once its
+work is recorded: a match assignment with its game, the others with a report
+marked final or when their task finishes. Assignments that miss heartbeats are
+expired by the Reaper; late reports for them, or for tasks that already
+finished, are dropped and the client is told to stop.
 */
 func (s *TaskServiceImpl) ReportProgress(ctx context.Context, req *pb.ProgressReport) (*pb.ProgressResponse, error) {
 
-	_, err := s.validateToken(req.Token)
+	tok, err := TokenFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var task models.TaskAssignment
-	rowTask := s.DB.QueryRow(`SELECT id, created_at, updated_at, task_id, task_type, assigned_token_id, assigned_at, last_heartbeat_at, status, cancelled_at, completed_at FROM task_assignments WHERE task_id = $1`, req.TaskId)
-	err = rowTask.Scan(
-		&task.ID, &task.CreatedAt, &task.UpdatedAt, &task.TaskID, &task.TaskType, &task.AssignedTokenID, &task.AssignedAt, &task.LastHeartbeatAt, &task.Status, &task.CancelledAt, &task.CompletedAt,
-	)
+	if req.GetTaskId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "No task id supplied")
+	}
+	task, err := queries.FetchTaskAssignmentByTaskID(s.DB, req.TaskId)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "Task not found")
+		}
+		return nil, status.Error(codes.Internal, "Failed to load task")
+	}
+	if err := checkProgressAllowed(tok, task, req); err != nil {
 		return nil, err
 	}
+	// The reaper already gave this work away, so keep it from counting twice.
+	// A completed assignment has all its work recorded (e.g. a retried final
+	// report), and a cancelled one is not wanted any more.
+	switch task.Status {
+	case models.TaskStatusExpired, models.TaskStatusDone, models.TaskStatusCancelled:
+		return s.progressResponse(task, pb.ProgressResponse_CANCELLED), nil
+	}
 	now := time.Now()
+	// Results for a task that already finished are not recorded
+	finished, err := s.closeIfParentFinished(task, now)
+	if err != nil {
+		return nil, err
+	}
+	if finished {
+		return s.progressResponse(task, pb.ProgressResponse_CANCELLED), nil
+	}
 	task.LastHeartbeatAt = &now
 
-	switch req.GetProgress().(type) {
+	switch progress := req.GetProgress().(type) {
 	case *pb.ProgressReport_Training:
-		// TODO: Handle training progress
+		if err := s.handleTrainingProgress(ctx, tok, task, progress.Training, req.GetSequence(), now); err != nil {
+			return nil, err
+		}
 	case *pb.ProgressReport_Match:
-		// TODO: Handle match progress
+		if err := s.handleMatchProgress(ctx, task, progress.Match, now); err != nil {
+			return nil, err
+		}
 	case *pb.ProgressReport_Sprt:
-		// TODO: Handle SPRT progress
+		if err := s.handleSprtProgress(ctx, task, progress.Sprt, req.GetSequence()); err != nil {
+			return nil, err
+		}
 	case *pb.ProgressReport_Tuning:
-		// TODO: Handle tuning progress
+		if err := s.handleTuningProgress(ctx, task, progress.Tuning, req.GetSequence(), now); err != nil {
+			return nil, err
+		}
 	}
 
 	err = queries.UpdateTaskAssignmentHeartbeat(s.DB, task.ID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to record heartbeat")
+	}
+
+	if req.GetFinal() && task.Status == models.TaskStatusActive {
+		if _, err := queries.CompleteTaskAssignment(s.DB, task.ID, now); err != nil {
+			return nil, status.Error(codes.Internal, "Failed to complete task")
+		}
+		// Sets the client gave up on go to the next one
+		if task.TaskType == models.TaskTypeTuning {
+			if _, err := queries.ReleaseTuneParamSets(s.DB, task.ID); err != nil {
+				return nil, status.Error(codes.Internal, "Failed to release parameter sets")
+			}
+		}
+		task.Status = models.TaskStatusDone
+		task.CompletedAt = &now
+	}
+	// The client is done once its assignment has all its work recorded
+	if task.Status == models.TaskStatusDone {
+		return s.progressResponse(task, pb.ProgressResponse_CANCELLED), nil
+	}
+
+	// Stop clients once the task they work on has finished (e.g. this report
+	// brought an SPRT to a verdict)
+	finished, err = s.closeIfParentFinished(task, now)
 	if err != nil {
 		return nil, err
 	}
+	if finished {
+		return s.progressResponse(task, pb.ProgressResponse_CANCELLED), nil
+	}
+	return s.progressResponse(task, pb.ProgressResponse_ACTIVE), nil
+}
+
+// progressResponse answers a progress report with st, advising an upgrade if
+// the client the task was assigned to is older than the next versions.
+func (s *TaskServiceImpl) progressResponse(task *models.TaskAssignment, st pb.ProgressResponse_Status) *pb.ProgressResponse {
+	return &pb.ProgressResponse{Status: st, Upgrade: s.Versions.advisory(task.ClientVersion, task.EngineVersion, time.Now())}
+}
 
-	status := pb.ProgressResponse_ACTIVE
-	if task.Status == models.TaskStatusCancelled {
-		status = pb.ProgressResponse_CANCELLED
+// closeIfParentFinished reports whether the task an assignment belongs to is
+// DONE or CANCELLED, and if so closes the assignment the same way, so that the
+// reaper does not expire it for missed heartbeats.
+func (s *TaskServiceImpl) closeIfParentFinished(task *models.TaskAssignment, now time.Time) (bool, error) {
+	if task.ParentTaskID == nil {
+		return false, nil
+	}
+	parentStatus, err := queries.FetchTaskStatus(s.DB, *task.ParentTaskID)
+	if err != nil {
+		return false, status.Error(codes.Internal, "Failed to load task status")
+	}
+	switch parentStatus {
+	case models.TaskStatusDone:
+		if _, err := queries.CompleteTaskAssignment(s.DB, task.ID, now); err != nil {
+			return false, status.Error(codes.Internal, "Failed to complete task")
+		}
+	case models.TaskStatusCancelled:
+		if _, err := queries.CancelTaskAssignment(s.DB, task.ID, reasonTaskCancelled, now); err != nil {
+			return false, status.Error(codes.Internal, "Failed to cancel task")
+		}
+	default:
+		return false, nil
 	}
-	return &pb.ProgressResponse{Status: status}, nil
+	return true, nil
 }
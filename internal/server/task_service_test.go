@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+var taskAssignmentColumns = []string{"id", "created_at", "updated_at", "task_id", "task_type", "parent_task_id", "network_id", "match_game_id",
+	"assigned_token_id", "assigned_at", "last_heartbeat_at", "status", "status_reason", "cancelled_at", "completed_at", "client_version", "engine_version"}
+
+func TestReportProgress(t *testing.T) {
+	tests := []struct {
+		name       string
+		found      bool
+		tokenID    uint
+		taskType   string
+		status     string
+		training   bool // report training progress rather than a plain heartbeat
+		wantCode   codes.Code
+		wantStatus pb.ProgressResponse_Status
+	}{
+		{"not found", false, 1, models.TaskTypeTraining, models.TaskStatusActive, true, codes.NotFound, 0},
+		{"token mismatch", true, 2, models.TaskTypeTraining, models.TaskStatusActive, true, codes.PermissionDenied, 0},
+		{"type mismatch", true, 1, models.TaskTypeSprt, models.TaskStatusActive, true, codes.InvalidArgument, 0},
+		{"cancelled", true, 1, models.TaskTypeTraining, models.TaskStatusCancelled, true, codes.OK, pb.ProgressResponse_CANCELLED},
+		{"expired", true, 1, models.TaskTypeTraining, models.TaskStatusExpired, true, codes.OK, pb.ProgressResponse_CANCELLED},
+		{"done", true, 1, models.TaskTypeTraining, models.TaskStatusDone, true, codes.OK, pb.ProgressResponse_CANCELLED},
+		{"heartbeat", true, 1, models.TaskTypeTraining, models.TaskStatusActive, false, codes.OK, pb.ProgressResponse_ACTIVE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+			query := mock.ExpectQuery("FROM task_assignments").WithArgs("t1")
+			if !tt.found {
+				query.WillReturnError(sql.ErrNoRows)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows(taskAssignmentColumns).AddRow(
+					9, now, now, "t1", tt.taskType, 3, nil, nil, tt.tokenID, now, now, tt.status, "", nil, nil, "", ""))
+			}
+			if tt.wantStatus == pb.ProgressResponse_ACTIVE {
+				mock.ExpectQuery("FROM tasks").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(models.TaskStatusActive))
+				mock.ExpectExec("UPDATE task_assignments").WithArgs(sqlmock.AnyArg(), 9).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM tasks").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(models.TaskStatusActive))
+			}
+
+			s := &TaskServiceImpl{DB: db}
+			ctx := ContextWithToken(context.Background(), &models.AuthToken{ID: 1, Token: testToken})
+			req := &pb.ProgressReport{TaskId: "t1"}
+			if tt.training {
+				req.Progress = &pb.ProgressReport_Training{Training: &pb.TrainingProgress{}}
+			}
+			resp, err := s.ReportProgress(ctx, req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("ReportProgress code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if resp.GetStatus() != tt.wantStatus {
+				t.Errorf("ReportProgress status = %v, want %v", resp.GetStatus(), tt.wantStatus)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetNextMatchTaskUsesTrainingRun(t *testing.T) {
+	tests := []struct {
+		name  string
+		runID *uint
+	}{
+		{"run differs from task", func() *uint { id := uint(4); return &id }()},
+		{"no run", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			if tt.runID != nil {
+				// Matches belong to the run, not to the training task
+				mock.ExpectQuery("FROM matches").WithArgs(*tt.runID, 2).WillReturnError(sql.ErrNoRows)
+			}
+
+			s := &TaskServiceImpl{DB: db}
+			tr := models.TrainingTask{ID: 2, TaskID: 3, TrainingRunID: tt.runID}
+			resp, err := s.getNextMatchTask(context.Background(), &models.AuthToken{ID: 1}, tr, time.Now(), &pb.TaskRequest{}, 2)
+			if err != nil || resp != nil {
+				t.Errorf("getNextMatchTask = %v, %v, want no task", resp, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
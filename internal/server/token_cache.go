@@ -0,0 +1,247 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"github.com/leelachesszero/lczero-server/internal/config"
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+const (
+	defaultTokenCacheTTL      = time.Minute
+	defaultTokenFlushInterval = 30 * time.Second
+)
+
+// TokenCache keeps validated auth tokens in memory for TTL, so most calls do
+// not query auth_tokens at all. Usage audit fields (last_used_at and client
+// info) are collected in memory and written in batches by Flush.
+//
+// Revocations through this process invalidate the cache immediately; changes
+// made elsewhere (another server, an operator suspending a token in SQL) are
+// picked up once the cached entry is older than TTL.
+type TokenCache struct {
+	DB            *sql.DB
+	TTL           time.Duration
+	FlushInterval time.Duration
+
+	mu       sync.Mutex
+	entries  map[string]cachedToken
+	fetching map[string]*tokenFetch
+	pending  map[uint]*tokenUsage
+}
+
+type cachedToken struct {
+	tok      models.AuthToken
+	loadedAt time.Time
+}
+
+// tokenFetch tracks the database reads of a token in flight. Invalidate bumps
+// gen, so reads that started before it do not cache what they read.
+type tokenFetch struct {
+	gen      uint64
+	inflight int
+}
+
+// tokenUsage is an audit update waiting to be flushed.
+type tokenUsage struct {
+	lastUsedAt time.Time
+	clientInfo *pb.ClientInfo
+}
+
+// NewTokenCache creates a TokenCache using config.Config.Tokens.
+func NewTokenCache(dbConn *sql.DB) *TokenCache {
+	c := &TokenCache{
+		DB:            dbConn,
+		TTL:           time.Duration(config.Config.Tokens.CacheTTLSeconds) * time.Second,
+		FlushInterval: time.Duration(config.Config.Tokens.FlushIntervalSeconds) * time.Second,
+		entries:       make(map[string]cachedToken),
+		fetching:      make(map[string]*tokenFetch),
+		pending:       make(map[uint]*tokenUsage),
+	}
+	if c.TTL <= 0 {
+		c.TTL = defaultTokenCacheTTL
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = defaultTokenFlushInterval
+	}
+	return c
+}
+
+// Validate returns the token if it exists and is ACTIVE, and records that it
+// was used. The database is only queried when the token is not cached.
+func (c *TokenCache) Validate(token string, now time.Time) (*models.AuthToken, error) {
+	if len(token) < 5 || token[:4] != "lc0-" {
+		return nil, ErrInvalidTokenFormat
+	}
+	c.mu.Lock()
+	entry, ok := c.entries[token]
+	stale := !ok || now.Sub(entry.loadedAt) >= c.TTL
+	var gen uint64
+	if stale {
+		gen = c.startFetch(token)
+	}
+	c.mu.Unlock()
+	if stale {
+		tok, err := queries.FetchAuthTokenByToken(c.DB, token)
+		c.mu.Lock()
+		// A token invalidated during the read may have been read before the change
+		if c.finishFetch(token, gen) && err == nil {
+			c.entries[token] = cachedToken{tok: *tok, loadedAt: now}
+		}
+		c.mu.Unlock()
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Error(codes.Unauthenticated, "Unknown token")
+			}
+			return nil, status.Error(codes.Internal, "Database error")
+		}
+		entry = cachedToken{tok: *tok, loadedAt: now}
+	}
+
+	tok := entry.tok
+	if err := checkTokenStatus(&tok); err != nil {
+		return nil, err
+	}
+	tok.LastUsedAt = &now
+	c.mu.Lock()
+	c.usage(tok.ID).lastUsedAt = now
+	c.mu.Unlock()
+	return &tok, nil
+}
+
+// startFetch registers a database read of a token and returns the generation
+// it started in. c.mu must be held.
+func (c *TokenCache) startFetch(token string) uint64 {
+	f, ok := c.fetching[token]
+	if !ok {
+		f = &tokenFetch{}
+		c.fetching[token] = f
+	}
+	f.inflight++
+	return f.gen
+}
+
+// finishFetch unregisters a read started in generation gen and reports
+// whether the token was not invalidated since. c.mu must be held.
+func (c *TokenCache) finishFetch(token string, gen uint64) bool {
+	f := c.fetching[token]
+	f.inflight--
+	if f.inflight == 0 {
+		delete(c.fetching, token)
+	}
+	return f.gen == gen
+}
+
+// RecordClientInfo queues an update of the client info stored with a token.
+func (c *TokenCache) RecordClientInfo(tok *models.AuthToken, info *pb.ClientInfo, now time.Time) {
+	if info == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	u := c.usage(tok.ID)
+	u.lastUsedAt = now
+	u.clientInfo = info
+}
+
+// usage returns the pending update of a token, creating it if needed. c.mu
+// must be held.
+func (c *TokenCache) usage(id uint) *tokenUsage {
+	u, ok := c.pending[id]
+	if !ok {
+		u = &tokenUsage{}
+		c.pending[id] = u
+	}
+	return u
+}
+
+// Invalidate drops a token from the cache, so its next use reads its status
+// from the database again. Reads already in flight do not cache their result.
+func (c *TokenCache) Invalidate(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, token)
+	if f, ok := c.fetching[token]; ok {
+		f.gen++
+	}
+}
+
+// Run flushes pending usage every FlushInterval until ctx is done, then
+// flushes one last time.
+func (c *TokenCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := c.Flush(time.Now()); err != nil {
+				log.Printf("Failed to flush token usage: %v", err)
+			}
+			return
+		case now := <-ticker.C:
+			if err := c.Flush(now); err != nil {
+				log.Printf("Failed to flush token usage: %v", err)
+			}
+		}
+	}
+}
+
+// Flush writes all pending usage with at most two statements and drops
+// expired cache entries. Updates that fail to write are dropped, as they are
+// only audit data.
+func (c *TokenCache) Flush(now time.Time) error {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[uint]*tokenUsage)
+	for token, entry := range c.entries {
+		if now.Sub(entry.loadedAt) >= c.TTL {
+			delete(c.entries, token)
+		}
+	}
+	c.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var ids, infoIDs, gpuIDs []int64
+	var usedAt, hosts, versions, gpuTypes []string
+	for id, u := range pending {
+		ids = append(ids, int64(id))
+		usedAt = append(usedAt, u.lastUsedAt.UTC().Format(time.RFC3339Nano))
+		if u.clientInfo != nil {
+			infoIDs = append(infoIDs, int64(id))
+			hosts = append(hosts, u.clientInfo.GetHostname())
+			versions = append(versions, u.clientInfo.GetVersion())
+			gpuTypes = append(gpuTypes, u.clientInfo.GetGpuType())
+			gpuIDs = append(gpuIDs, int64(u.clientInfo.GetGpuId()))
+		}
+	}
+	_, err := c.DB.Exec(`UPDATE auth_tokens AS t
+	SET last_used_at = GREATEST(t.last_used_at, v.last_used_at)
+	FROM unnest($1::bigint[], $2::timestamptz[]) AS v(id, last_used_at)
+	WHERE t.id = v.id`, pq.Array(ids), pq.Array(usedAt))
+	if err != nil {
+		return err
+	}
+	if len(infoIDs) == 0 {
+		return nil
+	}
+	_, err = c.DB.Exec(`UPDATE auth_tokens AS t
+	SET client_host = v.client_host, client_version = v.client_version, gpu_type = v.gpu_type, gpu_id = v.gpu_id
+	FROM unnest($1::bigint[], $2::text[], $3::text[], $4::text[], $5::integer[]) AS v(id, client_host, client_version, gpu_type, gpu_id)
+	WHERE t.id = v.id`, pq.Array(infoIDs), pq.Array(hosts), pq.Array(versions), pq.Array(gpuTypes), pq.Array(gpuIDs))
+	return err
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+)
+
+// countingDriver is a database/sql driver that answers every auth_tokens
+// select with an ACTIVE token and counts the statements it receives, per DSN.
+type countingDriver struct{}
+
+// statementCounts counts the statements run against one counting database.
+// When gate is set, selects announce themselves on started and wait on gate.
+type statementCounts struct {
+	queries atomic.Int64
+	execs   atomic.Int64
+	started chan struct{}
+	gate    chan struct{}
+}
+
+var (
+	registerCountingDriver sync.Once
+	countingDSNs           atomic.Int64
+	countsByDSN            sync.Map // DSN -> *statementCounts
+)
+
+func (countingDriver) Open(dsn string) (driver.Conn, error) {
+	d, _ := countsByDSN.Load(dsn)
+	return &countingConn{d: d.(*statementCounts)}, nil
+}
+
+type countingConn struct{ d *statementCounts }
+
+func (c *countingConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *countingConn) Close() error                        { return nil }
+func (c *countingConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *countingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.queries.Add(1)
+	if c.d.gate != nil {
+		c.d.started <- struct{}{}
+		<-c.d.gate
+	}
+	token, _ := args[0].Value.(string)
+	return &tokenRowsDriver{token: token}, nil
+}
+
+func (c *countingConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	c.d.execs.Add(1)
+	return driver.RowsAffected(1), nil
+}
+
+type tokenRowsDriver struct {
+	token string
+	done  bool
+}
+
+func (r *tokenRowsDriver) Columns() []string {
+	return []string{"id", "created_at", "updated_at", "user_id", "token", "last_used_at", "issued_reason",
+		"client_version", "client_host", "gpu_type", "gpu_id", "status", "revoked_at", "scope"}
+}
+
+func (r *tokenRowsDriver) Close() error { return nil }
+
+func (r *tokenRowsDriver) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	now := time.Now()
+	copy(dest, []driver.Value{int64(1), now, now, nil, r.token, nil, "anonymous", "", "", "", nil, "ACTIVE", nil, "CLIENT"})
+	return nil
+}
+
+// newCountingDB returns a database with its own statement counts.
+func newCountingDB(tb testing.TB) (*sql.DB, *statementCounts) {
+	registerCountingDriver.Do(func() { sql.Register("counting", countingDriver{}) })
+	dsn := strconv.FormatInt(countingDSNs.Add(1), 10)
+	d := &statementCounts{}
+	countsByDSN.Store(dsn, d)
+	db, err := sql.Open("counting", dsn)
+	if err != nil {
+		tb.Fatalf("sql.Open returned error: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func TestTokenCache(t *testing.T) {
+	db, d := newCountingDB(t)
+	c := NewTokenCache(db)
+	c.TTL = time.Minute
+	start := time.Now()
+
+	for i := 0; i < 10; i++ {
+		if _, err := c.Validate(testToken, start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("Validate #%d: %v", i+1, err)
+		}
+	}
+	if got := d.queries.Load(); got != 1 {
+		t.Errorf("queries after 10 validations within TTL = %d, want 1", got)
+	}
+	if got := d.execs.Load(); got != 0 {
+		t.Errorf("writes before Flush = %d, want 0", got)
+	}
+
+	if _, err := c.Validate(testToken, start.Add(time.Minute)); err != nil {
+		t.Fatalf("Validate after TTL: %v", err)
+	}
+	if got := d.queries.Load(); got != 2 {
+		t.Errorf("queries after TTL = %d, want 2", got)
+	}
+	c.Invalidate(testToken)
+	if _, err := c.Validate(testToken, start.Add(time.Minute)); err != nil {
+		t.Fatalf("Validate after Invalidate: %v", err)
+	}
+	if got := d.queries.Load(); got != 3 {
+		t.Errorf("queries after Invalidate = %d, want 3", got)
+	}
+
+	if err := c.Flush(start.Add(time.Minute)); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := d.execs.Load(); got != 1 {
+		t.Errorf("writes for last_used_at only = %d, want 1", got)
+	}
+	tok, _ := c.Validate(testToken, start.Add(time.Minute))
+	c.RecordClientInfo(tok, &pb.ClientInfo{Hostname: "box"}, start.Add(time.Minute))
+	if err := c.Flush(start.Add(time.Minute)); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := d.execs.Load(); got != 3 {
+		t.Errorf("writes after flushing client info = %d, want 3", got)
+	}
+	if err := c.Flush(start.Add(time.Minute)); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := d.execs.Load(); got != 3 {
+		t.Errorf("writes after empty Flush = %d, want 3", got)
+	}
+}
+
+func TestTokenCacheInvalidateDuringFetch(t *testing.T) {
+	db, d := newCountingDB(t)
+	d.started, d.gate = make(chan struct{}), make(chan struct{})
+	c := NewTokenCache(db)
+	now := time.Now()
+
+	done := make(chan error)
+	go func() {
+		_, err := c.Validate(testToken, now)
+		done <- err
+	}()
+	// The token is revoked while the read is in flight
+	<-d.started
+	c.Invalidate(testToken)
+	close(d.gate)
+	if err := <-done; err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	// The read from before the revocation must not have been cached
+	d.gate = nil
+	if _, err := c.Validate(testToken, now); err != nil {
+		t.Fatalf("Validate after Invalidate: %v", err)
+	}
+	if got := d.queries.Load(); got != 2 {
+		t.Errorf("queries = %d, want 2", got)
+	}
+	if _, err := c.Validate(testToken, now); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if got := d.queries.Load(); got != 2 {
+		t.Errorf("queries once cached again = %d, want 2", got)
+	}
+	if len(c.fetching) != 0 {
+		t.Errorf("%d reads still tracked, want none", len(c.fetching))
+	}
+}
+
+// benchmarkValidation runs one token validation per RPC and reports how many
+// statements reach the database per RPC, flushing as often as the server would.
+func benchmarkValidation(b *testing.B, ttl time.Duration) {
+	db, d := newCountingDB(b)
+	c := NewTokenCache(db)
+	c.TTL = ttl
+	info := &pb.ClientInfo{Hostname: "box"}
+	start := time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Pretend a thousand calls arrive per flush interval
+		now := start.Add(time.Duration(i) * c.FlushInterval / 1000)
+		tok, err := c.Validate(testToken, now)
+		if err != nil {
+			b.Fatal(err)
+		}
+		c.RecordClientInfo(tok, info, now)
+		if i%1000 == 999 {
+			if err := c.Flush(now); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.ReportMetric(float64(d.queries.Load()+d.execs.Load())/float64(b.N), "queries/rpc")
+}
+
+// BenchmarkTokenValidationDirect replays what every call did before the
+// cache: a select plus a last_used_at and a client info update.
+func BenchmarkTokenValidationDirect(b *testing.B) {
+	db, d := newCountingDB(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tok, err := queries.FetchAuthTokenByToken(db, testToken)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := db.Exec(`UPDATE auth_tokens SET last_used_at = $1 WHERE id = $2`, time.Now(), tok.ID); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := db.Exec(`UPDATE auth_tokens SET client_host = $1 WHERE id = $2`, "box", tok.ID); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(d.queries.Load()+d.execs.Load())/float64(b.N), "queries/rpc")
+}
+
+// BenchmarkTokenValidationExpired expires the cache on every call, leaving
+// only the batching of writes.
+func BenchmarkTokenValidationExpired(b *testing.B) { benchmarkValidation(b, time.Nanosecond) }
+
+func BenchmarkTokenValidationCached(b *testing.B) { benchmarkValidation(b, time.Minute) }
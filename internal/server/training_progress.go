@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+// trainingDataKey returns the storage key for a training game's data frame.
+func trainingDataKey(trainingRunID, gameNumber uint) string {
+	return fmt.Sprintf("training/run%d/training.%d.gz", trainingRunID, gameNumber)
+}
+
+// trainingPgnKey returns the storage key for a training game's compressed PGN.
+func trainingPgnKey(trainingRunID, gameNumber uint) string {
+	return fmt.Sprintf("pgn/run%d/%d.pgn.gz", trainingRunID, gameNumber)
+}
+
+// parseClientVersion converts a client version such as "v31" or "31" to the
+// integer form stored in the legacy version columns. Unparseable versions map to 0.
+func parseClientVersion(v string) uint {
+	n, err := strconv.ParseUint(strings.TrimPrefix(v, "v"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(n)
+}
+
+// handleTrainingProgress stores every uploaded game of a training assignment.
+// Each game gets the next game number of its training run and a training_games
+// row in one transaction. Its raw blobs are written to the storage backend only
+// once that commits, so a rolled back game number, which the next upload gets
+// again, never has blobs stored under it. A retried report, i.e. one whose
+// sequence number is not above the last one, is not stored again. If a blob
+// write fails the report is undone as far as possible (see failTrainingUpload)
+// so that the client's retry stores the games afresh.
+func (s *TaskServiceImpl) handleTrainingProgress(
+	ctx context.Context,
+	tok *models.AuthToken,
+	task *models.TaskAssignment,
+	progress *pb.TrainingProgress,
+	seq uint64,
+	now time.Time,
+) error {
+	games := progress.GetGames()
+	if len(games) == 0 {
+		return nil
+	}
+	if task.ParentTaskID == nil || task.NetworkID == nil {
+		return status.Error(codes.FailedPrecondition, "Task assignment is not linked to a training task")
+	}
+	if seq == 0 {
+		return status.Error(codes.InvalidArgument, "Training progress needs a sequence number")
+	}
+	tr, err := queries.FetchTrainingTaskByTaskID(s.DB, *task.ParentTaskID)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to load training task")
+	}
+	if tr.TrainingRunID == nil {
+		return status.Error(codes.FailedPrecondition, "Training task has no training run")
+	}
+	runID := *tr.TrainingRunID
+	var userID uint
+	if tok.UserID != nil {
+		userID = *tok.UserID
+	}
+
+	for _, game := range games {
+		if len(game.GetTrainingDataFrame()) == 0 {
+			return status.Error(codes.InvalidArgument, "Game is missing training data")
+		}
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	fresh, err := queries.AdvanceTaskAssignmentSequence(tx, task.ID, seq)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to record report sequence")
+	}
+	if !fresh {
+		return nil
+	}
+	gameIDs := make([]uint64, len(games))
+	gameNumbers := make([]uint, len(games))
+	for i := range games {
+		gameNumbers[i], err = queries.NextTrainingGameNumber(tx, runID)
+		if err != nil {
+			return status.Error(codes.Internal, "Failed to allocate game number")
+		}
+		gameIDs[i], err = queries.InsertTrainingGame(tx, &models.TrainingGame{
+			CreatedAt:     now,
+			UserID:        userID,
+			TrainingRunID: runID,
+			NetworkID:     *task.NetworkID,
+			GameNumber:    gameNumbers[i],
+			Version:       parseClientVersion(task.ClientVersion),
+			EngineVersion: task.EngineVersion,
+		})
+		if err != nil {
+			return status.Error(codes.Internal, "Failed to insert training game")
+		}
+	}
+	if err := queries.IncrementNetworkGamesPlayed(tx, *task.NetworkID, len(games)); err != nil {
+		return status.Error(codes.Internal, "Failed to update network game count")
+	}
+	if err := queries.AddTaskAssignmentGames(tx, task.ID, len(games)); err != nil {
+		return status.Error(codes.Internal, "Failed to count task games")
+	}
+	if err := tx.Commit(); err != nil {
+		return status.Error(codes.Internal, "Database error")
+	}
+
+	for i, game := range games {
+		err := s.Store.Put(ctx, trainingDataKey(runID, gameNumbers[i]), game.GetTrainingDataFrame())
+		if err == nil && len(game.GetCompressedPgn()) > 0 {
+			err = s.Store.Put(ctx, trainingPgnKey(runID, gameNumbers[i]), game.GetCompressedPgn())
+		}
+		if err != nil {
+			log.Printf("training run %d game %d: storing blobs: %v", runID, gameNumbers[i], err)
+			if err := s.failTrainingUpload(ctx, task, gameIDs, seq); err != nil {
+				log.Printf("task %s: undoing failed upload: %v", task.TaskID, err)
+			}
+			return status.Error(codes.Internal, "Failed to store training data")
+		}
+	}
+	return nil
+}
+
+// failTrainingUpload undoes a training report whose blobs could not all be
+// stored: its games are flagged as having no data and leave the game counts,
+// and its sequence number is released so the client's retry is stored under
+// new game numbers. The game numbers of the failed upload stay used.
+func (s *TaskServiceImpl) failTrainingUpload(ctx context.Context, task *models.TaskAssignment, gameIDs []uint64, seq uint64) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := queries.MarkTrainingGamesUploadFailed(tx, gameIDs); err != nil {
+		return err
+	}
+	if err := queries.IncrementNetworkGamesPlayed(tx, *task.NetworkID, -len(gameIDs)); err != nil {
+		return err
+	}
+	if err := queries.AddTaskAssignmentGames(tx, task.ID, -len(gameIDs)); err != nil {
+		return err
+	}
+	if err := queries.RewindTaskAssignmentSequence(tx, task.ID, seq); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
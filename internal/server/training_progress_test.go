@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+var trainingTaskColumns = []string{"id", "created_at", "updated_at", "task_id", "training_run_id", "train_book_id", "match_book_id",
+	"best_network_id", "train_parameters", "match_parameters", "active", "description"}
+
+// recordingStore is a storage.Store remembering the keys it was given. Puts
+// under failKey fail.
+type recordingStore struct {
+	keys    []string
+	failKey string
+}
+
+func (s *recordingStore) Put(_ context.Context, key string, _ []byte) error {
+	if key == s.failKey {
+		return errors.New("bucket unavailable")
+	}
+	s.keys = append(s.keys, key)
+	return nil
+}
+
+func TestHandleTrainingProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		retry     bool
+		commitErr error
+		failKey   string
+		wantCode  codes.Code
+		wantKeys  []string
+	}{
+		{"stored after commit", false, nil, "", codes.OK, []string{"training/run4/training.8.gz", "pgn/run4/8.pgn.gz", "training/run4/training.9.gz"}},
+		{"nothing stored when commit fails", false, errors.New("serialization failure"), "", codes.Internal, nil},
+		{"retried report", true, nil, "", codes.OK, nil},
+		{"upload undone when a put fails", false, nil, "training/run4/training.9.gz", codes.Internal, []string{"training/run4/training.8.gz", "pgn/run4/8.pgn.gz"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectQuery("FROM training_tasks").WithArgs(3).WillReturnRows(
+				sqlmock.NewRows(trainingTaskColumns).AddRow(1, now, now, 3, 4, 0, 0, 6, "", "", true, ""))
+			mock.ExpectBegin()
+			if tt.retry {
+				mock.ExpectExec("SET last_sequence").WithArgs(5, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec("SET last_sequence").WithArgs(5, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+				for _, n := range []int{8, 9} {
+					mock.ExpectQuery("UPDATE training_runs").WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"last_game"}).AddRow(n))
+					mock.ExpectQuery("INSERT INTO training_games").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(n + 100))
+				}
+				mock.ExpectExec("UPDATE networks").WithArgs(2, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("SET games_reported").WithArgs(2, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+				if tt.commitErr != nil {
+					mock.ExpectCommit().WillReturnError(tt.commitErr)
+				} else {
+					mock.ExpectCommit()
+				}
+			}
+			if tt.failKey != "" {
+				// The games lose their counts and the retry gets new numbers
+				mock.ExpectBegin()
+				mock.ExpectExec("SET upload_failed").WithArgs("{108,109}").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE networks").WithArgs(-2, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("SET games_reported").WithArgs(-2, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("last_sequence = \\$1 - 1").WithArgs(5, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			store := &recordingStore{failKey: tt.failKey}
+			s := &TaskServiceImpl{DB: db, Store: store}
+			parent, net := uint(3), uint(6)
+			task := &models.TaskAssignment{ID: 9, ParentTaskID: &parent, NetworkID: &net}
+			progress := &pb.TrainingProgress{Games: []*pb.GameData{
+				{TrainingDataFrame: []byte{1}, CompressedPgn: []byte{2}},
+				{TrainingDataFrame: []byte{3}},
+			}}
+			err = s.handleTrainingProgress(context.Background(), &models.AuthToken{}, task, progress, 5, now)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("handleTrainingProgress code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if !reflect.DeepEqual(store.keys, tt.wantKeys) {
+				t.Errorf("stored keys = %v, want %v", store.keys, tt.wantKeys)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
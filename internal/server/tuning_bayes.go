@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/tuning"
+)
+
+// unknownEloStdDev is the measurement error assumed for a parameter set whose
+// Elo has no finite confidence interval, e.g. because it won every pair.
+const unknownEloStdDev = 200
+
+// maxObservedElo bounds the Elo of an observation, so that a set that won or
+// lost every pair, whose Elo is infinite, still counts as very good or bad.
+const maxObservedElo = 1000
+
+// bayesOptimizer returns the Bayesian optimization settings of a tuning task.
+func bayesOptimizer(tt *models.TuneTask) tuning.Bayes {
+	b := tuning.Bayes{Acquisition: tuning.ExpectedImprovement, Kappa: tt.BayesKappa}
+	if tt.BayesAcquisition == models.BayesAcquisitionUCB {
+		b.Acquisition = tuning.UpperConfidenceBound
+	}
+	return b
+}
+
+// tuneObservation returns the measured Elo of a finished parameter set, or
+// false if it is unfinished or lacks one of the parameters of space.
+func tuneObservation(space []tuning.Param, ps *models.TuneParamSet, gamesPerParamSet int32) (tuning.Observation, bool) {
+	if ps.GamesPlayed < int(gamesPerParamSet) {
+		return tuning.Observation{}, false
+	}
+	var options map[string]string
+	if err := json.Unmarshal([]byte(ps.ParamsUciOptions), &options); err != nil {
+		return tuning.Observation{}, false
+	}
+	obs := tuning.Observation{Values: make([]float64, len(space)), Elo: ps.Elo}
+	for i, p := range space {
+		v, err := strconv.ParseFloat(options[p.Name], 64)
+		if err != nil {
+			return tuning.Observation{}, false
+		}
+		obs.Values[i] = v
+	}
+	// The stored interval is a 95% one
+	obs.EloStdDev = (ps.EloUpper - ps.EloLower) / (2 * 1.96)
+	if !(obs.EloStdDev > 0) || math.IsInf(obs.EloStdDev, 0) {
+		obs.EloStdDev = unknownEloStdDev
+	}
+	if math.IsNaN(obs.Elo) {
+		obs.Elo = 0
+	}
+	obs.Elo = math.Max(-maxObservedElo, math.Min(maxObservedElo, obs.Elo))
+	return obs, true
+}
+
+// tuneObservations returns the observations of every finished parameter set,
+// with the sets they came from.
+func tuneObservations(space []tuning.Param, sets []models.TuneParamSet, gamesPerParamSet int32) ([]tuning.Observation, []*models.TuneParamSet) {
+	var obs []tuning.Observation
+	var from []*models.TuneParamSet
+	for i := range sets {
+		if o, ok := tuneObservation(space, &sets[i], gamesPerParamSet); ok {
+			obs = append(obs, o)
+			from = append(from, &sets[i])
+		}
+	}
+	return obs, from
+}
+
+// bayesianBatch returns the next batch of parameter sets of a Bayesian tuning
+// task with the given params and sets, chosen by the surrogate fitted to every
+// finished set, or none once the task has max_points sets.
+func bayesianBatch(tt *models.TuneTask, params []models.TuneParam, sets []models.TuneParamSet) ([]models.TuneParamSet, error) {
+	n := min(tt.BayesBatchSize, tt.BayesMaxPoints-len(sets))
+	if n <= 0 {
+		return nil, nil
+	}
+	space, _ := tuneSpace(params)
+	obs, _ := tuneObservations(space, sets, tt.GamesPerParamSet)
+	// Seeded by the task and its progress, so a batch can be reproduced offline
+	rng := rand.New(rand.NewPCG(uint64(tt.ID), uint64(len(sets))))
+	points, err := bayesOptimizer(tt).Propose(space, obs, n, rng)
+	if err != nil {
+		// Keep the task going on random points rather than stalling it
+		log.Printf("tune task %d: proposing parameter sets: %v", tt.ID, err)
+		if points, err = tuning.Random(space, n, rng); err != nil {
+			return nil, fmt.Errorf("drawing random parameter sets: %w", err)
+		}
+	}
+	existing := make(map[string]bool, len(sets))
+	for _, ps := range sets {
+		existing[ps.ParamSetID] = true
+	}
+	var batch []models.TuneParamSet
+	for _, pt := range points {
+		// A set whose options could not be read is no observation, so may come up again
+		if existing[pt.ID()] {
+			continue
+		}
+		ps, err := tuneParamSet(tt, pt.ID(), pt)
+		if err != nil {
+			return nil, err
+		}
+		existing[pt.ID()] = true
+		batch = append(batch, ps)
+	}
+	if len(batch) == 0 {
+		return nil, errors.New("every proposed parameter set was played already")
+	}
+	return batch, nil
+}
+
+// proposeBayesianBatch adds the next batch of parameter sets to the Bayesian
+// tuning task extending base task taskID once every set has finished, and
+// marks the task DONE once it has max_points sets. The surrogate is fitted
+// without holding any lock, so reports are not held up by it; the batch is
+// only stored if no other report added sets in the meantime.
+func (s *TaskServiceImpl) proposeBayesianBatch(ctx context.Context, taskID uint) error {
+	tt, err := queries.FetchTuneTaskByTaskID(s.DB, taskID)
+	if err != nil {
+		return err
+	}
+	params, err := queries.FetchTuneParams(s.DB, tt.ID)
+	if err != nil {
+		return err
+	}
+	sets, err := queries.FetchRankedTuneParamSets(s.DB, tt.ID)
+	if err != nil {
+		return err
+	}
+	batch, err := bayesianBatch(tt, params, sets)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Locking the task serializes this with reports and other proposals
+	locked, err := queries.FetchTuneTaskByTaskIDForUpdate(tx, taskID)
+	if err != nil {
+		return err
+	}
+	if locked.Task.Status != models.TaskStatusActive {
+		return nil
+	}
+	count, err := queries.CountTuneParamSets(tx, tt.ID)
+	if err != nil {
+		return err
+	}
+	pending, err := queries.CountPendingTuneParamSets(tx, tt.ID, tt.GamesPerParamSet)
+	if err != nil {
+		return err
+	}
+	if count != len(sets) || pending > 0 {
+		return nil
+	}
+	if len(batch) == 0 {
+		if err := queries.UpdateTaskStatus(tx, taskID, models.TaskStatusDone); err != nil {
+			return err
+		}
+	}
+	for i := range batch {
+		if _, err := queries.InsertTuneParamSet(tx, &batch[i]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
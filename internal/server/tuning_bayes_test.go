@@ -0,0 +1,60 @@
+package server
+
+import (
+	"math"
+	"testing"
+
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/tuning"
+)
+
+func TestTuneObservation(t *testing.T) {
+	space := []tuning.Param{{Name: "CPuct", Min: 0, Max: 5}}
+	ps := &models.TuneParamSet{ParamsUciOptions: `{"CPuct":"1.5","Threads":"2"}`, GamesPlayed: 100, Elo: 12, EloLower: 2.2, EloUpper: 21.8}
+	obs, ok := tuneObservation(space, ps, 100)
+	if !ok || obs.Values[0] != 1.5 || obs.Elo != 12 || math.Abs(obs.EloStdDev-5) > 1e-9 {
+		t.Errorf("tuneObservation = %+v, %v, want CPuct 1.5, Elo 12 ± 5", obs, ok)
+	}
+	if _, ok := tuneObservation(space, ps, 200); ok {
+		t.Error("tuneObservation accepted an unfinished set")
+	}
+
+	ps.EloLower, ps.EloUpper = math.Inf(-1), math.Inf(1)
+	if obs, ok := tuneObservation(space, ps, 100); !ok || obs.Elo != 12 || obs.EloStdDev != unknownEloStdDev {
+		t.Errorf("tuneObservation with an unbounded interval = %+v, %v, want Elo 12 ± %d", obs, ok, unknownEloStdDev)
+	}
+	// Sets that won or lost every pair keep the sign of their Elo
+	for _, elo := range []float64{math.Inf(1), math.Inf(-1)} {
+		ps.Elo, ps.EloLower, ps.EloUpper = elo, elo, elo
+		want := math.Copysign(maxObservedElo, elo)
+		if obs, ok := tuneObservation(space, ps, 100); !ok || obs.Elo != want || obs.EloStdDev != unknownEloStdDev {
+			t.Errorf("tuneObservation with Elo %v = %+v, %v, want Elo %v ± %d", elo, obs, ok, want, unknownEloStdDev)
+		}
+	}
+}
+
+func TestBayesianBatch(t *testing.T) {
+	tt := &models.TuneTask{ID: 5, GamesPerParamSet: 10, BayesBatchSize: 2, BayesMaxPoints: 4}
+	params := []models.TuneParam{{Name: "CPuct", Min: 0, Max: 5}}
+	sets := []models.TuneParamSet{
+		{ParamSetID: "a", ParamsUciOptions: `{"CPuct":"1"}`, GamesPlayed: 10, Elo: -20, EloLower: -40, EloUpper: 0},
+		{ParamSetID: "b", ParamsUciOptions: `{"CPuct":"4"}`, GamesPlayed: 10, Elo: math.Inf(1), EloLower: math.Inf(1), EloUpper: math.Inf(1)},
+	}
+	batch, err := bayesianBatch(tt, params, sets)
+	if err != nil {
+		t.Fatalf("bayesianBatch returned error: %v", err)
+	}
+	if len(batch) != 2 || batch[0].ParamSetID == batch[1].ParamSetID {
+		t.Errorf("bayesianBatch = %+v, want 2 distinct sets", batch)
+	}
+	for _, ps := range batch {
+		if ps.TuneTaskID != tt.ID || ps.AssignmentID != nil {
+			t.Errorf("bayesianBatch set %+v, want an unclaimed set of task %d", ps, tt.ID)
+		}
+	}
+
+	tt.BayesMaxPoints = 2
+	if batch, err := bayesianBatch(tt, params, sets); err != nil || batch != nil {
+		t.Errorf("bayesianBatch at max_points = %+v, %v, want no sets", batch, err)
+	}
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/sprt"
+)
+
+// foldTuningPairs adds reported game pairs to a parameter set until it has
+// gamesPerParamSet games and returns the pairs it counted. Every counted game
+// counts towards games_played; only complete pairs enter the pentanomial
+// counters. In tuning games the "candidate" is the engine running the set.
+func foldTuningPairs(ps *models.TuneParamSet, pairs []*pb.TuningPairResult, gamesPerParamSet int32) []*pb.TuningPairResult {
+	counts := []int{ps.LL, ps.LD, ps.DD, ps.DW, ps.WW}
+	n := 0
+	for _, pair := range pairs {
+		if ps.GamesPlayed >= int(gamesPerParamSet) {
+			break
+		}
+		if pair.GetGame1() != nil {
+			ps.GamesPlayed++
+		}
+		if pair.GetGame2() != nil {
+			ps.GamesPlayed++
+		}
+		if idx, ok := pairIndex(pair.GetGame1(), pair.GetGame2()); ok {
+			counts[idx]++
+		}
+		n++
+	}
+	ps.LL, ps.LD, ps.DD, ps.DW, ps.WW = counts[0], counts[1], counts[2], counts[3], counts[4]
+	if lower, elo, upper, err := sprt.EloPentanomial(counts); err == nil {
+		ps.EloLower, ps.Elo, ps.EloUpper = lower, elo, upper
+	}
+	return pairs[:n]
+}
+
+// handleTuningProgress folds reported results into the parameter sets of the
+// tuning task that were handed to the assignment, ignoring games beyond
+// games_per_param_set, and completes the assignment once all of its sets
+// (or its SPSA iteration) have their games. The task is marked DONE once every
+// set has played games_per_param_set games. SPSA tasks also update their values from each
+// result, and are DONE once they have run all their iterations. Bayesian tasks
+// get their next batch of sets instead, proposed after the report commits,
+// until they reach max_points. A retried report, i.e. one whose sequence
+// number is not above the last one, is not counted again.
+func (s *TaskServiceImpl) handleTuningProgress(
+	ctx context.Context,
+	task *models.TaskAssignment,
+	progress *pb.TuningProgress,
+	seq uint64,
+	now time.Time,
+) error {
+	results := progress.GetResults()
+	if len(results) == 0 {
+		return nil
+	}
+	if task.ParentTaskID == nil {
+		return status.Error(codes.FailedPrecondition, "Task assignment is not linked to a tuning task")
+	}
+	if seq == 0 {
+		return status.Error(codes.InvalidArgument, "Tuning progress needs a sequence number")
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	// Locking the task serializes reports
+	tt, err := queries.FetchTuneTaskByTaskIDForUpdate(tx, *task.ParentTaskID)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to load tuning task")
+	}
+	fresh, err := queries.AdvanceTaskAssignmentSequence(tx, task.ID, seq)
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to record report sequence")
+	}
+	if !fresh {
+		return nil
+	}
+
+	var iterationDone bool
+	games := 0
+	for _, result := range results {
+		ps, err := queries.FetchTuneParamSetForUpdate(tx, tt.ID, result.GetParamSetId())
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.InvalidArgument, "Unknown parameter set %q", result.GetParamSetId())
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "Failed to load parameter set")
+		}
+		if ps.AssignmentID == nil || *ps.AssignmentID != task.ID {
+			return status.Errorf(codes.PermissionDenied, "Parameter set %q was not handed to this task", result.GetParamSetId())
+		}
+		played := ps.GamesPlayed
+		pairs := foldTuningPairs(ps, result.GetPairs(), tt.GamesPerParamSet)
+		games += ps.GamesPlayed - played
+		if err := queries.UpdateTuneParamSetResults(tx, ps); err != nil {
+			return status.Error(codes.Internal, "Failed to update parameter set")
+		}
+		if tt.Mode == models.TuneModeSpsa {
+			if iterationDone, err = foldSpsaResult(tx, tt, ps.ParamSetID, pairs, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := queries.AddTaskAssignmentGames(tx, task.ID, games); err != nil {
+		return status.Error(codes.Internal, "Failed to count task games")
+	}
+
+	var assignmentDone bool
+	if tt.Mode == models.TuneModeSpsa {
+		assignmentDone = iterationDone
+	} else {
+		held, err := queries.CountPendingTuneParamSetsOfAssignment(tx, task.ID, tt.GamesPerParamSet)
+		if err != nil {
+			return status.Error(codes.Internal, "Database error")
+		}
+		assignmentDone = held == 0
+	}
+	if assignmentDone {
+		if _, err := queries.CompleteTaskAssignment(tx, task.ID, now); err != nil {
+			return status.Error(codes.Internal, "Failed to complete task")
+		}
+	}
+
+	var done, propose bool
+	if tt.Mode == models.TuneModeSpsa {
+		done = tt.SpsaCompleted >= tt.SpsaIterations
+	} else {
+		pending, err := queries.CountPendingTuneParamSets(tx, tt.ID, tt.GamesPerParamSet)
+		if err != nil {
+			return status.Error(codes.Internal, "Database error")
+		}
+		// A Bayesian task is done once no further batch can be proposed
+		propose = pending == 0 && tt.Mode == models.TuneModeBayesian
+		done = pending == 0 && !propose
+	}
+	if done {
+		if err := queries.UpdateTaskStatus(tx, tt.TaskID, models.TaskStatusDone); err != nil {
+			return status.Error(codes.Internal, "Failed to finish tuning task")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return status.Error(codes.Internal, "Database error")
+	}
+	if assignmentDone {
+		task.Status = models.TaskStatusDone
+		task.CompletedAt = &now
+	}
+	if propose {
+		// The results are stored, so a failed proposal must not fail the report
+		if err := s.proposeBayesianBatch(ctx, tt.TaskID); err != nil {
+			log.Printf("tune task %d: proposing parameter sets: %v", tt.ID, err)
+		}
+	}
+	return nil
+}
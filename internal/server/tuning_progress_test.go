@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+)
+
+var tuneTaskColumns = []string{"id", "created_at", "updated_at", "task_id", "build_repo_url", "build_commit_hash", "build_params",
+	"tune_network_id", "opening_book_id", "games_per_param_set", "mode", "params_args", "params_uci_options",
+	"time_control_type", "base_time_seconds", "increment_seconds", "nodes_per_move",
+	"spsa_a", "spsa_c", "spsa_stability", "spsa_alpha", "spsa_gamma", "spsa_iterations", "spsa_completed",
+	"bayes_initial_points", "bayes_batch_size", "bayes_max_points", "bayes_acquisition", "bayes_kappa",
+	"status", "description"}
+
+func TestFoldTuningPairs(t *testing.T) {
+	win := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_WHITE_WIN, CandidateIsWhite: true}
+	ps := &models.TuneParamSet{GamesPlayed: 6, WW: 3}
+	pairs := []*pb.TuningPairResult{{Game1: win, Game2: win}, {Game1: win}, {Game1: win, Game2: win}}
+	counted := foldTuningPairs(ps, pairs, 9)
+	if len(counted) != 2 || ps.GamesPlayed != 9 || ps.WW != 4 {
+		t.Errorf("foldTuningPairs counted %d pairs, set has %d games and %d WW, want 2 pairs, 9 games, 4 WW", len(counted), ps.GamesPlayed, ps.WW)
+	}
+	if counted := foldTuningPairs(ps, pairs, 9); len(counted) != 0 || ps.GamesPlayed != 9 {
+		t.Errorf("foldTuningPairs on a full set counted %d pairs, set has %d games", len(counted), ps.GamesPlayed)
+	}
+}
+
+func TestHandleTuningProgress(t *testing.T) {
+	tests := []struct {
+		name         string
+		holder       any // assignment_id of the reported set
+		played       int
+		heldPending  int
+		wantCode     codes.Code
+		wantComplete bool
+	}{
+		{"set of another assignment", 8, 0, 0, codes.PermissionDenied, false},
+		{"unclaimed set", nil, 0, 0, codes.PermissionDenied, false},
+		{"games left", 9, 0, 1, codes.OK, false},
+		{"last games of the assignment", 9, 8, 0, codes.OK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			mock.ExpectQuery("FROM tune_tasks").WithArgs(3).WillReturnRows(sqlmock.NewRows(tuneTaskColumns).AddRow(
+				5, now, now, 3, "", "", "", 1, 2, 10, models.TuneModeGrid, "", "", "nodes", 0.0, 0.0, 100,
+				0.0, 0.0, 0.0, 0.0, 0.0, 0, 0, 0, 0, 0, "", 0.0, models.TaskStatusActive, ""))
+			mock.ExpectExec("SET last_sequence").WithArgs(1, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery("FROM tune_param_sets").WithArgs(5, "ps-1").WillReturnRows(sqlmock.NewRows(tuneParamSetColumns).AddRow(
+				20, 5, "ps-1", "", "", tt.played, 0, 0, 0, 0, 0, 0.0, 0.0, 0.0, tt.holder))
+			if tt.wantCode == codes.OK {
+				mock.ExpectExec("UPDATE tune_param_sets").WithArgs(min(tt.played+2, 10), 0, 0, 1, 0, 0,
+					sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 20).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("SET games_reported").WithArgs(min(tt.played+2, 10)-tt.played, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("WHERE assignment_id").WithArgs(9, 10).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.heldPending))
+				if tt.wantComplete {
+					mock.ExpectExec("UPDATE task_assignments").WithArgs(models.TaskStatusDone, now, 9, models.TaskStatusActive).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectQuery("SELECT COUNT").WithArgs(5, 10).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			parent := uint(3)
+			task := &models.TaskAssignment{ID: 9, ParentTaskID: &parent, Status: models.TaskStatusActive}
+			s := &TaskServiceImpl{DB: db}
+			draw := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_DRAW}
+			progress := &pb.TuningProgress{Results: []*pb.TuningParamSetResult{
+				{ParamSetId: "ps-1", Pairs: []*pb.TuningPairResult{{Game1: draw, Game2: draw}}},
+			}}
+			err = s.handleTuningProgress(context.Background(), task, progress, 1, now)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("handleTuningProgress code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if done := task.Status == models.TaskStatusDone; done != tt.wantComplete {
+				t.Errorf("assignment status = %s, want completed %v", task.Status, tt.wantComplete)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestHandleTuningProgressRetry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	taskRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(tuneTaskColumns).AddRow(
+			5, now, now, 3, "", "", "", 1, 2, 10, models.TuneModeGrid, "", "", "nodes", 0.0, 0.0, 100,
+			0.0, 0.0, 0.0, 0.0, 0.0, 0, 0, 0, 0, 0, "", 0.0, models.TaskStatusActive, "")
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM tune_tasks").WithArgs(3).WillReturnRows(taskRows())
+	mock.ExpectExec("SET last_sequence").WithArgs(4, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("FROM tune_param_sets").WithArgs(5, "ps-1").WillReturnRows(sqlmock.NewRows(tuneParamSetColumns).AddRow(
+		20, 5, "ps-1", "", "", 0, 0, 0, 0, 0, 0, 0.0, 0.0, 0.0, 9))
+	mock.ExpectExec("UPDATE tune_param_sets").WithArgs(2, 0, 0, 1, 0, 0,
+		sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 20).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SET games_reported").WithArgs(2, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("WHERE assignment_id").WithArgs(9, 10).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT COUNT").WithArgs(5, 10).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectCommit()
+	// The retry finds its sequence number already counted
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM tune_tasks").WithArgs(3).WillReturnRows(taskRows())
+	mock.ExpectExec("SET last_sequence").WithArgs(4, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	parent := uint(3)
+	task := &models.TaskAssignment{ID: 9, ParentTaskID: &parent, Status: models.TaskStatusActive}
+	s := &TaskServiceImpl{DB: db}
+	draw := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_DRAW}
+	progress := &pb.TuningProgress{Results: []*pb.TuningParamSetResult{
+		{ParamSetId: "ps-1", Pairs: []*pb.TuningPairResult{{Game1: draw, Game2: draw}}},
+	}}
+	for i := 0; i < 2; i++ {
+		if err := s.handleTuningProgress(context.Background(), task, progress, 4, now); err != nil {
+			t.Fatalf("handleTuningProgress report %d returned error: %v", i+1, err)
+		}
+	}
+	if err := s.handleTuningProgress(context.Background(), task, progress, 0, now); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("handleTuningProgress without a sequence code = %v, want InvalidArgument", status.Code(err))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet database expectations: %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/tuning"
+)
+
+// spsaParamSetID returns the parameter set ID of one side of an SPSA iteration.
+func spsaParamSetID(iterationID uint, plus bool) string {
+	if plus {
+		return fmt.Sprintf("spsa-%d-plus", iterationID)
+	}
+	return fmt.Sprintf("spsa-%d-minus", iterationID)
+}
+
+// parseSpsaParamSetID is the inverse of spsaParamSetID.
+func parseSpsaParamSetID(id string) (iterationID uint, plus bool, ok bool) {
+	var side string
+	if _, err := fmt.Sscanf(id, "spsa-%d-%s", &iterationID, &side); err != nil {
+		return 0, false, false
+	}
+	if side != "plus" && side != "minus" {
+		return 0, false, false
+	}
+	return iterationID, side == "plus", true
+}
+
+// spsaGains returns the SPSA gains of a tuning task.
+func spsaGains(tt *models.TuneTask) tuning.SPSA {
+	return tuning.SPSA{A: tt.SpsaA, C: tt.SpsaC, Stability: tt.SpsaStability, Alpha: tt.SpsaAlpha, Gamma: tt.SpsaGamma}
+}
+
+// spsaValues encodes the current values of SPSA parameters as name -> value.
+func spsaValues(params []models.TuneParam) map[string]float64 {
+	values := make(map[string]float64, len(params))
+	for _, p := range params {
+		values[p.Name] = p.Value
+	}
+	return values
+}
+
+// maxOpenSpsaIterations caps how many iterations of an SPSA tuning task are
+// played at once. Every open iteration is drawn around values that are stale
+// by the time it completes, so more clients than this wait for other tasks.
+const maxOpenSpsaIterations = 8
+
+// nextSpsaParamSets returns the parameter sets of an SPSA iteration for the
+// assignment assignmentID. An open iteration released by the reaper is played
+// again before a new one is drawn, and no iteration is drawn while
+// maxOpenSpsaIterations are open or the open ones complete the task.
+func nextSpsaParamSets(tx queries.Querier, tt *models.TuneTask, assignmentID uint) ([]models.TuneParamSet, error) {
+	open, err := queries.FetchOpenSpsaIterations(tx, tt.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load SPSA iterations")
+	}
+	for _, it := range open {
+		sets, err := queries.ClaimSpsaParamSets(tx, tt.ID, assignmentID, spsaParamSetID(it.ID, true), spsaParamSetID(it.ID, false))
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to claim parameter sets")
+		}
+		if len(sets) > 0 {
+			return sets, nil
+		}
+	}
+	if len(open) >= maxOpenSpsaIterations || tt.SpsaCompleted+len(open) >= tt.SpsaIterations {
+		return nil, nil
+	}
+	return newSpsaIteration(tx, tt, assignmentID)
+}
+
+// newSpsaIteration draws a perturbation around the current values of an SPSA
+// tuning task and stores it with its plus and minus parameter sets, held by
+// the assignment assignmentID.
+func newSpsaIteration(tx queries.Querier, tt *models.TuneTask, assignmentID uint) ([]models.TuneParamSet, error) {
+	params, err := queries.FetchTuneParams(tx, tt.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to load SPSA parameters")
+	}
+	space, values := tuneSpace(params)
+	pt := spsaGains(tt).Perturb(space, values, tt.SpsaCompleted, rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())))
+	delta := make(map[string]float64, len(space))
+	for i, p := range space {
+		delta[p.Name] = pt.Delta[i]
+	}
+	deltaJSON, err := json.Marshal(delta)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to encode SPSA perturbation")
+	}
+	it := &models.SpsaIteration{TuneTaskID: tt.ID, Iteration: tt.SpsaCompleted, Delta: string(deltaJSON), CK: pt.CK}
+	it.ID, err = queries.InsertSpsaIteration(tx, it)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to insert SPSA iteration")
+	}
+
+	var sets []models.TuneParamSet
+	for _, side := range []struct {
+		plus   bool
+		values []float64
+	}{{true, pt.Plus}, {false, pt.Minus}} {
+		ps, err := tuneParamSet(tt, spsaParamSetID(it.ID, side.plus), tuning.PointAt(space, side.values))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Tuning task %d: %v", tt.ID, err)
+		}
+		ps.AssignmentID = &assignmentID
+		ps.ID, err = queries.InsertTuneParamSet(tx, &ps)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to insert parameter set")
+		}
+		sets = append(sets, ps)
+	}
+	return sets, nil
+}
+
+// spsaScore returns how many games of the pairs were reported and the wins
+// minus losses among them, from the reporting set's side.
+func spsaScore(pairs []*pb.TuningPairResult) (games, score int) {
+	for _, pair := range pairs {
+		for _, game := range []*pb.MatchGame{pair.GetGame1(), pair.GetGame2()} {
+			if game == nil {
+				continue
+			}
+			games++
+			if halfPoints, ok := candidateHalfPoints(game); ok {
+				score += halfPoints - 1
+			}
+		}
+	}
+	return games, score
+}
+
+// foldSpsaResult moves the values of an SPSA tuning task along the gradient
+// estimated from pairs reported for one side of an iteration. Once the
+// iteration has played games_per_param_set games it is completed, recording
+// the values in its history. Games reported after that are ignored. It
+// reports whether the iteration is completed. Every call takes a step, so
+// callers must not pass a retried report twice; handleTuningProgress drops
+// those by their sequence number.
+func foldSpsaResult(db queries.Querier, tt *models.TuneTask, paramSetID string, pairs []*pb.TuningPairResult, now time.Time) (bool, error) {
+	iterationID, plus, ok := parseSpsaParamSetID(paramSetID)
+	if !ok {
+		return false, status.Errorf(codes.InvalidArgument, "Parameter set %q is not part of an SPSA iteration", paramSetID)
+	}
+	it, err := queries.FetchSpsaIterationForUpdate(db, tt.ID, iterationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, status.Errorf(codes.InvalidArgument, "Unknown SPSA iteration %d", iterationID)
+	}
+	if err != nil {
+		return false, status.Error(codes.Internal, "Failed to load SPSA iteration")
+	}
+	if it.CompletedAt != nil {
+		return true, nil
+	}
+	games, score := spsaScore(pairs)
+	if !plus {
+		score = -score
+	}
+
+	params, err := queries.FetchTuneParamsForUpdate(db, tt.ID)
+	if err != nil {
+		return false, status.Error(codes.Internal, "Failed to load SPSA parameters")
+	}
+	var delta map[string]float64
+	if err := json.Unmarshal([]byte(it.Delta), &delta); err != nil {
+		return false, status.Errorf(codes.Internal, "SPSA iteration %d: %v", it.ID, err)
+	}
+	space, values := tuneSpace(params)
+	signs := make([]float64, len(space))
+	for i, p := range space {
+		signs[i] = delta[p.Name]
+		if signs[i] == 0 {
+			return false, status.Errorf(codes.Internal, "SPSA iteration %d has no perturbation of %s", it.ID, p.Name)
+		}
+	}
+	if score != 0 {
+		next := spsaGains(tt).Update(space, values, signs, it.CK, it.Iteration, float64(score))
+		for i := range params {
+			params[i].Value = next[i]
+			if err := queries.UpdateTuneParamValue(db, params[i].ID, next[i]); err != nil {
+				return false, status.Error(codes.Internal, "Failed to update SPSA parameter")
+			}
+		}
+	}
+
+	it.GamesPlayed += games
+	it.Score += score
+	if it.GamesPlayed >= int(tt.GamesPerParamSet) {
+		valuesJSON, err := json.Marshal(spsaValues(params))
+		if err != nil {
+			return false, status.Error(codes.Internal, "Failed to encode SPSA values")
+		}
+		it.CompletedAt = &now
+		it.ValuesAfter = string(valuesJSON)
+		tt.SpsaCompleted, err = queries.IncrementSpsaCompleted(db, tt.ID, now)
+		if err != nil {
+			return false, status.Error(codes.Internal, "Failed to complete SPSA iteration")
+		}
+	}
+	if err := queries.UpdateSpsaIterationResults(db, it); err != nil {
+		return false, status.Error(codes.Internal, "Failed to update SPSA iteration")
+	}
+	return it.CompletedAt != nil, nil
+}
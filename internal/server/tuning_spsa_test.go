@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/tuning"
+)
+
+func TestSpsaParamSetID(t *testing.T) {
+	for _, plus := range []bool{true, false} {
+		id := spsaParamSetID(42, plus)
+		iterationID, gotPlus, ok := parseSpsaParamSetID(id)
+		if !ok || iterationID != 42 || gotPlus != plus {
+			t.Errorf("parseSpsaParamSetID(%q) = %d, %v, %v, want 42, %v, true", id, iterationID, gotPlus, ok, plus)
+		}
+	}
+	for _, id := range []string{"ps-0123456789ab", "spsa-1-both", "spsa-x-plus"} {
+		if _, _, ok := parseSpsaParamSetID(id); ok {
+			t.Errorf("parseSpsaParamSetID(%q) succeeded", id)
+		}
+	}
+}
+
+func TestSpsaScore(t *testing.T) {
+	win := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_WHITE_WIN, CandidateIsWhite: true}
+	loss := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_WHITE_WIN, CandidateIsWhite: false}
+	draw := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_DRAW}
+	undecided := &pb.MatchGame{}
+	games, score := spsaScore([]*pb.TuningPairResult{
+		{Game1: win, Game2: win},
+		{Game1: draw, Game2: loss},
+		{Game1: undecided},
+	})
+	if games != 5 || score != 1 {
+		t.Errorf("spsaScore = %d games, score %d, want 5 games, score 1", games, score)
+	}
+}
+
+var (
+	spsaIterationColumns = []string{"id", "created_at", "tune_task_id", "iteration", "delta", "ck", "games_played", "score", "completed_at", "values_after"}
+	tuneParamSetColumns  = []string{"id", "tune_task_id", "param_set_id", "params_args", "params_uci_options", "games_played",
+		"ll", "ld", "dd", "dw", "ww", "elo", "elo_lower", "elo_upper", "assignment_id"}
+	tuneParamColumns = []string{"id", "tune_task_id", "name", "min_value", "max_value", "step", "value"}
+)
+
+func TestNextSpsaParamSets(t *testing.T) {
+	tests := []struct {
+		name      string
+		open      int  // open iterations, ids 1..open
+		released  bool // the last open iteration can be claimed
+		completed int
+		wantSets  int
+		wantDrawn bool
+	}{
+		{"released iteration played again", 3, true, 0, 2, false},
+		{"new iteration drawn", 2, false, 0, 2, true},
+		{"too many open iterations", maxOpenSpsaIterations, false, 0, 0, false},
+		{"open iterations complete the task", 2, false, 8, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New returned error: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows(spsaIterationColumns)
+			for id := 1; id <= tt.open; id++ {
+				rows.AddRow(id, time.Now(), 5, 0, `{"CPuct":1}`, 0.1, 0, 0, nil, "")
+			}
+			mock.ExpectQuery("FROM spsa_iterations").WithArgs(5).WillReturnRows(rows)
+			for id := 1; id <= tt.open; id++ {
+				claimed := sqlmock.NewRows(tuneParamSetColumns)
+				if tt.released && id == tt.open {
+					for i, side := range []bool{true, false} {
+						claimed.AddRow(20+i, 5, spsaParamSetID(uint(id), side), "", "", 0, 0, 0, 0, 0, 0, 0.0, 0.0, 0.0, 9)
+					}
+				}
+				mock.ExpectQuery("UPDATE tune_param_sets").
+					WithArgs(9, 5, spsaParamSetID(uint(id), true), spsaParamSetID(uint(id), false)).
+					WillReturnRows(claimed)
+			}
+			if tt.wantDrawn {
+				mock.ExpectQuery("FROM tune_params").WithArgs(5).WillReturnRows(
+					sqlmock.NewRows(tuneParamColumns).AddRow(1, 5, "CPuct", 1.0, 3.0, 0.0, 2.0))
+				mock.ExpectQuery("INSERT INTO spsa_iterations").WithArgs(5, tt.completed, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, time.Now()))
+				for i, side := range []bool{true, false} {
+					mock.ExpectQuery("INSERT INTO tune_param_sets").
+						WithArgs(5, spsaParamSetID(7, side), sqlmock.AnyArg(), sqlmock.AnyArg(), 9).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30 + i))
+				}
+			}
+
+			task := &models.TuneTask{ID: 5, SpsaA: 1, SpsaC: 0.1, SpsaAlpha: 0.6, SpsaGamma: 0.1, SpsaIterations: 10, SpsaCompleted: tt.completed}
+			sets, err := nextSpsaParamSets(db, task, 9)
+			if err != nil {
+				t.Fatalf("nextSpsaParamSets returned error: %v", err)
+			}
+			if len(sets) != tt.wantSets {
+				t.Errorf("nextSpsaParamSets returned %d sets, want %d", len(sets), tt.wantSets)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet database expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestFoldSpsaResultUsesIterationGain(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	defer db.Close()
+
+	// Drawn at k = 0 while the task has since completed 5 iterations
+	task := &models.TuneTask{ID: 5, SpsaA: 1, SpsaC: 0.1, SpsaAlpha: 0.6, SpsaGamma: 0.1, SpsaIterations: 10, SpsaCompleted: 5, GamesPerParamSet: 100}
+	mock.ExpectQuery("FROM spsa_iterations").WithArgs(5, 3).WillReturnRows(
+		sqlmock.NewRows(spsaIterationColumns).AddRow(3, time.Now(), 5, 0, `{"CPuct":1}`, 0.1, 0, 0, nil, ""))
+	mock.ExpectQuery("FROM tune_params").WithArgs(5).WillReturnRows(
+		sqlmock.NewRows(tuneParamColumns).AddRow(1, 5, "CPuct", 1.0, 3.0, 0.0, 2.0))
+	space := []tuning.Param{{Name: "CPuct", Min: 1, Max: 3}}
+	want := spsaGains(task).Update(space, []float64{2}, []float64{1}, 0.1, 0, 1)[0]
+	mock.ExpectExec("UPDATE tune_params").WithArgs(want, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE spsa_iterations").WithArgs(1, 1, nil, nil, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	win := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_WHITE_WIN, CandidateIsWhite: true}
+	pairs := []*pb.TuningPairResult{{Game1: win}}
+	done, err := foldSpsaResult(db, task, spsaParamSetID(3, true), pairs, time.Now())
+	if err != nil {
+		t.Fatalf("foldSpsaResult returned error: %v", err)
+	}
+	if done {
+		t.Error("foldSpsaResult completed an iteration short of its games")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet database expectations: %v", err)
+	}
+}
+
+func TestSpsaReportRetry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New returned error: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	taskRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(tuneTaskColumns).AddRow(
+			5, now, now, 3, "", "", "", 1, 2, 2, models.TuneModeSpsa, "", "", "nodes", 0.0, 0.0, 100,
+			1.0, 0.1, 0.0, 0.6, 0.1, 10, 0, 0, 0, 0, "", 0.0, models.TaskStatusActive, "")
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM tune_tasks").WithArgs(3).WillReturnRows(taskRows())
+	mock.ExpectExec("SET last_sequence").WithArgs(1, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("FROM tune_param_sets").WithArgs(5, spsaParamSetID(3, true)).WillReturnRows(sqlmock.NewRows(tuneParamSetColumns).AddRow(
+		20, 5, spsaParamSetID(3, true), "", "", 0, 0, 0, 0, 0, 0, 0.0, 0.0, 0.0, 9))
+	mock.ExpectExec("UPDATE tune_param_sets").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("FROM spsa_iterations").WithArgs(5, 3).WillReturnRows(
+		sqlmock.NewRows(spsaIterationColumns).AddRow(3, now, 5, 0, `{"CPuct":1}`, 0.1, 0, 0, nil, ""))
+	mock.ExpectQuery("FROM tune_params").WithArgs(5).WillReturnRows(
+		sqlmock.NewRows(tuneParamColumns).AddRow(1, 5, "CPuct", 1.0, 3.0, 0.0, 2.0))
+	// One step, one completed iteration
+	mock.ExpectExec("UPDATE tune_params").WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SET spsa_completed").WithArgs(now, 5).WillReturnRows(sqlmock.NewRows([]string{"spsa_completed"}).AddRow(1))
+	mock.ExpectExec("UPDATE spsa_iterations").WithArgs(2, 2, now, sqlmock.AnyArg(), 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SET games_reported").WithArgs(2, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE task_assignments").WithArgs(models.TaskStatusDone, now, 9, models.TaskStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	// The retried report stops at its sequence number
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM tune_tasks").WithArgs(3).WillReturnRows(taskRows())
+	mock.ExpectExec("SET last_sequence").WithArgs(1, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	parent := uint(3)
+	task := &models.TaskAssignment{ID: 9, ParentTaskID: &parent, Status: models.TaskStatusActive}
+	s := &TaskServiceImpl{DB: db}
+	win := &pb.MatchGame{ShortOutcome: pb.ShortOutcome_WHITE_WIN, CandidateIsWhite: true}
+	progress := &pb.TuningProgress{Results: []*pb.TuningParamSetResult{
+		{ParamSetId: spsaParamSetID(3, true), Pairs: []*pb.TuningPairResult{{Game1: win, Game2: win}}},
+	}}
+	for i := 0; i < 2; i++ {
+		if err := s.handleTuningProgress(context.Background(), task, progress, 1, now); err != nil {
+			t.Fatalf("handleTuningProgress report %d returned error: %v", i+1, err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet database expectations: %v", err)
+	}
+}
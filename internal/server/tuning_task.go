@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	pb "github.com/leelachesszero/lczero-server/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/leelachesszero/lczero-server/internal/db/queries"
+	"github.com/leelachesszero/lczero-server/internal/models"
+	"github.com/leelachesszero/lczero-server/internal/tuning"
+)
+
+// maxParamSetsPerTuningTask caps how many parameter sets a single client is sent.
+const maxParamSetsPerTuningTask = 16
+
+// buildSpec decodes the build columns of a tuning task.
+func buildSpec(tt *models.TuneTask) (*pb.BuildSpec, error) {
+	spec := &pb.BuildSpec{
+		RepoUrl:     tt.BuildRepoURL,
+		CommitHash:  tt.BuildCommitHash,
+		BuildParams: map[string]string{},
+	}
+	if tt.BuildParams != "" {
+		if err := json.Unmarshal([]byte(tt.BuildParams), &spec.BuildParams); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
+
+// tuneSpace returns the parameter space of a tuning task and the current
+// values of an SPSA task.
+func tuneSpace(params []models.TuneParam) ([]tuning.Param, []float64) {
+	space := make([]tuning.Param, len(params))
+	values := make([]float64, len(params))
+	for i, p := range params {
+		space[i] = tuning.Param{Name: p.Name, Min: p.Min, Max: p.Max, Step: p.Step}
+		values[i] = p.Value
+	}
+	return space, values
+}
+
+// tuneParamSet builds a parameter set of a tuning task: its shared engine
+// parameters with the UCI options of pt added.
+func tuneParamSet(tt *models.TuneTask, paramSetID string, pt tuning.Point) (models.TuneParamSet, error) {
+	params, err := engineParams(tt.ParamsArgs, tt.ParamsUciOptions)
+	if err != nil {
+		return models.TuneParamSet{}, err
+	}
+	for name, value := range pt {
+		params.UciOptions[name] = value
+	}
+	ps := models.TuneParamSet{TuneTaskID: tt.ID, ParamSetID: paramSetID}
+	ps.ParamsArgs, ps.ParamsUciOptions, err = encodeEngineParams(params)
+	return ps, err
+}
+
+// getNextTuningTask assigns a tuning task with the parameter sets that still
+// need games, or returns nil if there are none. The sets are claimed for the
+// assignment in the transaction that inserts it, so every set is played by one
+// client at a time until the reaper releases it. SPSA tasks hand out the
+// perturbation pair of an iteration instead.
+func (s *TaskServiceImpl) getNextTuningTask(
+	ctx context.Context,
+	tok *models.AuthToken,
+	tt *models.TuneTask,
+	now time.Time,
+	req *pb.TaskRequest,
+) (*pb.TaskResponse, error) {
+	build, err := buildSpec(tt)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Tuning task %d build params: %v", tt.ID, err)
+	}
+	net, err := queries.FetchNetworkByID(s.DB, tt.TuneNetworkID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Tuning task %d network: %v", tt.ID, err)
+	}
+	book, err := queries.FetchBook(s.DB, tt.OpeningBookID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Tuning task %d book: %v", tt.ID, err)
+	}
+	tc, err := timeControl(tt.TimeControlType, tt.BaseTimeSeconds, tt.IncrementSeconds, tt.NodesPerMove)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Tuning task %d: %v", tt.ID, err)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	defer tx.Rollback()
+
+	taskID := time.Now().UTC().Format("20060102T150405.000000000")
+	assignment := newTaskAssignment(taskID, models.TaskTypeTuning, tok, now, req)
+	assignment.ParentTaskID = &tt.TaskID
+	assignment.NetworkID = &net.ID
+	assignment.ID, err = queries.InsertTaskAssignment(tx, assignment)
+	if err != nil {
+		return nil, err
+	}
+	var sets []models.TuneParamSet
+	if tt.Mode == models.TuneModeSpsa {
+		sets, err = nextSpsaParamSets(tx, tt, assignment.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		sets, err = queries.ClaimTuneParamSets(tx, tt.ID, assignment.ID, tt.GamesPerParamSet, maxParamSetsPerTuningTask)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to claim parameter sets")
+		}
+	}
+	if len(sets) == 0 {
+		return nil, nil
+	}
+
+	paramSets := make([]*pb.ParamSet, 0, len(sets))
+	for _, ps := range sets {
+		params, err := engineParams(ps.ParamsArgs, ps.ParamsUciOptions)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Tuning task %d param set %s: %v", tt.ID, ps.ParamSetID, err)
+		}
+		paramSets = append(paramSets, &pb.ParamSet{
+			ParamSetId: ps.ParamSetID,
+			Params:     params,
+		})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Error(codes.Internal, "Database error")
+	}
+	return &pb.TaskResponse{
+		TaskId: taskID,
+		Task: &pb.TaskResponse_Tuning{
+			Tuning: &pb.TuningTask{
+				Build:            build,
+				Network:          networkResource(net),
+				OpeningBook:      bookResource(book),
+				ParamSets:        paramSets,
+				TimeControl:      tc,
+				GamesPerParamSet: tt.GamesPerParamSet,
+			},
+		},
+	}, nil
+}
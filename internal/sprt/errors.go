@@ -0,0 +1,19 @@
+package sprt
+
+import "errors"
+
+// Errors returned by this package. Callers can match them with errors.Is.
+var (
+	// ErrResultsLength is returned when a results slice has the wrong number of entries.
+	ErrResultsLength = errors.New("sprt: wrong number of results")
+	// ErrNegativeCount is returned when a results slice contains a negative count.
+	ErrNegativeCount = errors.New("sprt: negative result count")
+	// ErrInvalidTest is returned for error rates outside (0, 1) or elo0 >= elo1.
+	ErrInvalidTest = errors.New("sprt: invalid test parameters")
+	// ErrInvalidPDF is returned when an intermediate distribution is not a probability distribution.
+	ErrInvalidPDF = errors.New("sprt: invalid probability distribution")
+	// ErrDegenerate is returned when a distribution has no spread to work with.
+	ErrDegenerate = errors.New("sprt: degenerate distribution")
+	// ErrNoConvergence is returned when a numerical solver fails.
+	ErrNoConvergence = errors.New("sprt: solver did not converge")
+)
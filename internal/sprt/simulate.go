@@ -0,0 +1,158 @@
+package sprt
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// SimConfig describes a Monte-Carlo simulation of an SPRT.
+type SimConfig struct {
+	Test Test
+
+	// Elo is the true logistic Elo of the candidate and DrawRatio the
+	// probability of a draw in a single game. Games within a pair are
+	// simulated independently.
+	Elo       float64
+	DrawRatio float64
+
+	// Runs is the number of simulated tests.
+	Runs int
+	// MaxPairs stops a run that has not reached a verdict after this many
+	// pairs; 0 means no limit.
+	MaxPairs int
+	// CheckEvery is the number of pairs between LLR evaluations; 0 or 1 checks
+	// after every pair.
+	CheckEvery int
+}
+
+// SimResult summarizes a simulation.
+type SimResult struct {
+	Runs       int
+	Passed     int
+	Failed     int
+	Unfinished int // runs stopped at MaxPairs
+
+	// Games played per run (two per pair), including unfinished runs.
+	MeanGames float64
+	P5Games   int
+	P50Games  int
+	P95Games  int
+}
+
+// PassRate returns the fraction of runs that passed.
+func (r SimResult) PassRate() float64 {
+	if r.Runs == 0 {
+		return 0
+	}
+	return float64(r.Passed) / float64(r.Runs)
+}
+
+// GameProbabilities returns the per-game [L, D, W] probabilities for a true
+// logistic Elo and draw ratio.
+func GameProbabilities(elo, drawRatio float64) ([3]float64, error) {
+	if !(drawRatio >= 0 && drawRatio < 1) || math.IsNaN(elo) || math.IsInf(elo, 0) {
+		return [3]float64{}, fmt.Errorf("%w: draw ratio must be in [0, 1) and elo finite", ErrInvalidTest)
+	}
+	score := 1 / (1 + math.Pow(10, -elo/400))
+	win := score - drawRatio/2
+	loss := 1 - score - drawRatio/2
+	if win < 0 || loss < 0 {
+		return [3]float64{}, fmt.Errorf("%w: elo %v is not reachable with draw ratio %v", ErrInvalidTest, elo, drawRatio)
+	}
+	return [3]float64{loss, drawRatio, win}, nil
+}
+
+// PairProbabilities returns the [LL, LD, DD/WL, DW, WW] probabilities of a
+// pair of independent games with the given [L, D, W] probabilities.
+func PairProbabilities(game [3]float64) [5]float64 {
+	var pair [5]float64
+	for i, p := range game {
+		for j, q := range game {
+			pair[i+j] += p * q
+		}
+	}
+	return pair
+}
+
+// Simulate runs cfg.Runs simulated SPRTs, evaluating the pentanomial LLR as
+// pairs come in, and summarizes their verdicts and lengths.
+func Simulate(cfg SimConfig, rng *rand.Rand) (SimResult, error) {
+	if err := cfg.Test.Validate(); err != nil {
+		return SimResult{}, err
+	}
+	if cfg.Runs <= 0 || cfg.MaxPairs < 0 {
+		return SimResult{}, fmt.Errorf("%w: runs must be positive and max pairs non-negative", ErrInvalidTest)
+	}
+	game, err := GameProbabilities(cfg.Elo, cfg.DrawRatio)
+	if err != nil {
+		return SimResult{}, err
+	}
+	pair := PairProbabilities(game)
+	checkEvery := cfg.CheckEvery
+	if checkEvery < 1 {
+		checkEvery = 1
+	}
+
+	res := SimResult{Runs: cfg.Runs}
+	games := make([]int, 0, cfg.Runs)
+	for run := 0; run < cfg.Runs; run++ {
+		counts := make([]int, 5)
+		pairs := 0
+		state := Continue
+		for state == Continue && (cfg.MaxPairs == 0 || pairs < cfg.MaxPairs) {
+			counts[samplePair(pair, rng)]++
+			pairs++
+			if pairs%checkEvery != 0 {
+				continue
+			}
+			llr, err := cfg.Test.LLR(counts, nil)
+			if err != nil {
+				return SimResult{}, err
+			}
+			state = cfg.Test.State(llr)
+		}
+		switch state {
+		case Passed:
+			res.Passed++
+		case Failed:
+			res.Failed++
+		default:
+			res.Unfinished++
+		}
+		games = append(games, 2*pairs)
+	}
+
+	sort.Ints(games)
+	total := 0
+	for _, g := range games {
+		total += g
+	}
+	res.MeanGames = float64(total) / float64(len(games))
+	res.P5Games = percentile(games, 0.05)
+	res.P50Games = percentile(games, 0.50)
+	res.P95Games = percentile(games, 0.95)
+	return res, nil
+}
+
+// samplePair draws a pentanomial bucket from pair probabilities.
+func samplePair(pair [5]float64, rng *rand.Rand) int {
+	x := rng.Float64()
+	for i, p := range pair {
+		if x < p {
+			return i
+		}
+		x -= p
+	}
+	return len(pair) - 1
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []int, p float64) int {
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,71 @@
+package sprt
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPairProbabilities(t *testing.T) {
+	game, err := GameProbabilities(0, 0.5)
+	if err != nil {
+		t.Fatalf("GameProbabilities returned error: %v", err)
+	}
+	want := [3]float64{0.25, 0.5, 0.25}
+	for i := range game {
+		if math.Abs(game[i]-want[i]) > 1e-12 {
+			t.Fatalf("GameProbabilities(0, 0.5) = %v, want %v", game, want)
+		}
+	}
+
+	pair := PairProbabilities(game)
+	wantPair := [5]float64{0.0625, 0.25, 0.375, 0.25, 0.0625}
+	for i := range pair {
+		if math.Abs(pair[i]-wantPair[i]) > 1e-12 {
+			t.Fatalf("PairProbabilities(%v) = %v, want %v", game, pair, wantPair)
+		}
+	}
+
+	if _, err := GameProbabilities(800, 0.9); !errors.Is(err, ErrInvalidTest) {
+		t.Errorf("GameProbabilities(800, 0.9) error = %v, want %v", err, ErrInvalidTest)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	test := Test{Elo0: 0, Elo1: 5, Alpha: 0.05, Beta: 0.05}
+	tests := []struct {
+		name       string
+		elo        float64
+		maxPairs   int
+		passed     int
+		failed     int
+		unfinished int
+	}{
+		{"strong candidate", 30, 0, 20, 0, 0},
+		{"weak candidate", -30, 0, 0, 20, 0},
+		{"capped", 2, 10, 0, 0, 20},
+	}
+
+	for _, tt := range tests {
+		cfg := SimConfig{Test: test, Elo: tt.elo, DrawRatio: 0.6, Runs: 20, MaxPairs: tt.maxPairs, CheckEvery: 4}
+		res, err := Simulate(cfg, rand.New(rand.NewPCG(1, 2)))
+		if err != nil {
+			t.Fatalf("%s: Simulate returned error: %v", tt.name, err)
+		}
+		if res.Passed != tt.passed || res.Failed != tt.failed || res.Unfinished != tt.unfinished {
+			t.Errorf("%s: passed/failed/unfinished = %d/%d/%d, want %d/%d/%d",
+				tt.name, res.Passed, res.Failed, res.Unfinished, tt.passed, tt.failed, tt.unfinished)
+		}
+		if res.P5Games > res.P50Games || res.P50Games > res.P95Games || res.MeanGames <= 0 {
+			t.Errorf("%s: inconsistent game counts %+v", tt.name, res)
+		}
+	}
+	if res, _ := Simulate(SimConfig{Test: test, Elo: 2, DrawRatio: 0.6, Runs: 20, MaxPairs: 10}, rand.New(rand.NewPCG(1, 2))); res.P95Games != 20 {
+		t.Errorf("capped runs played %d games, want 20", res.P95Games)
+	}
+
+	if _, err := Simulate(SimConfig{Test: test, Runs: 0}, rand.New(rand.NewPCG(1, 2))); !errors.Is(err, ErrInvalidTest) {
+		t.Errorf("Simulate with no runs error = %v, want %v", err, ErrInvalidTest)
+	}
+}
@@ -0,0 +1,119 @@
+package sprt
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// State is the outcome of an SPRT so far.
+type State int
+
+const (
+	// Continue means the LLR is between the bounds and more games are needed.
+	Continue State = iota
+	// Passed means the LLR reached the upper bound (elo1 accepted).
+	Passed
+	// Failed means the LLR reached the lower bound (elo0 accepted).
+	Failed
+)
+
+func (s State) String() string {
+	switch s {
+	case Continue:
+		return "continue"
+	case Passed:
+		return "passed"
+	case Failed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// Bounds returns the Wald lower and upper LLR bounds for the false positive
+// rate alpha and false negative rate beta.
+func Bounds(alpha, beta float64) (lower, upper float64) {
+	return math.Log(beta / (1 - alpha)), math.Log((1 - beta) / alpha)
+}
+
+// Test is an SPRT of elo0 against elo1 (normalized Elo) with error rates alpha and beta.
+type Test struct {
+	Elo0  float64
+	Elo1  float64
+	Alpha float64
+	Beta  float64
+}
+
+// Validate checks that the error rates are in (0, 1) and elo0 < elo1.
+func (t Test) Validate() error {
+	if !(t.Alpha > 0 && t.Alpha < 1) || !(t.Beta > 0 && t.Beta < 1) {
+		return fmt.Errorf("%w: alpha and beta must be in (0, 1)", ErrInvalidTest)
+	}
+	if !(t.Elo0 < t.Elo1) || math.IsInf(t.Elo0, 0) || math.IsInf(t.Elo1, 0) {
+		return fmt.Errorf("%w: elo0 must be below elo1", ErrInvalidTest)
+	}
+	return nil
+}
+
+// LLR combines the pentanomial LLR of complete game pairs [LL, LD, DD/WL, DW, WW]
+// with the trinomial LLR of unpaired games [L, D, W]. Either may be all zeros.
+func (t Test) LLR(pentanomial, trinomial []int) (float64, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+	llr := 0.0
+	if sum(pentanomial) > 0 {
+		v, err := PentanomialSPRT(pentanomial, t.Elo0, t.Elo1)
+		if err != nil {
+			return 0, err
+		}
+		llr += v
+	}
+	if sum(trinomial) > 0 {
+		v, err := TrinomialSPRT(trinomial, t.Elo0, t.Elo1)
+		if err != nil {
+			return 0, err
+		}
+		llr += v
+	}
+	return llr, nil
+}
+
+// State returns the state of the test for the given LLR.
+func (t Test) State(llr float64) State {
+	lower, upper := Bounds(t.Alpha, t.Beta)
+	switch {
+	case llr >= upper:
+		return Passed
+	case llr <= lower:
+		return Failed
+	}
+	return Continue
+}
+
+// LOS returns the likelihood of superiority, the probability that the true
+// score is above 50%, from trinomial [L, D, W] or pentanomial
+// [LL, LD, DD/WL, DW, WW] results.
+func LOS(results []int) (float64, error) {
+	if len(results) != 3 && len(results) != 5 {
+		return 0, fmt.Errorf("%w: got %d, want 3 or 5", ErrResultsLength, len(results))
+	}
+	if err := checkCounts(results); err != nil {
+		return 0, err
+	}
+	n, mu, variance := scoreStats(results)
+	if n == 0 || variance == 0 {
+		return 0.5, nil
+	}
+	return distuv.UnitNormal.CDF((mu - 0.5) / math.Sqrt(variance/n)), nil
+}
+
+// sum returns the sum of counts.
+func sum(counts []int) int {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
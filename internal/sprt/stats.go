@@ -2,26 +2,56 @@
 package sprt
 
 import (
-	"errors"
+	"fmt"
 	"math"
 
 	"gonum.org/v1/gonum/stat/distuv"
 )
 
 // From https://github.com/AndyGrant/OpenBench/blob/master/OpenBench/stats.py converted to Go.
-// Only two functions should be used externally from this Module.
-// 1. llr = PentanomialSPRT([ll, ld, dd, dw, ww], elo0, elo1)
-// 2. lower, elo, upper = Elo((L, D, W) or (LL, LD, DD/WL, DW, WW)) (Not implemented)
+// The functions meant to be used externally from this Module are:
+// 1. llr = PentanomialSPRT([ll, ld, dd, dw, ww], elo0, elo1) or TrinomialSPRT([l, d, w], elo0, elo1)
+// 2. lower, elo, upper = EloTrinomial([l, d, w]) or EloPentanomial([ll, ld, dd/wl, dw, ww])
+// 3. lower, nelo, upper = NormalizedElo([l, d, w] or [ll, ld, dd/wl, dw, ww])
 
 // PentanomialSPRT implements the pentanomial SPRT as described in
 // https://hardy.uhasselt.be/Fishtest/normalized_elo_practical.pdf
+// The LLR of zero pairs is 0.
 func PentanomialSPRT(results []int, elo0, elo1 float64) (float64, error) {
 	if len(results) != 5 {
-		return 0, errors.New("results must have length 5")
+		return 0, fmt.Errorf("%w: got %d, want 5", ErrResultsLength, len(results))
 	}
+	// A pair score has sqrt(2) less spread than a game score.
+	nt0 := elo0 / neloDividedByNt
+	nt1 := elo1 / neloDividedByNt
+	return generalizedLLR(results, nt0*math.Sqrt(2), nt1*math.Sqrt(2))
+}
+
+// TrinomialSPRT implements the trinomial SPRT on game results [L, D, W], for
+// games that cannot be paired. The LLR of zero games is 0.
+func TrinomialSPRT(results []int, elo0, elo1 float64) (float64, error) {
+	if len(results) != 3 {
+		return 0, fmt.Errorf("%w: got %d, want 3", ErrResultsLength, len(results))
+	}
+	return generalizedLLR(results, elo0/neloDividedByNt, elo1/neloDividedByNt)
+}
 
-	// Avoid division by zero
-	r := make([]float64, 5)
+// generalizedLLR computes the generalized log-likelihood ratio of equally
+// spaced outcomes for the t-values t0 and t1.
+func generalizedLLR(results []int, t0, t1 float64) (float64, error) {
+	if err := checkCounts(results); err != nil {
+		return 0, err
+	}
+	if math.IsNaN(t0) || math.IsInf(t0, 0) || math.IsNaN(t1) || math.IsInf(t1, 0) {
+		return 0, fmt.Errorf("%w: elo bounds must be finite", ErrInvalidTest)
+	}
+	if sum(results) == 0 {
+		return 0, nil
+	}
+
+	// Avoid division by zero; this also gives all-draw or all-win results a
+	// finite LLR
+	r := make([]float64, len(results))
 	for i, x := range results {
 		val := float64(x)
 		if val < 1e-3 {
@@ -30,20 +60,15 @@ func PentanomialSPRT(results []int, elo0, elo1 float64) (float64, error) {
 		r[i] = val
 	}
 
-	neloDividedByNt := 800.0 / math.Log(10)
-	nt0 := elo0 / neloDividedByNt
-	nt1 := elo1 / neloDividedByNt
-	t0 := nt0 * math.Sqrt(2)
-	t1 := nt1 * math.Sqrt(2)
-
 	N := 0.0
 	for _, v := range r {
 		N += v
 	}
 
-	pdf := make([][2]float64, 5)
-	for i := 0; i < 5; i++ {
-		pdf[i][0] = float64(i) / 4.0
+	div := float64(len(r) - 1)
+	pdf := make([][2]float64, len(r))
+	for i := range r {
+		pdf[i][0] = float64(i) / div
 		pdf[i][1] = r[i] / N
 	}
 
@@ -62,8 +87,25 @@ func PentanomialSPRT(results []int, elo0, elo1 float64) (float64, error) {
 		mlePDF[i][1] = pdf[i][1]
 	}
 
-	s, _ := stats(mlePDF)
-	return N * s, nil
+	s, _, err := stats(mlePDF)
+	if err != nil {
+		return 0, err
+	}
+	llr := N * s
+	if math.IsNaN(llr) || math.IsInf(llr, 0) {
+		return 0, fmt.Errorf("%w: LLR is not finite", ErrNoConvergence)
+	}
+	return llr, nil
+}
+
+// checkCounts rejects negative result counts.
+func checkCounts(results []int) error {
+	for _, c := range results {
+		if c < 0 {
+			return ErrNegativeCount
+		}
+	}
+	return nil
 }
 
 // MLE_tvalue computes the maximum likelihood estimate for a given t-value.
@@ -73,8 +115,14 @@ func MLE_tvalue(pdfhat [][2]float64, ref, s float64) ([][2]float64, error) {
 	for iter := 0; iter < 10; iter++ {
 		prev := make([][2]float64, N)
 		copy(prev, pdfMLE)
-		mu, var_ := stats(pdfMLE)
+		mu, var_, err := stats(pdfMLE)
+		if err != nil {
+			return nil, err
+		}
 		sigma := math.Sqrt(var_)
+		if sigma == 0 {
+			return nil, ErrDegenerate
+		}
 		pdf1 := make([][2]float64, N)
 		for i, v := range pdfhat {
 			ai := v[0]
@@ -85,9 +133,19 @@ func MLE_tvalue(pdfhat [][2]float64, ref, s float64) ([][2]float64, error) {
 		if err != nil {
 			return nil, err
 		}
+		total := 0.0
 		for i := range pdfMLE {
 			pdfMLE[i][0] = pdfhat[i][0]
 			pdfMLE[i][1] = pdfhat[i][1] / (1 + x*pdf1[i][0])
+			total += pdfMLE[i][1]
+		}
+		// The root is only found up to floating point precision, which for
+		// very lopsided results leaves the distribution slightly off 1.
+		if total <= 0 || math.IsNaN(total) || math.IsInf(total, 0) {
+			return nil, ErrInvalidPDF
+		}
+		for i := range pdfMLE {
+			pdfMLE[i][1] /= total
 		}
 		maxDiff := 0.0
 		for i := range pdfMLE {
@@ -104,17 +162,17 @@ func MLE_tvalue(pdfhat [][2]float64, ref, s float64) ([][2]float64, error) {
 }
 
 // stats computes the mean and variance of a pdf.
-func stats(pdf [][2]float64) (mean, variance float64) {
+func stats(pdf [][2]float64) (mean, variance float64, err error) {
 	epsilon := 1e-6
 	n := 0.0
 	for _, v := range pdf {
-		if v[1] < -epsilon || v[1] > 1+epsilon {
-			panic("probability out of bounds")
+		if !(v[1] >= -epsilon && v[1] <= 1+epsilon) {
+			return 0, 0, fmt.Errorf("%w: probability %v out of bounds", ErrInvalidPDF, v[1])
 		}
 		n += v[1]
 	}
 	if math.Abs(n-1) > epsilon {
-		panic("probabilities do not sum to 1")
+		return 0, 0, fmt.Errorf("%w: probabilities sum to %v", ErrInvalidPDF, n)
 	}
 	s := 0.0
 	for _, v := range pdf {
@@ -124,7 +182,7 @@ func stats(pdf [][2]float64) (mean, variance float64) {
 	for _, v := range pdf {
 		var_ += v[1] * math.Pow(v[0]-s, 2)
 	}
-	return s, var_
+	return s, var_, nil
 }
 
 // uniform returns a uniform pdf with the same support as pdf.
@@ -152,7 +210,7 @@ func secular(pdf [][2]float64) (float64, error) {
 		}
 	}
 	if v*w >= 0 {
-		return 0, errors.New("secular: v*w >= 0")
+		return 0, fmt.Errorf("%w: secular: v*w >= 0", ErrDegenerate)
 	}
 	l := -1.0 / w
 	u := -1.0 / v
@@ -170,8 +228,8 @@ func secular(pdf [][2]float64) (float64, error) {
 	const maxIter = 100
 	a, b := l+epsilon, u-epsilon
 	fa, fb := f(a), f(b)
-	if fa*fb > 0 {
-		return 0, errors.New("secular: root not bracketed")
+	if !(fa*fb <= 0) {
+		return 0, fmt.Errorf("%w: secular: root not bracketed", ErrNoConvergence)
 	}
 	for i := 0; i < maxIter; i++ {
 		c := (a + b) / 2
@@ -185,7 +243,7 @@ func secular(pdf [][2]float64) (float64, error) {
 			a, fa = c, fc
 		}
 	}
-	return 0, errors.New("secular: did not converge")
+	return 0, fmt.Errorf("%w: secular", ErrNoConvergence)
 }
 
 // Elo computes the logistic Elo and confidence interval for a set of results.
@@ -222,3 +280,93 @@ func logisticElo(x float64) float64 {
 	x = math.Min(math.Max(x, 1e-3), 1-1e-3)
 	return -400 * math.Log10(1/x-1)
 }
+
+// eloConfidence is the two-sided confidence level of the Elo intervals below.
+const eloConfidence = 0.95
+
+// neloDividedByNt converts a normalized t-value to normalized Elo.
+var neloDividedByNt = 800.0 / math.Log(10)
+
+// scoreStats returns the number of samples and the mean and variance of the
+// score of equally spaced outcomes (e.g. L/D/W = 0, 0.5, 1).
+func scoreStats(results []int) (n, mu, variance float64) {
+	div := float64(len(results) - 1)
+	for _, count := range results {
+		n += float64(count)
+	}
+	for f, count := range results {
+		mu += (float64(f) / div) * float64(count)
+	}
+	mu /= n
+	for f, count := range results {
+		diff := float64(f)/div - mu
+		variance += diff * diff * float64(count)
+	}
+	variance /= n
+	return n, mu, variance
+}
+
+// eloFromResults computes the logistic Elo of results with a normal
+// confidence interval, as OpenBench does.
+func eloFromResults(results []int) (float64, float64, float64) {
+	n, mu, variance := scoreStats(results)
+	if n <= 1 {
+		return 0.0, 0.0, 0.0
+	}
+	z := distuv.UnitNormal.Quantile(0.5 + eloConfidence/2)
+	delta := z * math.Sqrt(variance) / math.Sqrt(n)
+	return logisticElo(mu - delta), logisticElo(mu), logisticElo(mu + delta)
+}
+
+// EloTrinomial computes the logistic Elo and 95% confidence interval from
+// game results [L, D, W]. Returns (elo_min, elo, elo_max).
+func EloTrinomial(results []int) (float64, float64, float64, error) {
+	if len(results) != 3 {
+		return 0, 0, 0, fmt.Errorf("%w: got %d, want 3", ErrResultsLength, len(results))
+	}
+	if err := checkCounts(results); err != nil {
+		return 0, 0, 0, err
+	}
+	lower, elo, upper := eloFromResults(results)
+	return lower, elo, upper, nil
+}
+
+// EloPentanomial computes the logistic Elo and 95% confidence interval from
+// game pair results [LL, LD, DD/WL, DW, WW]. Returns (elo_min, elo, elo_max).
+func EloPentanomial(results []int) (float64, float64, float64, error) {
+	if len(results) != 5 {
+		return 0, 0, 0, fmt.Errorf("%w: got %d, want 5", ErrResultsLength, len(results))
+	}
+	if err := checkCounts(results); err != nil {
+		return 0, 0, 0, err
+	}
+	lower, elo, upper := eloFromResults(results)
+	return lower, elo, upper, nil
+}
+
+// NormalizedElo computes the normalized Elo (nElo) and 95% confidence interval
+// from trinomial [L, D, W] or pentanomial [LL, LD, DD/WL, DW, WW] results, as
+// defined in https://hardy.uhasselt.be/Fishtest/normalized_elo_practical.pdf.
+// Returns (nelo_min, nelo, nelo_max).
+func NormalizedElo(results []int) (float64, float64, float64, error) {
+	if len(results) != 3 && len(results) != 5 {
+		return 0, 0, 0, fmt.Errorf("%w: got %d, want 3 or 5", ErrResultsLength, len(results))
+	}
+	if err := checkCounts(results); err != nil {
+		return 0, 0, 0, err
+	}
+	n, mu, variance := scoreStats(results)
+	if n <= 1 || variance == 0 {
+		return 0.0, 0.0, 0.0, nil
+	}
+	// A pair score has sqrt(2) less spread than a game score, so scale the
+	// pentanomial standard deviation back to a per-game one.
+	sigma := math.Sqrt(variance)
+	if len(results) == 5 {
+		sigma *= math.Sqrt(2)
+	}
+	nelo := (mu - 0.5) / sigma * neloDividedByNt
+	z := distuv.UnitNormal.Quantile(0.5 + eloConfidence/2)
+	delta := z * math.Sqrt(variance) / math.Sqrt(n) / sigma * neloDividedByNt
+	return nelo - delta, nelo, nelo + delta, nil
+}
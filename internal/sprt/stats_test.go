@@ -1,6 +1,8 @@
 package sprt
 
 import (
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -25,3 +27,198 @@ func TestPentanomialSPRT(t *testing.T) {
 		t.Errorf("PentanomialSPRT = %v, want %v (diff %v > tol %v)", llr, expected, diff, tol)
 	}
 }
+
+func TestEloFunctions(t *testing.T) {
+	// Reference values from OpenBench's Elo() and the nElo definitions used by Fishtest.
+	tests := []struct {
+		name    string
+		fn      func([]int) (float64, float64, float64, error)
+		results []int
+		want    [3]float64
+	}{
+		{"EloTrinomial", EloTrinomial, []int{1200, 4500, 1300}, [3]float64{0.100747, 4.963703, 9.828605}},
+		{"EloTrinomial", EloTrinomial, []int{300, 200, 500}, [3]float64{51.079169, 70.436504, 90.235546}},
+		{"EloPentanomial", EloPentanomial, []int{39, 8843, 26675, 9240, 44}, [3]float64{0.546463, 1.576763, 2.607091}},
+		{"EloPentanomial", EloPentanomial, []int{10, 20, 40, 25, 5}, [3]float64{-43.763202, -8.687700, 26.211045}},
+		{"NormalizedElo", NormalizedElo, []int{1200, 4500, 1300}, [3]float64{0.168630, 8.307673, 16.446716}},
+		{"NormalizedElo", NormalizedElo, []int{300, 200, 500}, [3]float64{58.173305, 79.707190, 101.241075}},
+		{"NormalizedElo", NormalizedElo, []int{39, 8843, 26675, 9240, 44}, [3]float64{1.206065, 3.479956, 5.753848}},
+		{"NormalizedElo", NormalizedElo, []int{10, 20, 40, 25, 5}, [3]float64{-60.153194, -12.001963, 36.149268}},
+	}
+	tol := 0.5 * 1e-5
+
+	for _, tt := range tests {
+		lower, elo, upper, err := tt.fn(tt.results)
+		if err != nil {
+			t.Fatalf("%s(%v) returned error: %v", tt.name, tt.results, err)
+		}
+		got := [3]float64{lower, elo, upper}
+		for i := range got {
+			if math.Abs(got[i]-tt.want[i]) > tol {
+				t.Errorf("%s(%v) = %v, want %v", tt.name, tt.results, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestEloFunctionsRejectWrongLength(t *testing.T) {
+	if _, _, _, err := EloTrinomial([]int{1, 2, 3, 4, 5}); err == nil {
+		t.Error("EloTrinomial accepted 5 results")
+	}
+	if _, _, _, err := EloPentanomial([]int{1, 2, 3}); err == nil {
+		t.Error("EloPentanomial accepted 3 results")
+	}
+	if _, _, _, err := NormalizedElo([]int{1, 2, 3, 4}); err == nil {
+		t.Error("NormalizedElo accepted 4 results")
+	}
+}
+
+func TestTrinomialSPRT(t *testing.T) {
+	tests := []struct {
+		results    []int
+		elo0, elo1 float64
+		want       float64
+	}{
+		{[]int{1200, 4500, 1300}, 0, 5, 1.683418},
+		{[]int{3000, 4000, 3300}, 0, 5, 4.453921},
+	}
+	tol := 0.5 * 1e-5
+
+	for _, tt := range tests {
+		llr, err := TrinomialSPRT(tt.results, tt.elo0, tt.elo1)
+		if err != nil {
+			t.Fatalf("TrinomialSPRT(%v) returned error: %v", tt.results, err)
+		}
+		if math.Abs(llr-tt.want) > tol {
+			t.Errorf("TrinomialSPRT(%v, %v, %v) = %v, want %v", tt.results, tt.elo0, tt.elo1, llr, tt.want)
+		}
+	}
+}
+
+func TestLOS(t *testing.T) {
+	tests := []struct {
+		results []int
+		want    float64
+	}{
+		{[]int{1200, 4500, 1300}, 0.977281},
+		{[]int{39, 8843, 26675, 9240, 44}, 0.998648},
+		{[]int{10, 20, 40, 25, 5}, 0.312587},
+		{[]int{0, 0, 0}, 0.5},
+	}
+	tol := 0.5 * 1e-6
+
+	for _, tt := range tests {
+		los, err := LOS(tt.results)
+		if err != nil {
+			t.Fatalf("LOS(%v) returned error: %v", tt.results, err)
+		}
+		if math.Abs(los-tt.want) > tol {
+			t.Errorf("LOS(%v) = %v, want %v", tt.results, los, tt.want)
+		}
+	}
+}
+
+func TestTestState(t *testing.T) {
+	test := Test{Elo0: 0.5, Elo1: 2.5, Alpha: 0.05, Beta: 0.05}
+	lower, upper := Bounds(test.Alpha, test.Beta)
+	if math.Abs(lower+2.944439) > 1e-6 || math.Abs(upper-2.944439) > 1e-6 {
+		t.Fatalf("Bounds(0.05, 0.05) = (%v, %v), want (-2.944439, 2.944439)", lower, upper)
+	}
+
+	llr, err := test.LLR([]int{39, 8843, 26675, 9240, 44}, []int{0, 0, 0})
+	if err != nil {
+		t.Fatalf("LLR returned error: %v", err)
+	}
+	if got := test.State(llr); got != Continue {
+		t.Errorf("State(%v) = %v, want %v", llr, got, Continue)
+	}
+	if got := test.State(upper); got != Passed {
+		t.Errorf("State(%v) = %v, want %v", upper, got, Passed)
+	}
+	if got := test.State(lower); got != Failed {
+		t.Errorf("State(%v) = %v, want %v", lower, got, Failed)
+	}
+
+	// Unpaired games add their trinomial LLR.
+	tri, _ := TrinomialSPRT([]int{1200, 4500, 1300}, test.Elo0, test.Elo1)
+	combined, err := test.LLR([]int{39, 8843, 26675, 9240, 44}, []int{1200, 4500, 1300})
+	if err != nil {
+		t.Fatalf("LLR returned error: %v", err)
+	}
+	if math.Abs(combined-(llr+tri)) > 1e-9 {
+		t.Errorf("combined LLR = %v, want %v", combined, llr+tri)
+	}
+}
+
+func TestSPRTEdgeCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []int
+		sign    int // expected sign of the LLR
+	}{
+		{"no games", []int{0, 0, 0, 0, 0}, 0},
+		{"all draws", []int{0, 0, 100, 0, 0}, -1},
+		{"all wins", []int{0, 0, 0, 0, 100}, 1},
+		{"all losses", []int{100, 0, 0, 0, 0}, -1},
+		{"lopsided", []int{0, 1000000, 0, 0, 0}, -1},
+	}
+
+	for _, tt := range tests {
+		llr, err := PentanomialSPRT(tt.results, 0, 5)
+		if err != nil {
+			t.Errorf("%s: PentanomialSPRT(%v) returned error: %v", tt.name, tt.results, err)
+			continue
+		}
+		if (tt.sign == 0 && llr != 0) || (tt.sign > 0 && llr <= 0) || (tt.sign < 0 && llr >= 0) {
+			t.Errorf("%s: PentanomialSPRT(%v) = %v, want sign %d", tt.name, tt.results, llr, tt.sign)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"wrong length", func() error { _, err := PentanomialSPRT([]int{1, 2, 3}, 0, 5); return err }(), ErrResultsLength},
+		{"negative count", func() error { _, err := PentanomialSPRT([]int{-3, 0, 1, 0, 0}, 0, 5); return err }(), ErrNegativeCount},
+		{"negative elo count", func() error { _, _, _, err := EloTrinomial([]int{1, -1, 1}); return err }(), ErrNegativeCount},
+		{"bad alpha", func() error {
+			_, err := Test{Elo0: 0, Elo1: 5, Alpha: 0, Beta: 0.05}.LLR([]int{1, 1, 1, 1, 1}, nil)
+			return err
+		}(), ErrInvalidTest},
+		{"swapped hypotheses", Test{Elo0: 5, Elo1: 0, Alpha: 0.05, Beta: 0.05}.Validate(), ErrInvalidTest},
+	}
+
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: got error %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func FuzzPentanomialSPRT(f *testing.F) {
+	f.Add(uint32(39), uint32(8843), uint32(26675), uint32(9240), uint32(44), 0.5, 2.5)
+	f.Add(uint32(0), uint32(0), uint32(0), uint32(0), uint32(0), 0.0, 5.0)
+	f.Add(uint32(0), uint32(1000000), uint32(0), uint32(0), uint32(0), 0.0, 5.0)
+	f.Add(uint32(0), uint32(0), uint32(0), uint32(0), uint32(100), -5.0, 0.0)
+
+	f.Fuzz(func(t *testing.T, ll, ld, dd, dw, ww uint32, elo0, elo1 float64) {
+		results := []int{int(ll), int(ld), int(dd), int(dw), int(ww)}
+		llr, err := PentanomialSPRT(results, elo0, elo1)
+		if err == nil && (math.IsNaN(llr) || math.IsInf(llr, 0)) {
+			t.Errorf("PentanomialSPRT(%v, %v, %v) = %v without error", results, elo0, elo1, llr)
+		}
+		if _, _, _, err := EloPentanomial(results); err != nil {
+			t.Errorf("EloPentanomial(%v) returned error: %v", results, err)
+		}
+		if _, _, _, err := NormalizedElo(results); err != nil {
+			t.Errorf("NormalizedElo(%v) returned error: %v", results, err)
+		}
+		if _, err := LOS(results); err != nil {
+			t.Errorf("LOS(%v) returned error: %v", results, err)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+// Package storage persists uploaded game blobs (training data and PGNs).
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store writes opaque blobs under slash-separated keys.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// ErrInvalidKey is returned when a key is empty or escapes the store root.
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// LocalStore is a Store backed by a directory on the local filesystem.
+type LocalStore struct {
+	Root string
+}
+
+// NewLocalStore creates a LocalStore rooted at root.
+func NewLocalStore(root string) *LocalStore {
+	return &LocalStore{Root: root}
+}
+
+// Put writes data to Root/key, creating parent directories as needed.
+// The file is written to a temporary name first and renamed into place so
+// readers never observe a partially written blob.
+func (s *LocalStore) Put(ctx context.Context, key string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return ErrInvalidKey
+	}
+	path := filepath.Join(s.Root, clean)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,175 @@
+package tuning
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// defaultCandidates is how many random points the acquisition function is
+// evaluated at when Bayes.Candidates is 0.
+const defaultCandidates = 2000
+
+// Acquisition selects where a Bayesian tuner measures next.
+type Acquisition int
+
+const (
+	// ExpectedImprovement favours points likely to beat the best predicted Elo so far.
+	ExpectedImprovement Acquisition = iota
+	// UpperConfidenceBound favours points whose mean plus Kappa standard
+	// deviations is highest.
+	UpperConfidenceBound
+)
+
+// Bayes proposes points by Bayesian optimization over a Gaussian process
+// fitted to the observations so far.
+type Bayes struct {
+	Acquisition Acquisition
+	Kappa       float64
+	Candidates  int
+}
+
+// validateRanges checks that every parameter of space is a range.
+func validateRanges(space []Param) error {
+	if err := validateSpace(space); err != nil {
+		return err
+	}
+	for _, p := range space {
+		if len(p.Values) > 0 {
+			return fmt.Errorf("%s: needs a range, not explicit values", p.Name)
+		}
+	}
+	return nil
+}
+
+// Validate checks the settings, and that every parameter of space is a range.
+func (b Bayes) Validate(space []Param) error {
+	if err := validateRanges(space); err != nil {
+		return err
+	}
+	if !(b.Kappa >= 0) || math.IsInf(b.Kappa, 0) {
+		return errors.New("kappa must be finite and not negative")
+	}
+	return nil
+}
+
+// snap rounds x to the parameter's step.
+func (p Param) snap(x float64) float64 {
+	if p.Step <= 0 {
+		return p.clamp(x)
+	}
+	i := math.Round((x - p.Min) / p.Step)
+	return p.Min + math.Max(0, math.Min(i, float64(p.size()-1)))*p.Step
+}
+
+// candidates draws random values of space, snapped to the parameters' steps.
+func (b Bayes) candidates(space []Param, rng *rand.Rand) [][]float64 {
+	n := b.Candidates
+	if n == 0 {
+		n = defaultCandidates
+	}
+	cs := make([][]float64, n)
+	for i := range cs {
+		cs[i] = make([]float64, len(space))
+		for j, p := range space {
+			cs[i][j] = p.snap(p.unscale(rng.Float64()))
+		}
+	}
+	return cs
+}
+
+// score evaluates the acquisition function for a standardized posterior,
+// given the best posterior mean at the observed points.
+func (b Bayes) score(mean, stddev, incumbent float64) float64 {
+	if b.Acquisition == UpperConfidenceBound {
+		return mean + b.Kappa*stddev
+	}
+	improvement := mean - incumbent
+	if stddev <= 0 {
+		return math.Max(improvement, 0)
+	}
+	z := improvement / stddev
+	cdf := 0.5 * math.Erfc(-z/math.Sqrt2)
+	pdf := math.Exp(-z*z/2) / math.Sqrt(2*math.Pi)
+	return improvement*cdf + stddev*pdf
+}
+
+// Propose returns n points to measure next, none of them already observed.
+// Without observations they are drawn at random. Within a batch each point is
+// chosen as if the previous ones had measured their predicted Elo, which
+// spreads the batch out. It may return fewer than n points once the space
+// runs out of unobserved points.
+func (b Bayes) Propose(space []Param, obs []Observation, n int, rng *rand.Rand) ([]Point, error) {
+	if err := validateRanges(space); err != nil {
+		return nil, err
+	}
+	if len(obs) == 0 {
+		return Random(space, n, rng)
+	}
+	seen := make(map[string]bool, len(obs)+n)
+	for _, o := range obs {
+		seen[PointAt(space, o.Values).ID()] = true
+	}
+	believed := append([]Observation(nil), obs...)
+	var points []Point
+	for len(points) < n {
+		g, err := FitGP(space, believed)
+		if err != nil {
+			return nil, err
+		}
+		incumbent := math.Inf(-1)
+		for _, x := range g.xs {
+			mean, _ := g.predictScaled(x)
+			incumbent = math.Max(incumbent, mean)
+		}
+		var best []float64
+		var bestPoint Point
+		bestScore := math.Inf(-1)
+		for _, c := range b.candidates(space, rng) {
+			pt := PointAt(space, c)
+			if seen[pt.ID()] {
+				continue
+			}
+			mean, sd := g.predictScaled(scalePoint(space, c))
+			if s := b.score(mean, sd, incumbent); s > bestScore {
+				best, bestPoint, bestScore = c, pt, s
+			}
+		}
+		if best == nil {
+			break
+		}
+		seen[bestPoint.ID()] = true
+		points = append(points, bestPoint)
+		elo, _ := g.Predict(space, best)
+		believed = append(believed, Observation{Values: best, Elo: elo})
+	}
+	if len(points) == 0 {
+		return nil, errors.New("no unobserved points left")
+	}
+	return points, nil
+}
+
+// Optimum returns the values with the highest predicted Elo, among the
+// observed points and random candidates, with that Elo and its standard
+// deviation.
+func (b Bayes) Optimum(space []Param, obs []Observation, rng *rand.Rand) (values []float64, elo, stddev float64, err error) {
+	if err := validateRanges(space); err != nil {
+		return nil, 0, 0, err
+	}
+	g, err := FitGP(space, obs)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	elo = math.Inf(-1)
+	candidates := b.candidates(space, rng)
+	for _, o := range obs {
+		candidates = append(candidates, o.Values)
+	}
+	for _, c := range candidates {
+		if mean, sd := g.Predict(space, c); mean > elo {
+			values, elo, stddev = c, mean, sd
+		}
+	}
+	return values, elo, stddev, nil
+}
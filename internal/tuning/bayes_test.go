@@ -0,0 +1,87 @@
+package tuning
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+)
+
+func TestFitGP(t *testing.T) {
+	space := []Param{{Name: "X", Min: 0, Max: 10}}
+	f := func(x float64) float64 { return 50 * math.Sin(x/3) }
+	var obs []Observation
+	for x := 0.0; x <= 10; x++ {
+		obs = append(obs, Observation{Values: []float64{x}, Elo: f(x), EloStdDev: 1})
+	}
+	g, err := FitGP(space, obs)
+	if err != nil {
+		t.Fatalf("FitGP: %v", err)
+	}
+	for _, x := range []float64{2.5, 5.5, 8.5} {
+		if elo, _ := g.Predict(space, []float64{x}); math.Abs(elo-f(x)) > 3 {
+			t.Errorf("Predict(%v) = %.1f, want about %.1f", x, elo, f(x))
+		}
+	}
+	_, near := g.Predict(space, []float64{5})
+	far, err := FitGP([]Param{{Name: "X", Min: 0, Max: 100}}, obs)
+	if err != nil {
+		t.Fatalf("FitGP: %v", err)
+	}
+	if _, sd := far.Predict([]Param{{Name: "X", Min: 0, Max: 100}}, []float64{90}); sd <= near {
+		t.Errorf("stddev far from the data = %.2f, want more than %.2f next to it", sd, near)
+	}
+}
+
+// TestBayesFindsOptimum runs a small tuning loop on a noisy two-parameter
+// Elo surface with its optimum at (0.3, 60).
+func TestBayesFindsOptimum(t *testing.T) {
+	space := []Param{{Name: "CPuct", Min: 0, Max: 1}, {Name: "Nodes", Min: 0, Max: 100, Step: 1}}
+	elo := func(v []float64) float64 {
+		return 20 - 400*math.Pow(v[0]-0.3, 2) - 0.01*math.Pow(v[1]-60, 2)
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	b := Bayes{Acquisition: ExpectedImprovement, Candidates: 500}
+	var obs []Observation
+	for round := 0; round < 8; round++ {
+		points, err := b.Propose(space, obs, 4, rng)
+		if err != nil {
+			t.Fatalf("Propose: %v", err)
+		}
+		for _, pt := range points {
+			v := make([]float64, len(space))
+			for i, p := range space {
+				v[i], _ = strconv.ParseFloat(pt[p.Name], 64)
+			}
+			obs = append(obs, Observation{Values: v, Elo: elo(v) + rng.NormFloat64()*2, EloStdDev: 2})
+		}
+	}
+	values, best, sd, err := b.Optimum(space, obs, rng)
+	if err != nil {
+		t.Fatalf("Optimum: %v", err)
+	}
+	if math.Abs(values[0]-0.3) > 0.1 || math.Abs(values[1]-60) > 20 {
+		t.Errorf("optimum = %v, want about [0.3 60]", values)
+	}
+	if math.Abs(best-20) > 5 || sd <= 0 || sd > 10 {
+		t.Errorf("optimum Elo = %.1f ± %.1f, want about 20 with a small positive stddev", best, sd)
+	}
+}
+
+func TestBayesProposeDistinct(t *testing.T) {
+	space := []Param{{Name: "Threads", Min: 1, Max: 4, Step: 1}}
+	obs := []Observation{{Values: []float64{1}, Elo: 0, EloStdDev: 5}, {Values: []float64{2}, Elo: 10, EloStdDev: 5}}
+	points, err := Bayes{Acquisition: UpperConfidenceBound, Kappa: 2}.Propose(space, obs, 5, rand.New(rand.NewPCG(1, 2)))
+	if err != nil {
+		t.Fatalf("Propose: %v", err)
+	}
+	// Only 3 and 4 are left unobserved
+	if len(points) != 2 || points[0]["Threads"] == points[1]["Threads"] {
+		t.Errorf("Propose = %v, want the two unobserved values", points)
+	}
+	for _, pt := range points {
+		if v := pt["Threads"]; v != "3" && v != "4" {
+			t.Errorf("proposed observed value %s", v)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package tuning
+
+import (
+	"errors"
+	"math"
+)
+
+// minNoiseVariance keeps the kernel matrix positive definite when results are
+// exact or repeated, in units of the standardized Elo.
+const minNoiseVariance = 1e-6
+
+// Hyperparameter candidates tried when fitting; the pair with the largest
+// marginal likelihood wins. Length scales are in units of a parameter's range.
+var (
+	gpLengthScales    = []float64{0.05, 0.1, 0.15, 0.2, 0.3, 0.45, 0.7, 1, 1.5, 2.5}
+	gpSignalVariances = []float64{0.25, 0.5, 1, 2, 4}
+)
+
+// Observation is the Elo measured at a point of a parameter space, with the
+// standard deviation of the measurement.
+type Observation struct {
+	Values    []float64 // One per parameter, in the parameter's own units
+	Elo       float64
+	EloStdDev float64
+}
+
+// GP is a Gaussian process regression of Elo over parameters scaled to
+// [0, 1], with a Matérn 5/2 kernel and the measurement noise of each
+// observation, like the surrogate of chess-tuning-tools.
+type GP struct {
+	xs          [][]float64
+	chol        [][]float64 // Lower Cholesky factor of the kernel matrix plus noise
+	alpha       []float64   // Kernel matrix plus noise, inverted, times the standardized Elo
+	lengthScale float64
+	signalVar   float64
+	eloMean     float64
+	eloScale    float64
+}
+
+// scale maps a parameter value to [0, 1].
+func (p Param) scale(x float64) float64 { return (x - p.Min) / (p.Max - p.Min) }
+
+// unscale is the inverse of scale.
+func (p Param) unscale(u float64) float64 { return p.Min + u*(p.Max-p.Min) }
+
+// scalePoint maps values of space to the unit cube.
+func scalePoint(space []Param, values []float64) []float64 {
+	u := make([]float64, len(space))
+	for i, p := range space {
+		u[i] = p.scale(values[i])
+	}
+	return u
+}
+
+// matern52 returns the Matérn 5/2 covariance of two points.
+func matern52(a, b []float64, lengthScale, signalVar float64) float64 {
+	var d2 float64
+	for i := range a {
+		d := a[i] - b[i]
+		d2 += d * d
+	}
+	r := math.Sqrt(5*d2) / lengthScale
+	return signalVar * (1 + r + r*r/3) * math.Exp(-r)
+}
+
+// cholesky returns the lower triangular L with L Lᵀ = m, or false if m is not
+// positive definite.
+func cholesky(m [][]float64) ([][]float64, bool) {
+	n := len(m)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := m[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, false
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, true
+}
+
+// forwardSubst solves L x = b for lower triangular L.
+func forwardSubst(l [][]float64, b []float64) []float64 {
+	x := make([]float64, len(b))
+	for i := range b {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= l[i][k] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
+
+// backSubst solves Lᵀ x = b for lower triangular L.
+func backSubst(l [][]float64, b []float64) []float64 {
+	n := len(b)
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := b[i]
+		for k := i + 1; k < n; k++ {
+			sum -= l[k][i] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
+
+// FitGP fits a Gaussian process to observations of space, choosing the
+// length scale and signal variance that maximize the marginal likelihood.
+func FitGP(space []Param, obs []Observation) (*GP, error) {
+	if len(obs) == 0 {
+		return nil, errors.New("no observations")
+	}
+	g := &GP{xs: make([][]float64, len(obs))}
+	for i, o := range obs {
+		if len(o.Values) != len(space) {
+			return nil, errors.New("observation does not match the parameter space")
+		}
+		g.xs[i] = scalePoint(space, o.Values)
+		g.eloMean += o.Elo / float64(len(obs))
+	}
+	var variance float64
+	for _, o := range obs {
+		variance += (o.Elo - g.eloMean) * (o.Elo - g.eloMean) / float64(len(obs))
+	}
+	g.eloScale = math.Sqrt(variance)
+	if g.eloScale < 1e-9 {
+		g.eloScale = 1
+	}
+	y := make([]float64, len(obs))
+	noise := make([]float64, len(obs))
+	for i, o := range obs {
+		y[i] = (o.Elo - g.eloMean) / g.eloScale
+		sd := o.EloStdDev / g.eloScale
+		noise[i] = math.Max(sd*sd, minNoiseVariance)
+	}
+
+	best := math.Inf(-1)
+	for _, ls := range gpLengthScales {
+		for _, sv := range gpSignalVariances {
+			k := make([][]float64, len(obs))
+			for i := range k {
+				k[i] = make([]float64, len(obs))
+				for j := range k[i] {
+					k[i][j] = matern52(g.xs[i], g.xs[j], ls, sv)
+				}
+				k[i][i] += noise[i]
+			}
+			l, ok := cholesky(k)
+			if !ok {
+				continue
+			}
+			alpha := backSubst(l, forwardSubst(l, y))
+			// log p(y) = -yᵀα/2 - Σ log L_ii - n log(2π)/2; the constant does not matter
+			lml := 0.0
+			for i := range y {
+				lml -= y[i]*alpha[i]/2 + math.Log(l[i][i])
+			}
+			if lml > best {
+				best = lml
+				g.chol, g.alpha, g.lengthScale, g.signalVar = l, alpha, ls, sv
+			}
+		}
+	}
+	if g.chol == nil {
+		return nil, errors.New("kernel matrix is not positive definite")
+	}
+	return g, nil
+}
+
+// predictScaled returns the posterior mean and standard deviation of the
+// standardized Elo at a point of the unit cube.
+func (g *GP) predictScaled(u []float64) (mean, stddev float64) {
+	k := make([]float64, len(g.xs))
+	for i, x := range g.xs {
+		k[i] = matern52(u, x, g.lengthScale, g.signalVar)
+		mean += k[i] * g.alpha[i]
+	}
+	v := forwardSubst(g.chol, k)
+	variance := g.signalVar
+	for _, vi := range v {
+		variance -= vi * vi
+	}
+	return mean, math.Sqrt(math.Max(variance, 0))
+}
+
+// Predict returns the posterior mean and standard deviation of the Elo at
+// values of the space the process was fitted to.
+func (g *GP) Predict(space []Param, values []float64) (elo, stddev float64) {
+	mean, sd := g.predictScaled(scalePoint(space, values))
+	return g.eloMean + mean*g.eloScale, sd * g.eloScale
+}
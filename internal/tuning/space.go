@@ -0,0 +1,233 @@
+// Package tuning generates the parameter sets of engine tuning jobs.
+package tuning
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// continuousDecimals is how many decimals values sampled from a range
+// without a step are rounded to.
+const continuousDecimals = 6
+
+// maxSteps caps how many values a range with a step may take, so that its
+// size fits an int on every platform.
+const maxSteps = math.MaxInt32
+
+// Param is one tunable UCI option. It either takes one of Values, or ranges
+// from Min to Max in increments of Step. A zero Step makes the range
+// continuous, which only random sampling supports.
+type Param struct {
+	Name   string
+	Min    float64
+	Max    float64
+	Step   float64
+	Values []string
+}
+
+// Point assigns a value to every parameter of a space, keyed by UCI option name.
+type Point map[string]string
+
+// ID returns an identifier derived from the assigned values only, so the same
+// point always gets the same ID whatever the order of the space.
+func (p Point) ID() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\n", name, p[name])
+	}
+	return "ps-" + hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// discrete reports whether the parameter takes finitely many values.
+func (p Param) discrete() bool { return len(p.Values) > 0 || p.Step > 0 }
+
+// validate checks a parameter on its own.
+func (p Param) validate() error {
+	if p.Name == "" {
+		return errors.New("parameter without a name")
+	}
+	if len(p.Values) > 0 {
+		if p.Min != 0 || p.Max != 0 || p.Step != 0 {
+			return fmt.Errorf("%s: values and a range are mutually exclusive", p.Name)
+		}
+		seen := make(map[string]bool, len(p.Values))
+		for _, v := range p.Values {
+			if seen[v] {
+				return fmt.Errorf("%s: duplicate value %q", p.Name, v)
+			}
+			seen[v] = true
+		}
+		return nil
+	}
+	for _, x := range []float64{p.Min, p.Max, p.Step} {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return fmt.Errorf("%s: range must be finite", p.Name)
+		}
+	}
+	if p.Max <= p.Min {
+		return fmt.Errorf("%s: max must be greater than min", p.Name)
+	}
+	if p.Step < 0 {
+		return fmt.Errorf("%s: step must not be negative", p.Name)
+	}
+	if p.Step > 0 && !((p.Max-p.Min)/p.Step < maxSteps) {
+		return fmt.Errorf("%s: step is too small for the range", p.Name)
+	}
+	return nil
+}
+
+// size returns how many values a discrete parameter takes.
+func (p Param) size() int {
+	if len(p.Values) > 0 {
+		return len(p.Values)
+	}
+	// The epsilon keeps Max on the grid despite rounding, e.g. 0.1 to 0.3 by 0.1
+	return int(math.Floor((p.Max-p.Min)/p.Step+1e-9)) + 1
+}
+
+// value returns the i-th value of a discrete parameter.
+func (p Param) value(i int) string {
+	if len(p.Values) > 0 {
+		return p.Values[i]
+	}
+	decimals := max(decimalPlaces(p.Min), decimalPlaces(p.Step))
+	return formatValue(p.Min+float64(i)*p.Step, decimals)
+}
+
+// sample draws a value uniformly.
+func (p Param) sample(rng *rand.Rand) string {
+	if p.discrete() {
+		return p.value(rng.IntN(p.size()))
+	}
+	return formatValue(p.Min+rng.Float64()*(p.Max-p.Min), continuousDecimals)
+}
+
+// decimalPlaces returns the number of decimals in the shortest representation of x.
+func decimalPlaces(x float64) int {
+	s := strconv.FormatFloat(x, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// formatValue formats x with at most decimals decimals and no trailing zeros.
+func formatValue(x float64, decimals int) string {
+	s := strconv.FormatFloat(x, 'f', decimals, 64)
+	if strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+// validateSpace checks every parameter and that names are unique.
+func validateSpace(space []Param) error {
+	if len(space) == 0 {
+		return errors.New("empty parameter space")
+	}
+	names := make(map[string]bool, len(space))
+	for _, p := range space {
+		if err := p.validate(); err != nil {
+			return err
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate parameter %s", p.Name)
+		}
+		names[p.Name] = true
+	}
+	return nil
+}
+
+// Grid returns every combination of the parameters' values, the first
+// parameter varying slowest. It fails if there would be more than limit points.
+func Grid(space []Param, limit int) ([]Point, error) {
+	if err := validateSpace(space); err != nil {
+		return nil, err
+	}
+	total := 1
+	for _, p := range space {
+		if !p.discrete() {
+			return nil, fmt.Errorf("%s: grid search needs a step or explicit values", p.Name)
+		}
+		n := p.size()
+		if n > limit || total > limit/n {
+			return nil, fmt.Errorf("grid has more than %d points", limit)
+		}
+		total *= n
+	}
+
+	points := make([]Point, 0, total)
+	idx := make([]int, len(space))
+	for range total {
+		pt := make(Point, len(space))
+		for i, p := range space {
+			pt[p.Name] = p.value(idx[i])
+		}
+		points = append(points, pt)
+		// Advance the last parameter fastest, like an odometer
+		for i := len(space) - 1; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < space[i].size() {
+				break
+			}
+			idx[i] = 0
+		}
+	}
+	return points, nil
+}
+
+// Random draws n distinct points, each parameter uniformly. It fails if the
+// space has fewer than n points.
+func Random(space []Param, n int, rng *rand.Rand) ([]Point, error) {
+	if err := validateSpace(space); err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, errors.New("sample count must be positive")
+	}
+	total := 1
+	finite := true
+	for _, p := range space {
+		if !p.discrete() || total > math.MaxInt32/p.size() {
+			finite = false
+			break
+		}
+		total *= p.size()
+	}
+	if finite && total < n {
+		return nil, fmt.Errorf("space has only %d points, fewer than %d samples", total, n)
+	}
+
+	points := make([]Point, 0, n)
+	seen := make(map[string]bool, n)
+	// Rejecting duplicates only stalls when n is close to the size of the space
+	for attempts := 0; len(points) < n; attempts++ {
+		if attempts == 100*n {
+			return nil, fmt.Errorf("could only draw %d distinct points", len(points))
+		}
+		pt := make(Point, len(space))
+		for _, p := range space {
+			pt[p.Name] = p.sample(rng)
+		}
+		if id := pt.ID(); !seen[id] {
+			seen[id] = true
+			points = append(points, pt)
+		}
+	}
+	return points, nil
+}
@@ -0,0 +1,119 @@
+package tuning
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestGrid(t *testing.T) {
+	space := []Param{
+		{Name: "CPuct", Min: 1, Max: 1.5, Step: 0.25},
+		{Name: "FpuStrategy", Values: []string{"reduction", "absolute"}},
+	}
+	points, err := Grid(space, 100)
+	if err != nil {
+		t.Fatalf("Grid: %v", err)
+	}
+	want := []Point{
+		{"CPuct": "1", "FpuStrategy": "reduction"},
+		{"CPuct": "1", "FpuStrategy": "absolute"},
+		{"CPuct": "1.25", "FpuStrategy": "reduction"},
+		{"CPuct": "1.25", "FpuStrategy": "absolute"},
+		{"CPuct": "1.5", "FpuStrategy": "reduction"},
+		{"CPuct": "1.5", "FpuStrategy": "absolute"},
+	}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("Grid = %v, want %v", points, want)
+	}
+
+	if _, err := Grid(space, 5); err == nil {
+		t.Error("Grid over limit returned no error")
+	}
+	if _, err := Grid([]Param{{Name: "CPuct", Min: 1, Max: 2}}, 100); err == nil {
+		t.Error("Grid over continuous range returned no error")
+	}
+}
+
+func TestGridRounding(t *testing.T) {
+	points, err := Grid([]Param{{Name: "X", Min: 0.1, Max: 0.3, Step: 0.1}}, 100)
+	if err != nil {
+		t.Fatalf("Grid: %v", err)
+	}
+	var got []string
+	for _, pt := range points {
+		got = append(got, pt["X"])
+	}
+	if want := []string{"0.1", "0.2", "0.3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+}
+
+func TestPointID(t *testing.T) {
+	a := Point{"CPuct": "1.25", "FpuValue": "0.3"}
+	b := Point{"FpuValue": "0.3", "CPuct": "1.25"}
+	if a.ID() != b.ID() {
+		t.Errorf("IDs of equal points differ: %s, %s", a.ID(), b.ID())
+	}
+	if c := (Point{"CPuct": "1.5", "FpuValue": "0.3"}); a.ID() == c.ID() {
+		t.Errorf("IDs of different points are both %s", a.ID())
+	}
+}
+
+func TestRandom(t *testing.T) {
+	space := []Param{
+		{Name: "CPuct", Min: 1, Max: 3},
+		{Name: "Threads", Min: 1, Max: 4, Step: 1},
+	}
+	points, err := Random(space, 50, rand.New(rand.NewPCG(1, 2)))
+	if err != nil {
+		t.Fatalf("Random: %v", err)
+	}
+	if len(points) != 50 {
+		t.Fatalf("len(points) = %d, want 50", len(points))
+	}
+	ids := map[string]bool{}
+	for _, pt := range points {
+		ids[pt.ID()] = true
+		switch pt["Threads"] {
+		case "1", "2", "3", "4":
+		default:
+			t.Errorf("Threads = %q, want 1 to 4", pt["Threads"])
+		}
+	}
+	if len(ids) != 50 {
+		t.Errorf("%d distinct points, want 50", len(ids))
+	}
+
+	again, _ := Random(space, 50, rand.New(rand.NewPCG(1, 2)))
+	if !reflect.DeepEqual(points, again) {
+		t.Error("Random with the same seed returned different points")
+	}
+	if _, err := Random(space[1:], 5, rand.New(rand.NewPCG(1, 2))); err == nil {
+		t.Error("Random with more samples than points returned no error")
+	}
+	// Too many steps to count would overflow the size of the range
+	tiny := []Param{{Name: "CPuct", Min: 0, Max: 1e18, Step: 1e-9}}
+	if _, err := Random(tiny, 5, rand.New(rand.NewPCG(1, 2))); err == nil {
+		t.Error("Random over a range with too many steps returned no error")
+	}
+}
+
+func TestValidateSpace(t *testing.T) {
+	for _, space := range [][]Param{
+		nil,
+		{{Min: 0, Max: 1}},
+		{{Name: "X", Min: 1, Max: 1}},
+		{{Name: "X", Min: 0, Max: 1, Step: -1}},
+		{{Name: "X", Min: 0, Max: 1e18, Step: 1e-9}},
+		{{Name: "X", Min: -math.MaxFloat64, Max: math.MaxFloat64, Step: 1}},
+		{{Name: "X", Values: []string{"a", "a"}}},
+		{{Name: "X", Values: []string{"a"}, Max: 1}},
+		{{Name: "X", Values: []string{"a"}}, {Name: "X", Values: []string{"b"}}},
+	} {
+		if err := validateSpace(space); err == nil {
+			t.Errorf("validateSpace(%v) returned no error", space)
+		}
+	}
+}
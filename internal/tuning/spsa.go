@@ -0,0 +1,118 @@
+package tuning
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Spall's recommended decay exponents of the SPSA gain sequences.
+const (
+	DefaultAlpha = 0.602
+	DefaultGamma = 0.101
+)
+
+// SPSA holds the gain sequences of simultaneous perturbation stochastic
+// approximation: at iteration k the values are perturbed by
+// c_k = C / (k+1)^Gamma and moved by a_k = A / (k+1+Stability)^Alpha times the
+// estimated gradient. Both are in units of a parameter's range, so one
+// configuration suits parameters of any scale.
+type SPSA struct {
+	A         float64
+	C         float64
+	Stability float64 // The "A" of Spall's paper
+	Alpha     float64
+	Gamma     float64
+}
+
+// Perturbation is the pair of points played against each other in one iteration.
+type Perturbation struct {
+	Delta []float64 // +1 or -1 per parameter
+	CK    float64
+	Plus  []float64
+	Minus []float64
+}
+
+// Validate checks the gains, and that every parameter of space is a range
+// containing its start value.
+func (s SPSA) Validate(space []Param, start []float64) error {
+	if err := validateRanges(space); err != nil {
+		return err
+	}
+	if !(s.A > 0) || !(s.C > 0) || !(s.Alpha > 0) || !(s.Gamma > 0) {
+		return errors.New("a, c, alpha and gamma must be positive")
+	}
+	if !(s.Stability >= 0) || math.IsInf(s.A+s.C+s.Stability+s.Alpha+s.Gamma, 0) {
+		return errors.New("A must be finite and not negative")
+	}
+	if len(start) != len(space) {
+		return errors.New("one start value per parameter is needed")
+	}
+	for i, p := range space {
+		if start[i] < p.Min || start[i] > p.Max {
+			return fmt.Errorf("%s: start value outside of range", p.Name)
+		}
+	}
+	return nil
+}
+
+// CK returns the perturbation size of iteration k.
+func (s SPSA) CK(k int) float64 { return s.C / math.Pow(float64(k+1), s.Gamma) }
+
+// AK returns the step size of iteration k.
+func (s SPSA) AK(k int) float64 { return s.A / math.Pow(float64(k+1)+s.Stability, s.Alpha) }
+
+// Perturb draws a random direction and returns the points c_k away from
+// values on either side of it, clamped to the parameters' ranges.
+func (s SPSA) Perturb(space []Param, values []float64, k int, rng *rand.Rand) Perturbation {
+	pt := Perturbation{
+		Delta: make([]float64, len(space)),
+		CK:    s.CK(k),
+		Plus:  make([]float64, len(space)),
+		Minus: make([]float64, len(space)),
+	}
+	for i, p := range space {
+		pt.Delta[i] = 1
+		if rng.IntN(2) == 0 {
+			pt.Delta[i] = -1
+		}
+		shift := pt.CK * pt.Delta[i] * (p.Max - p.Min)
+		pt.Plus[i] = p.clamp(values[i] + shift)
+		pt.Minus[i] = p.clamp(values[i] - shift)
+	}
+	return pt
+}
+
+// Update moves values along the gradient estimated from a perturbation's
+// score: the plus point's wins minus losses against the minus point.
+func (s SPSA) Update(space []Param, values, delta []float64, ck float64, k int, score float64) []float64 {
+	ak := s.AK(k)
+	next := make([]float64, len(space))
+	for i, p := range space {
+		gradient := score / (2 * ck * delta[i])
+		next[i] = p.clamp(values[i] + ak*gradient*(p.Max-p.Min))
+	}
+	return next
+}
+
+// clamp limits x to the parameter's range.
+func (p Param) clamp(x float64) float64 { return math.Min(math.Max(x, p.Min), p.Max) }
+
+// Format formats x as the parameter's UCI option value, rounded to its step.
+func (p Param) Format(x float64) string {
+	if p.Step <= 0 {
+		return formatValue(x, continuousDecimals)
+	}
+	i := int(math.Round((x - p.Min) / p.Step))
+	return p.value(max(0, min(i, p.size()-1)))
+}
+
+// PointAt formats a value per parameter as a point of space.
+func PointAt(space []Param, values []float64) Point {
+	pt := make(Point, len(space))
+	for i, p := range space {
+		pt[p.Name] = p.Format(values[i])
+	}
+	return pt
+}
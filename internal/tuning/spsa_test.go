@@ -0,0 +1,63 @@
+package tuning
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSPSAValidate(t *testing.T) {
+	space := []Param{{Name: "CPuct", Min: 1, Max: 3}}
+	s := SPSA{A: 1, C: 0.05, Alpha: DefaultAlpha, Gamma: DefaultGamma}
+	if err := s.Validate(space, []float64{2}); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	if err := s.Validate(space, []float64{4}); err == nil {
+		t.Error("Validate with start outside range returned no error")
+	}
+	if err := (SPSA{C: 0.05, Alpha: 1, Gamma: 1}).Validate(space, []float64{2}); err == nil {
+		t.Error("Validate without a returned no error")
+	}
+	if err := s.Validate([]Param{{Name: "X", Values: []string{"a", "b"}}}, []float64{0}); err == nil {
+		t.Error("Validate with explicit values returned no error")
+	}
+}
+
+func TestSPSAPerturb(t *testing.T) {
+	space := []Param{{Name: "CPuct", Min: 1, Max: 3}, {Name: "Threads", Min: 1, Max: 8, Step: 1}}
+	s := SPSA{A: 1, C: 0.1, Alpha: DefaultAlpha, Gamma: DefaultGamma}
+	pt := s.Perturb(space, []float64{2, 1}, 0, rand.New(rand.NewPCG(1, 2)))
+	if pt.CK != 0.1 {
+		t.Errorf("CK = %v, want 0.1", pt.CK)
+	}
+	if got, want := math.Abs(pt.Plus[0]-pt.Minus[0]), 0.4; math.Abs(got-want) > 1e-12 {
+		t.Errorf("CPuct plus and minus differ by %v, want %v", got, want)
+	}
+	// Threads starts at its minimum, so one side is clamped
+	if math.Min(pt.Plus[1], pt.Minus[1]) != 1 || math.Abs(math.Max(pt.Plus[1], pt.Minus[1])-1.7) > 1e-12 {
+		t.Errorf("Threads plus, minus = %v, %v, want 1 and 1.7", pt.Plus[1], pt.Minus[1])
+	}
+	if got := PointAt(space, []float64{1.7, 1.7})["Threads"]; got != "2" {
+		t.Errorf("Threads formatted as %q, want 2", got)
+	}
+}
+
+// TestSPSAConverges tunes a parameter against a noiseless concave score with
+// its optimum at 0.7 and checks it gets there.
+func TestSPSAConverges(t *testing.T) {
+	space := []Param{{Name: "X", Min: 0, Max: 1}, {Name: "Y", Min: -10, Max: 10}}
+	values := []float64{0.2, 8}
+	s := SPSA{A: 0.5, C: 0.1, Stability: 10, Alpha: DefaultAlpha, Gamma: DefaultGamma}
+	strength := func(v []float64) float64 {
+		return -math.Pow(v[0]-0.7, 2) - math.Pow((v[1]+2)/20, 2)
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	for k := 0; k < 500; k++ {
+		pt := s.Perturb(space, values, k, rng)
+		score := strength(pt.Plus) - strength(pt.Minus)
+		values = s.Update(space, values, pt.Delta, pt.CK, k, score)
+	}
+	if math.Abs(values[0]-0.7) > 0.02 || math.Abs(values[1]+2) > 0.4 {
+		t.Errorf("values after 500 iterations = %v, want about [0.7 -2]", values)
+	}
+}
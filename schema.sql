@@ -91,7 +91,8 @@ CREATE TABLE training_games (
   version BIGINT,
   compacted BOOLEAN,
   engine_version TEXT,
-  resign_fp_threshold DOUBLE PRECISION
+  resign_fp_threshold DOUBLE PRECISION,
+  upload_failed BOOLEAN NOT NULL DEFAULT false -- The game's blobs could not be stored, so it has no data
 );
 CREATE INDEX idx_training_games_created_at ON training_games(created_at);
 CREATE INDEX idx_training_games_user_id ON training_games(user_id);
@@ -117,7 +118,10 @@ CREATE TABLE auth_tokens (
   client_version TEXT,
   client_host TEXT,
   gpu_type TEXT,
-  gpu_id INTEGER
+  gpu_id INTEGER,
+  status TEXT NOT NULL DEFAULT 'ACTIVE', -- "ACTIVE", "REVOKED" (by its owner) or "SUSPENDED" (by operators)
+  revoked_at TIMESTAMPTZ,
+  scope TEXT NOT NULL DEFAULT 'CLIENT' -- "CLIENT", or "ADMIN" for tokens allowed to call AdminService
 );
 CREATE INDEX idx_auth_tokens_user_id ON auth_tokens(user_id);
 CREATE INDEX idx_auth_tokens_last_used_at ON auth_tokens(last_used_at);
@@ -140,9 +144,36 @@ CREATE TABLE tasks (
   updated_at TIMESTAMPTZ NOT NULL,
   task_type TEXT, -- e.g., "TRAINING", "SPRT", "TUNE"
   status TEXT,
-  description TEXT
+  description TEXT,
+  weight DOUBLE PRECISION NOT NULL DEFAULT 1 -- Share of client throughput relative to other active tasks; 0 pauses the task
 );
 
+-- TaskAssignment table (one row per unit of work handed to a client)
+CREATE TABLE task_assignments (
+  id BIGSERIAL PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  task_id TEXT UNIQUE NOT NULL, -- External identifier returned to clients
+  task_type TEXT, -- e.g., "TRAINING", "MATCH", "SPRT", "TUNING"
+  parent_task_id BIGINT REFERENCES tasks(id),
+  network_id BIGINT REFERENCES networks(id), -- Network handed out with the assignment, if any
+  match_game_id BIGINT REFERENCES match_games(id), -- Game reserved for a MATCH assignment
+  assigned_token_id BIGINT REFERENCES auth_tokens(id),
+  assigned_at TIMESTAMPTZ,
+  last_heartbeat_at TIMESTAMPTZ,
+  status TEXT,
+  status_reason TEXT, -- Why the assignment left ACTIVE, e.g. "missed heartbeats"
+  cancelled_at TIMESTAMPTZ,
+  completed_at TIMESTAMPTZ,
+  client_version TEXT, -- As reported by the client when the task was assigned
+  engine_version TEXT,
+  last_sequence BIGINT NOT NULL DEFAULT 0, -- Sequence number of the last training, SPRT or tuning report counted
+  games_reported INTEGER NOT NULL DEFAULT 0, -- Games recorded for the assignment, its weight in scheduling
+  held_sprt_game SMALLINT -- Candidate half points of an SPRT game waiting for its pair to finish
+);
+CREATE INDEX idx_task_assignments_assigned_token_id ON task_assignments(assigned_token_id);
+CREATE INDEX idx_task_assignments_status_last_heartbeat_at ON task_assignments(status, last_heartbeat_at);
+
 -- TrainingTask table
 CREATE TABLE training_tasks (
   id BIGSERIAL PRIMARY KEY,
@@ -154,7 +185,8 @@ CREATE TABLE training_tasks (
   match_book_id BIGINT REFERENCES books(id),
   train_parameters TEXT,
   match_parameters TEXT,
-  best_network_id BIGINT REFERENCES networks(id)
+  best_network_id BIGINT REFERENCES networks(id),
+  active BOOLEAN NOT NULL DEFAULT false -- Active training tasks share clients with other active tasks by tasks.weight (SetTaskWeight)
 );
 
 -- MatchTask table
@@ -190,7 +222,21 @@ CREATE TABLE sprt_tasks (
   time_control_type VARCHAR(32),
   base_time_seconds DOUBLE PRECISION,
   increment_seconds DOUBLE PRECISION,
-  nodes_per_move BIGINT
+  nodes_per_move BIGINT,
+  elo0 DOUBLE PRECISION NOT NULL, -- SPRT null hypothesis (normalized Elo)
+  elo1 DOUBLE PRECISION NOT NULL, -- SPRT alternative hypothesis (normalized Elo)
+  alpha DOUBLE PRECISION NOT NULL DEFAULT 0.05,
+  beta DOUBLE PRECISION NOT NULL DEFAULT 0.05,
+  ll INTEGER NOT NULL DEFAULT 0, -- Pentanomial pair counts, from the candidate's side
+  ld INTEGER NOT NULL DEFAULT 0,
+  dd INTEGER NOT NULL DEFAULT 0, -- Includes WL pairs
+  dw INTEGER NOT NULL DEFAULT 0,
+  ww INTEGER NOT NULL DEFAULT 0,
+  unpaired_losses INTEGER NOT NULL DEFAULT 0, -- Games whose pair partner never finished
+  unpaired_draws INTEGER NOT NULL DEFAULT 0,
+  unpaired_wins INTEGER NOT NULL DEFAULT 0,
+  llr DOUBLE PRECISION NOT NULL DEFAULT 0,
+  verdict TEXT -- NULL while running, then "ACCEPTED" or "REJECTED"
 );
 
 -- See https://github.com/LeelaChessZero/OpenBench/blob/master/OpenBench/models.py for better table definitions. Must decide what is needed. At minimum, the following tables should be considered:
@@ -208,10 +254,25 @@ CREATE TABLE tune_tasks (
   tune_network_id BIGINT REFERENCES networks(id),
   opening_book_id BIGINT REFERENCES books(id),
   games_per_param_set INTEGER,
+  mode TEXT NOT NULL DEFAULT 'GRID', -- How the parameter sets are generated: "GRID", "RANDOM", "SPSA" or "BAYESIAN"
+  params_args TEXT, -- Engine parameters shared by every parameter set
+  params_uci_options TEXT,
   time_control_type VARCHAR(32),
   base_time_seconds DOUBLE PRECISION,
   increment_seconds DOUBLE PRECISION,
-  nodes_per_move BIGINT
+  nodes_per_move BIGINT,
+  spsa_a DOUBLE PRECISION NOT NULL DEFAULT 0, -- SPSA gains, see internal/tuning/spsa.go
+  spsa_c DOUBLE PRECISION NOT NULL DEFAULT 0,
+  spsa_stability DOUBLE PRECISION NOT NULL DEFAULT 0,
+  spsa_alpha DOUBLE PRECISION NOT NULL DEFAULT 0,
+  spsa_gamma DOUBLE PRECISION NOT NULL DEFAULT 0,
+  spsa_iterations INTEGER NOT NULL DEFAULT 0, -- Iterations to run
+  spsa_completed INTEGER NOT NULL DEFAULT 0, -- Iterations that played all their games
+  bayes_initial_points INTEGER NOT NULL DEFAULT 0, -- Bayesian optimization, see internal/tuning/bayes.go
+  bayes_batch_size INTEGER NOT NULL DEFAULT 0, -- Parameter sets proposed whenever none are pending
+  bayes_max_points INTEGER NOT NULL DEFAULT 0, -- Parameter sets to measure in total
+  bayes_acquisition TEXT NOT NULL DEFAULT '', -- "EI" or "UCB"
+  bayes_kappa DOUBLE PRECISION NOT NULL DEFAULT 0
 );
 
 -- TuneParamSet table
@@ -220,8 +281,48 @@ CREATE TABLE tune_param_sets (
   tune_task_id BIGINT REFERENCES tune_tasks(id),
   param_set_id TEXT,
   params_args TEXT,
-  params_uci_options TEXT
+  params_uci_options TEXT,
+  games_played INTEGER NOT NULL DEFAULT 0,
+  ll INTEGER NOT NULL DEFAULT 0, -- Pentanomial pair counts for this set, from its own side
+  ld INTEGER NOT NULL DEFAULT 0,
+  dd INTEGER NOT NULL DEFAULT 0,
+  dw INTEGER NOT NULL DEFAULT 0,
+  ww INTEGER NOT NULL DEFAULT 0,
+  elo DOUBLE PRECISION NOT NULL DEFAULT 0, -- Logistic Elo estimate with 95% confidence interval
+  elo_lower DOUBLE PRECISION NOT NULL DEFAULT 0,
+  elo_upper DOUBLE PRECISION NOT NULL DEFAULT 0,
+  assignment_id BIGINT REFERENCES task_assignments(id) -- Assignment playing the set; NULL while it can be claimed
+);
+CREATE INDEX idx_tune_param_sets_tune_task_id ON tune_param_sets(tune_task_id);
+CREATE INDEX idx_tune_param_sets_assignment_id ON tune_param_sets(assignment_id);
+
+-- TuneParam table: the parameter space of an SPSA or Bayesian tuning task
+CREATE TABLE tune_params (
+  id BIGSERIAL PRIMARY KEY,
+  tune_task_id BIGINT NOT NULL REFERENCES tune_tasks(id) ON DELETE CASCADE,
+  name TEXT NOT NULL, -- UCI option name
+  min_value DOUBLE PRECISION NOT NULL,
+  max_value DOUBLE PRECISION NOT NULL,
+  step DOUBLE PRECISION NOT NULL DEFAULT 0, -- Values sent to clients are rounded to this
+  value DOUBLE PRECISION NOT NULL DEFAULT 0, -- SPSA only: current estimate, updated after every result
+  UNIQUE (tune_task_id, name)
+);
+
+-- SpsaIteration table: one perturbation pair, played as the parameter sets
+-- "spsa-<id>-plus" and "spsa-<id>-minus"
+CREATE TABLE spsa_iterations (
+  id BIGSERIAL PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL,
+  tune_task_id BIGINT NOT NULL REFERENCES tune_tasks(id) ON DELETE CASCADE,
+  iteration INTEGER NOT NULL, -- spsa_completed when the pair was drawn
+  delta TEXT NOT NULL, -- JSON-encoded map[string]float64 of +1 or -1 per parameter
+  ck DOUBLE PRECISION NOT NULL, -- Perturbation size, in units of each parameter's range
+  games_played INTEGER NOT NULL DEFAULT 0,
+  score INTEGER NOT NULL DEFAULT 0, -- Wins minus losses of the plus set
+  completed_at TIMESTAMPTZ,
+  values_after TEXT -- JSON-encoded map[string]float64 of tune_params once completed
 );
+CREATE INDEX idx_spsa_iterations_tune_task_id ON spsa_iterations(tune_task_id);
 
 -- Notes: Tuning tasks will store data into Redis for processing externally.
 